@@ -0,0 +1,1160 @@
+package dataframe
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"time"
+)
+
+// Arrow IPC format, as defined in Schema.fbs, Message.fbs and File.fbs
+// of the Arrow columnar format. Nested types are not supported.
+
+const (
+	arrowMagic        = "ARROW1"
+	arrowContinuation = 0xFFFFFFFF
+	arrowMetadataV5   = 4
+)
+
+// arrow message header types
+const (
+	arrowHeaderSchema          = 1
+	arrowHeaderDictionaryBatch = 2
+	arrowHeaderRecordBatch     = 3
+)
+
+// arrow type ids, by the index in the Type union
+const (
+	arrowTypeNull          = 1
+	arrowTypeInt           = 2
+	arrowTypeFloatingPoint = 3
+	arrowTypeBinary        = 4
+	arrowTypeUtf8          = 5
+	arrowTypeBool          = 6
+	arrowTypeDecimal       = 7
+	arrowTypeDate          = 8
+	arrowTypeTime          = 9
+	arrowTypeTimestamp     = 10
+	arrowTypeDuration      = 18
+	arrowTypeLargeBinary   = 19
+	arrowTypeLargeUtf8     = 20
+)
+
+// arrow time units
+const (
+	arrowSecond      = 0
+	arrowMillisecond = 1
+	arrowMicrosecond = 2
+	arrowNanosecond  = 3
+)
+
+// arrow body compression codecs
+const (
+	arrowLZ4Frame = 0
+	arrowZstd     = 1
+)
+
+var errArrowCorrupt = errors.New("arrow: corrupt input")
+
+// ArrowFormat defines the Arrow IPC format.
+type ArrowFormat int
+
+const (
+	// ArrowFormat_Stream is the streaming format of a schema
+	// and record batches, to be read from the beginning to the end.
+	ArrowFormat_Stream ArrowFormat = iota
+
+	// ArrowFormat_File is the file format, also known as Feather v2,
+	// which has the footer with the locations of the record batches.
+	ArrowFormat_File
+)
+
+// ArrowWriteOptions configures writing Arrow IPC.
+type ArrowWriteOptions struct {
+	// Format is the IPC format.
+	Format ArrowFormat
+
+	// BatchSize is the maximum number of rows in a record batch.
+	// Zero or negative writes each Frame in one record batch.
+	BatchSize int
+}
+
+// arrowField is the Field with its Arrow type.
+type arrowField struct {
+	Field
+
+	typeID   uint8
+	bitWidth int  // Int, Time, Decimal
+	signed   bool // Int
+	unit     int16
+	scale    int32 // Decimal
+
+	// timezone is the time zone of Timestamp, or empty for naive times.
+	timezone string
+	loc      *time.Location
+
+	// dictionary is the id of the dictionary, and index is the field
+	// of the dictionary indices, if dictionary encoded.
+	dictionary *int64
+	index      *arrowField
+}
+
+// arrowTimeZone returns the time zone name of the time, which is
+// an IANA name if known, or a fixed offset such as "+09:00".
+func arrowTimeZone(t time.Time) string {
+	loc := t.Location()
+	switch name := loc.String(); name {
+	case "UTC":
+		return "UTC"
+	case "", "Local":
+	default:
+		if _, err := time.LoadLocation(name); err == nil {
+			return name
+		}
+	}
+	_, offset := t.Zone()
+	sign := '+'
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	return fmt.Sprintf("%c%02d:%02d", sign, offset/3600, offset%3600/60)
+}
+
+// arrowLocation returns the location of the time zone.
+// Unknown time zones are read in UTC.
+func arrowLocation(tz string) *time.Location {
+	switch tz {
+	case "", "UTC", "Z", "+00:00":
+		return time.UTC
+	}
+	if len(tz) == 6 && (tz[0] == '+' || tz[0] == '-') && tz[3] == ':' {
+		var h, m int
+		if _, err := fmt.Sscanf(tz[1:], "%02d:%02d", &h, &m); err == nil {
+			offset := h*3600 + m*60
+			if tz[0] == '-' {
+				offset = -offset
+			}
+			return time.FixedZone(tz, offset)
+		}
+	}
+	if loc, err := time.LoadLocation(tz); err == nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// newArrowField returns the Arrow type of the Column.
+func newArrowField(col Column) arrowField {
+	af := arrowField{Field: col.Field()}
+	switch af.Type {
+	case INT64:
+		af.typeID, af.bitWidth, af.signed = arrowTypeInt, 64, true
+	case UINT64:
+		af.typeID, af.bitWidth = arrowTypeInt, 64
+	case FLOAT64:
+		af.typeID = arrowTypeFloatingPoint
+	case BOOL:
+		af.typeID = arrowTypeBool
+	case DURATION:
+		af.typeID, af.unit = arrowTypeDuration, arrowNanosecond
+	case TIME:
+		af.typeID, af.unit, af.timezone = arrowTypeTimestamp, arrowNanosecond, "UTC"
+		if v, ok := col.FrontNonNil(); ok {
+			if tv, ok := v.Time(af.Layout); ok {
+				af.timezone = arrowTimeZone(tv)
+			}
+		}
+	default:
+		af.typeID = arrowTypeUtf8
+	}
+	return af
+}
+
+// buildArrowSchema builds the Schema table.
+func buildArrowSchema(b *fbBuilder, fields []arrowField) int {
+	offs := make([]int, len(fields))
+	jfields := make([]jsonField, len(fields))
+	for i, af := range fields {
+		name := b.createString(af.Name)
+		var tz int
+		if af.timezone != "" {
+			tz = b.createString(af.timezone)
+		}
+		b.startTable(2)
+		switch af.typeID {
+		case arrowTypeInt:
+			b.addInt32(0, int32(af.bitWidth))
+			b.addBool(1, af.signed)
+		case arrowTypeFloatingPoint:
+			b.addInt16(0, 2) // DOUBLE
+		case arrowTypeTimestamp:
+			b.addInt16(0, af.unit)
+			if tz != 0 {
+				b.addOffset(1, tz)
+			}
+		case arrowTypeDuration:
+			b.addInt16(0, af.unit)
+		}
+		typ := b.endTable()
+		children := b.createOffsetVector(nil)
+
+		b.startTable(7)
+		b.addOffset(0, name)
+		b.addBool(1, af.Nullable)
+		b.addUint8(2, af.typeID)
+		b.addOffset(3, typ)
+		b.addOffset(5, children)
+		offs[i] = b.endTable()
+
+		jfields[i] = jsonField{Name: af.Name, Type: af.Type, Nullable: af.Nullable, Layout: af.Layout}
+	}
+	vec := b.createOffsetVector(offs)
+
+	schema, _ := json.Marshal(jfields)
+	key, value := b.createString(schemaMetadataKey), b.createString(string(schema))
+	b.startTable(2)
+	b.addOffset(0, key)
+	b.addOffset(1, value)
+	metadata := b.createOffsetVector([]int{b.endTable()})
+
+	b.startTable(4)
+	b.addInt16(0, 0) // little endian
+	b.addOffset(1, vec)
+	b.addOffset(2, metadata)
+	return b.endTable()
+}
+
+func buildArrowMessage(b *fbBuilder, headerType uint8, header int, bodyLength int64) []byte {
+	b.startTable(5)
+	b.addInt16(0, arrowMetadataV5)
+	b.addUint8(1, headerType)
+	b.addOffset(2, header)
+	b.addInt64(3, bodyLength)
+	return b.finish(b.endTable())
+}
+
+// arrowBody is the body of a record batch.
+type arrowBody struct {
+	buf     []byte
+	nodes   [][2]int64 // length, null count
+	buffers [][2]int64 // offset, length
+}
+
+func (ab *arrowBody) addBuffer(data []byte) {
+	ab.buffers = append(ab.buffers, [2]int64{int64(len(ab.buf)), int64(len(data))})
+	ab.buf = append(ab.buf, data...)
+	for len(ab.buf)%8 != 0 {
+		ab.buf = append(ab.buf, 0)
+	}
+}
+
+// addColumn adds the rows [start, end) of the Column.
+// Rows out of the Column are null.
+func (ab *arrowBody) addColumn(col Column, af arrowField, start, end int) error {
+	n := end - start
+	validity := make([]byte, (n+7)/8)
+	var values, data []byte
+	if af.typeID == arrowTypeBool {
+		values = make([]byte, (n+7)/8)
+	}
+	if af.typeID == arrowTypeUtf8 {
+		values = binary.LittleEndian.AppendUint32(values, 0)
+	}
+
+	nulls := 0
+	for i := 0; i < n; i++ {
+		v, err := col.Value(start + i)
+		null := err != nil || v.IsNull()
+		if null {
+			if !af.Nullable {
+				return &SchemaError{Row: start + i, Column: af.Name, Err: ErrNotNullable}
+			}
+			nulls++
+		} else {
+			validity[i/8] |= 1 << uint(i%8)
+		}
+
+		ok := true
+		switch af.Type {
+		case BOOL:
+			if !null {
+				var bv bool
+				if bv, ok = v.Bool(); bv {
+					values[i/8] |= 1 << uint(i%8)
+				}
+			}
+		case STRING:
+			if !null {
+				var s string
+				s, ok = v.String()
+				data = append(data, s...)
+			}
+			if len(data) > math.MaxInt32 {
+				return fmt.Errorf("arrow: column %q has more than 2 GiB of strings", af.Name)
+			}
+			values = binary.LittleEndian.AppendUint32(values, uint32(len(data)))
+		default:
+			if null {
+				values = binary.LittleEndian.AppendUint64(values, 0)
+				continue
+			}
+			// INT64, UINT64, FLOAT64, DURATION and TIME are
+			// encoded in 8 bytes as in Parquet
+			values, ok = appendParquetValue(values, af.Field, v)
+		}
+		if !ok {
+			s, _ := v.String()
+			return &SchemaError{Row: start + i, Column: af.Name, Value: s, Err: ErrTypeMismatch}
+		}
+	}
+
+	ab.nodes = append(ab.nodes, [2]int64{int64(n), int64(nulls)})
+	if nulls == 0 {
+		validity = nil
+	}
+	ab.addBuffer(validity)
+	ab.addBuffer(values)
+	if af.typeID == arrowTypeUtf8 {
+		ab.addBuffer(data)
+	}
+	return nil
+}
+
+func (ab *arrowBody) message(length int) []byte {
+	b := newFBBuilder()
+	nodes := b.createStructVector(len(ab.nodes), 16, 8, func(i int) {
+		b.placeUint64(uint64(ab.nodes[i][1]))
+		b.placeUint64(uint64(ab.nodes[i][0]))
+	})
+	buffers := b.createStructVector(len(ab.buffers), 16, 8, func(i int) {
+		b.placeUint64(uint64(ab.buffers[i][1]))
+		b.placeUint64(uint64(ab.buffers[i][0]))
+	})
+	b.startTable(5)
+	b.addInt64(0, int64(length))
+	b.addOffset(1, nodes)
+	b.addOffset(2, buffers)
+	return buildArrowMessage(b, arrowHeaderRecordBatch, b.endTable(), int64(len(ab.buf)))
+}
+
+// arrowBlock is the location of a record batch in the file format.
+type arrowBlock struct {
+	offset     int64
+	metaLength int32
+	bodyLength int64
+}
+
+// ArrowWriter writes Frames to io.Writer in Arrow IPC,
+// each in one or more record batches.
+type ArrowWriter struct {
+	w      *countWriter
+	schema Schema
+	opts   ArrowWriteOptions
+
+	// fields are set when the schema message is written.
+	fields []arrowField
+	blocks []arrowBlock
+	closed bool
+}
+
+// NewArrowWriter returns an ArrowWriter of the Frames of the Schema.
+// TIME columns are written in nanoseconds with the time zone of the
+// first value, and the Schema is kept in the schema metadata.
+func NewArrowWriter(w io.Writer, schema Schema, opts ArrowWriteOptions) (*ArrowWriter, error) {
+	aw := &ArrowWriter{w: &countWriter{w: w}, schema: schema, opts: opts}
+	switch opts.Format {
+	case ArrowFormat_Stream:
+	case ArrowFormat_File:
+		if _, err := io.WriteString(aw.w, arrowMagic+"\x00\x00"); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("arrow format %d is unknown", opts.Format)
+	}
+	return aw, nil
+}
+
+// writeMessage writes the encapsulated message, and returns its block.
+func (aw *ArrowWriter) writeMessage(meta, body []byte) (arrowBlock, error) {
+	blk := arrowBlock{offset: aw.w.n, bodyLength: int64(len(body))}
+	size := (len(meta) + 7) &^ 7
+	prefix := make([]byte, 8, 8+size)
+	binary.LittleEndian.PutUint32(prefix, arrowContinuation)
+	binary.LittleEndian.PutUint32(prefix[4:], uint32(size))
+	prefix = append(prefix, meta...)
+	prefix = append(prefix, make([]byte, size-len(meta))...)
+	blk.metaLength = int32(len(prefix))
+	if _, err := aw.w.Write(prefix); err != nil {
+		return blk, err
+	}
+	_, err := aw.w.Write(body)
+	return blk, err
+}
+
+func (aw *ArrowWriter) writeSchema(cols []Column) error {
+	aw.fields = make([]arrowField, len(aw.schema.Fields))
+	for i, fd := range aw.schema.Fields {
+		if cols != nil {
+			aw.fields[i] = newArrowField(cols[i])
+		} else {
+			aw.fields[i] = newArrowField(NewColumnField(fd))
+		}
+		aw.fields[i].Field = fd
+	}
+	b := newFBBuilder()
+	_, err := aw.writeMessage(buildArrowMessage(b, arrowHeaderSchema, buildArrowSchema(b, aw.fields), 0), nil)
+	return err
+}
+
+// Write writes the Frame in record batches. The Frame must have the
+// columns of the Schema. Shorter columns are padded with nulls.
+func (aw *ArrowWriter) Write(fr Frame) error {
+	if aw.closed {
+		return fmt.Errorf("arrow: writer is closed")
+	}
+	cols := make([]Column, len(aw.schema.Fields))
+	rowN := 0
+	for i, fd := range aw.schema.Fields {
+		col, err := fr.Column(fd.Name)
+		if err != nil {
+			return &SchemaError{Row: -1, Column: fd.Name, Err: ErrFieldNotFound}
+		}
+		if tp := col.DataType(); tp != fd.Type {
+			return &SchemaError{Row: -1, Column: fd.Name, Err: fmt.Errorf("%w (expected %q, got %q)", ErrTypeMismatch, fd.Type, tp)}
+		}
+		cols[i] = col
+		if n := col.Count(); rowN < n {
+			rowN = n
+		}
+	}
+	if aw.fields == nil {
+		if err := aw.writeSchema(cols); err != nil {
+			return err
+		}
+	}
+
+	size := aw.opts.BatchSize
+	if size <= 0 {
+		size = rowN
+	}
+	for start := 0; start < rowN; start += size {
+		end := start + size
+		if end > rowN {
+			end = rowN
+		}
+		ab := &arrowBody{}
+		for i, col := range cols {
+			if err := ab.addColumn(col, aw.fields[i], start, end); err != nil {
+				return err
+			}
+		}
+		blk, err := aw.writeMessage(ab.message(end-start), ab.buf)
+		if err != nil {
+			return err
+		}
+		aw.blocks = append(aw.blocks, blk)
+	}
+	return nil
+}
+
+// Close writes the end of the stream, and the footer of the file format.
+// It does not close the underlying io.Writer.
+func (aw *ArrowWriter) Close() error {
+	if aw.closed {
+		return nil
+	}
+	aw.closed = true
+	if aw.fields == nil {
+		if err := aw.writeSchema(nil); err != nil {
+			return err
+		}
+	}
+	eos := []byte{0xFF, 0xFF, 0xFF, 0xFF, 0, 0, 0, 0}
+	if _, err := aw.w.Write(eos); err != nil {
+		return err
+	}
+	if aw.opts.Format != ArrowFormat_File {
+		return nil
+	}
+
+	b := newFBBuilder()
+	schema := buildArrowSchema(b, aw.fields)
+	putBlock := func(blk arrowBlock) {
+		b.placeUint64(uint64(blk.bodyLength))
+		b.placeUint32(0) // padding
+		b.placeUint32(uint32(blk.metaLength))
+		b.placeUint64(uint64(blk.offset))
+	}
+	dicts := b.createStructVector(0, 24, 8, nil)
+	batches := b.createStructVector(len(aw.blocks), 24, 8, func(i int) { putBlock(aw.blocks[i]) })
+	b.startTable(5)
+	b.addInt16(0, arrowMetadataV5)
+	b.addOffset(1, schema)
+	b.addOffset(2, dicts)
+	b.addOffset(3, batches)
+	footer := b.finish(b.endTable())
+
+	footer = binary.LittleEndian.AppendUint32(append([]byte{}, footer...), uint32(len(footer)))
+	footer = append(footer, arrowMagic...)
+	_, err := aw.w.Write(footer)
+	return err
+}
+
+func (f *frame) WriteArrowIPC(w io.Writer, opts ArrowWriteOptions) error {
+	aw, err := NewArrowWriter(w, f.Schema(), opts)
+	if err != nil {
+		return err
+	}
+	if err := aw.Write(f); err != nil {
+		return err
+	}
+	return aw.Close()
+}
+
+// recoverFlatbuf recovers from reading out of a corrupt FlatBuffer.
+func recoverFlatbuf(err *error) {
+	if r := recover(); r != nil {
+		if r != errFlatbufCorrupt {
+			panic(r)
+		}
+		*err = errArrowCorrupt
+	}
+}
+
+func parseArrowField(ft fbTable) (arrowField, error) {
+	af := arrowField{
+		Field:  Field{Name: ft.string(0), Nullable: ft.bool(1)},
+		typeID: ft.uint8(2, 0),
+	}
+	if _, n := ft.vector(5); n > 0 {
+		return af, fmt.Errorf("arrow: nested column %q is not supported", af.Name)
+	}
+	typ, _ := ft.table(3) // absent for Null
+
+	switch af.typeID {
+	case arrowTypeNull, arrowTypeBinary, arrowTypeUtf8, arrowTypeLargeBinary, arrowTypeLargeUtf8:
+		af.Type = STRING
+	case arrowTypeInt:
+		af.bitWidth, af.signed = int(typ.int32(0, 0)), typ.bool(1)
+		af.Type = INT64
+		if !af.signed {
+			af.Type = UINT64
+		}
+		if af.bitWidth != 8 && af.bitWidth != 16 && af.bitWidth != 32 && af.bitWidth != 64 {
+			return af, fmt.Errorf("arrow: column %q has invalid bit width %d", af.Name, af.bitWidth)
+		}
+	case arrowTypeFloatingPoint:
+		af.Type = FLOAT64
+		switch typ.int16(0, 0) {
+		case 0: // HALF
+			af.bitWidth = 16
+		case 1: // SINGLE
+			af.bitWidth = 32
+		case 2: // DOUBLE
+			af.bitWidth = 64
+		default:
+			return af, errArrowCorrupt
+		}
+	case arrowTypeBool:
+		af.Type = BOOL
+	case arrowTypeDecimal:
+		af.Type = FLOAT64
+		af.scale, af.bitWidth = typ.int32(1, 0), int(typ.int32(2, 128))
+		if af.bitWidth != 32 && af.bitWidth != 64 && af.bitWidth != 128 && af.bitWidth != 256 {
+			return af, errArrowCorrupt
+		}
+	case arrowTypeDate:
+		af.Type, af.Layout = TIME, "2006-01-02"
+		af.unit, af.bitWidth = typ.int16(0, arrowMillisecond), 64
+		if af.unit == 0 { // DAY
+			af.bitWidth = 32
+		}
+	case arrowTypeTime:
+		af.Type = DURATION
+		af.unit, af.bitWidth = typ.int16(0, arrowMillisecond), int(typ.int32(1, 32))
+		if af.bitWidth != 32 && af.bitWidth != 64 {
+			return af, errArrowCorrupt
+		}
+	case arrowTypeTimestamp:
+		af.Type = TIME
+		af.unit, af.timezone, af.bitWidth = typ.int16(0, arrowSecond), typ.string(1), 64
+		af.loc = arrowLocation(af.timezone)
+	case arrowTypeDuration:
+		af.Type = DURATION
+		af.unit, af.bitWidth = typ.int16(0, arrowMillisecond), 64
+	default:
+		return af, fmt.Errorf("arrow: column %q has unsupported type %d", af.Name, af.typeID)
+	}
+
+	if dt, ok := ft.table(4); ok {
+		id := dt.int64(0, 0)
+		index := &arrowField{Field: Field{Name: af.Name, Type: INT64}, typeID: arrowTypeInt, bitWidth: 32, signed: true}
+		if it, ok := dt.table(1); ok {
+			index.bitWidth, index.signed = int(it.int32(0, 32)), it.bool(1)
+		}
+		if index.bitWidth != 8 && index.bitWidth != 16 && index.bitWidth != 32 && index.bitWidth != 64 {
+			return af, errArrowCorrupt
+		}
+		af.dictionary, af.index = &id, index
+	}
+	return af, nil
+}
+
+func arrowUnit(unit int16) time.Duration {
+	switch unit {
+	case arrowSecond:
+		return time.Second
+	case arrowMillisecond:
+		return time.Millisecond
+	case arrowMicrosecond:
+		return time.Microsecond
+	default:
+		return time.Nanosecond
+	}
+}
+
+// arrowHalf converts the IEEE 754 half-precision float.
+func arrowHalf(h uint16) float64 {
+	sign := 1.0
+	if h&0x8000 != 0 {
+		sign = -1
+	}
+	exp := int(h>>10) & 0x1F
+	frac := float64(h & 0x3FF)
+	switch exp {
+	case 0:
+		return sign * math.Ldexp(frac, -24)
+	case 0x1F:
+		if frac != 0 {
+			return math.NaN()
+		}
+		return math.Inf(int(sign))
+	default:
+		return sign * math.Ldexp(1+frac/1024, exp-15)
+	}
+}
+
+// fixedValue returns the converter from the fixed-size value
+// of the field, such as Int and Timestamp.
+func (af *arrowField) fixedValue() func(b []byte) Value {
+	width := af.bitWidth / 8
+	integer := func(b []byte) int64 {
+		var buf [8]byte
+		copy(buf[:], b[:width])
+		v := binary.LittleEndian.Uint64(buf[:])
+		if shift := uint(64 - af.bitWidth); shift > 0 {
+			return int64(v<<shift) >> shift // sign extension
+		}
+		return int64(v)
+	}
+	switch af.typeID {
+	case arrowTypeInt:
+		if !af.signed {
+			return func(b []byte) Value {
+				var buf [8]byte
+				copy(buf[:], b[:width])
+				return Uint64(binary.LittleEndian.Uint64(buf[:]))
+			}
+		}
+		return func(b []byte) Value { return Int64(integer(b)) }
+	case arrowTypeFloatingPoint:
+		switch af.bitWidth {
+		case 16:
+			return func(b []byte) Value { return Float64(arrowHalf(binary.LittleEndian.Uint16(b))) }
+		case 32:
+			return func(b []byte) Value { return Float64(math.Float32frombits(binary.LittleEndian.Uint32(b))) }
+		default:
+			return func(b []byte) Value { return Float64(math.Float64frombits(binary.LittleEndian.Uint64(b))) }
+		}
+	case arrowTypeDecimal:
+		scale := new(big.Float).SetFloat64(math.Pow10(int(af.scale)))
+		return func(b []byte) Value {
+			be := make([]byte, width)
+			for i := range be {
+				be[i] = b[width-1-i]
+			}
+			fv, _ := new(big.Float).Quo(new(big.Float).SetInt(bigEndianInt(be)), scale).Float64()
+			return Float64(fv)
+		}
+	case arrowTypeDate:
+		if af.unit == 0 {
+			return func(b []byte) Value { return GoTime(time.Unix(integer(b)*86400, 0).UTC()) }
+		}
+		return func(b []byte) Value {
+			return GoTime(time.Unix(0, 0).UTC().Add(time.Duration(integer(b)) * time.Millisecond))
+		}
+	case arrowTypeTimestamp:
+		unit, loc := arrowUnit(af.unit), af.loc
+		return func(b []byte) Value {
+			v := integer(b)
+			if unit == time.Nanosecond {
+				return GoTime(time.Unix(0, v).In(loc))
+			}
+			sec := int64(time.Second / unit)
+			return GoTime(time.Unix(v/sec, v%sec*int64(unit)).In(loc))
+		}
+	default: // Time, Duration
+		unit := arrowUnit(af.unit)
+		return func(b []byte) Value { return GoDuration(time.Duration(integer(b)) * unit) }
+	}
+}
+
+// arrowBatch reads the buffers of a record batch in order.
+type arrowBatch struct {
+	body    []byte
+	nodes   []byte
+	buffers []byte
+	codec   int
+
+	// compressed is true if the buffers are compressed.
+	compressed bool
+}
+
+func (ab *arrowBatch) nextNode() (int, int, error) {
+	if len(ab.nodes) < 16 {
+		return 0, 0, errArrowCorrupt
+	}
+	n := int64(binary.LittleEndian.Uint64(ab.nodes))
+	nulls := int64(binary.LittleEndian.Uint64(ab.nodes[8:]))
+	ab.nodes = ab.nodes[16:]
+	if n < 0 || nulls < 0 || nulls > n || n > math.MaxInt32 {
+		return 0, 0, errArrowCorrupt
+	}
+	return int(n), int(nulls), nil
+}
+
+func (ab *arrowBatch) nextBuffer() ([]byte, error) {
+	if len(ab.buffers) < 16 {
+		return nil, errArrowCorrupt
+	}
+	offset := int64(binary.LittleEndian.Uint64(ab.buffers))
+	length := int64(binary.LittleEndian.Uint64(ab.buffers[8:]))
+	ab.buffers = ab.buffers[16:]
+	if offset < 0 || length < 0 || offset > int64(len(ab.body)) || length > int64(len(ab.body))-offset {
+		return nil, errArrowCorrupt
+	}
+	data := ab.body[offset : offset+length]
+	if !ab.compressed || len(data) == 0 {
+		return data, nil
+	}
+	if len(data) < 8 {
+		return nil, errArrowCorrupt
+	}
+	size := int64(binary.LittleEndian.Uint64(data))
+	if size == -1 {
+		return data[8:], nil
+	}
+	if size < 0 || size > math.MaxInt32 {
+		return nil, errArrowCorrupt
+	}
+	if ab.codec != arrowZstd {
+		return nil, fmt.Errorf("arrow: LZ4 compression is not supported")
+	}
+	return Compression_Zstd.decompress(data[8:], int(size))
+}
+
+// readColumn appends the values of the next field to the Column.
+func (ar *ArrowReader) readColumn(ab *arrowBatch, af *arrowField, col Column) error {
+	n, nulls, err := ab.nextNode()
+	if err != nil {
+		return err
+	}
+	if af.typeID == arrowTypeNull && af.dictionary == nil {
+		for i := 0; i < n; i++ {
+			col.PushBack(NewNullValue())
+		}
+		return nil
+	}
+	validity, err := ab.nextBuffer()
+	if err != nil {
+		return err
+	}
+	if nulls > 0 && len(validity) < (n+7)/8 {
+		return errArrowCorrupt
+	}
+	valid := func(i int) bool {
+		return nulls == 0 || validity[i/8]>>(uint(i)%8)&1 == 1
+	}
+
+	values, err := ab.nextBuffer()
+	if err != nil {
+		return err
+	}
+	if af.dictionary != nil {
+		dict, ok := ar.dicts[*af.dictionary]
+		if !ok {
+			return fmt.Errorf("arrow: dictionary %d of column %q does not exist", *af.dictionary, af.Name)
+		}
+		width := af.index.bitWidth / 8
+		if len(values) < n*width {
+			return errArrowCorrupt
+		}
+		index := af.index.fixedValue()
+		for i := 0; i < n; i++ {
+			if !valid(i) {
+				col.PushBack(NewNullValue())
+				continue
+			}
+			idx, _ := index(values[i*width:]).Int64()
+			if idx < 0 || idx >= int64(len(dict)) {
+				return fmt.Errorf("arrow: dictionary index %d out of range [0, %d)", idx, len(dict))
+			}
+			col.PushBack(dict[idx])
+		}
+		return nil
+	}
+
+	switch af.typeID {
+	case arrowTypeBool:
+		if len(values) < (n+7)/8 {
+			return errArrowCorrupt
+		}
+		for i := 0; i < n; i++ {
+			if !valid(i) {
+				col.PushBack(NewNullValue())
+				continue
+			}
+			col.PushBack(Bool(values[i/8]>>(uint(i)%8)&1 == 1))
+		}
+
+	case arrowTypeBinary, arrowTypeUtf8, arrowTypeLargeBinary, arrowTypeLargeUtf8:
+		data, err := ab.nextBuffer()
+		if err != nil {
+			return err
+		}
+		width := 4
+		if af.typeID == arrowTypeLargeBinary || af.typeID == arrowTypeLargeUtf8 {
+			width = 8
+		}
+		if n > 0 && len(values) < (n+1)*width {
+			return errArrowCorrupt
+		}
+		offset := func(i int) int64 {
+			if width == 4 {
+				return int64(int32(binary.LittleEndian.Uint32(values[4*i:])))
+			}
+			return int64(binary.LittleEndian.Uint64(values[8*i:]))
+		}
+		for i := 0; i < n; i++ {
+			if !valid(i) {
+				col.PushBack(NewNullValue())
+				continue
+			}
+			start, end := offset(i), offset(i+1)
+			if start < 0 || start > end || end > int64(len(data)) {
+				return errArrowCorrupt
+			}
+			col.PushBack(String(data[start:end]))
+		}
+
+	default:
+		width := af.bitWidth / 8
+		if len(values) < n*width {
+			return errArrowCorrupt
+		}
+		conv := af.fixedValue()
+		for i := 0; i < n; i++ {
+			if !valid(i) {
+				col.PushBack(NewNullValue())
+				continue
+			}
+			col.PushBack(conv(values[i*width:]))
+		}
+	}
+	return nil
+}
+
+// ArrowReader reads Arrow IPC in the streaming or the file format
+// from io.Reader, one record batch at a time.
+type ArrowReader struct {
+	r      io.Reader
+	schema Schema
+	fields []arrowField
+
+	// dicts are the values of the dictionaries by id.
+	dicts map[int64][]Value
+}
+
+// NewArrowReader reads the schema of Arrow IPC data from io.Reader.
+// The file format is read as a stream, so io.Reader does not need to seek.
+func NewArrowReader(r io.Reader) (ar *ArrowReader, err error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(len(arrowMagic)); err == nil && string(magic) == arrowMagic {
+		if _, err := br.Discard(8); err != nil {
+			return nil, err
+		}
+	}
+	ar = &ArrowReader{r: br, dicts: make(map[int64][]Value)}
+	msg, _, err := ar.readMessage()
+	if err == io.EOF {
+		return nil, fmt.Errorf("arrow: no schema")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	defer recoverFlatbuf(&err)
+	if msg.uint8(1, 0) != arrowHeaderSchema {
+		return nil, fmt.Errorf("arrow: first message is not schema")
+	}
+	st, ok := msg.table(2)
+	if !ok {
+		return nil, errArrowCorrupt
+	}
+	if st.int16(0, 0) != 0 {
+		return nil, fmt.Errorf("arrow: big endian is not supported")
+	}
+
+	var stored Schema
+	for _, kv := range st.tables(2) {
+		if kv.string(0) != schemaMetadataKey {
+			continue
+		}
+		var jfields []jsonField
+		if err := json.Unmarshal([]byte(kv.string(1)), &jfields); err == nil {
+			for _, jf := range jfields {
+				stored.Fields = append(stored.Fields, Field{Name: jf.Name, Type: jf.Type, Nullable: jf.Nullable, Layout: jf.Layout})
+			}
+		}
+	}
+	for _, ft := range st.tables(1) {
+		af, err := parseArrowField(ft)
+		if err != nil {
+			return nil, err
+		}
+		if sf, ok := stored.Field(af.Name); ok && sf.Type == af.Type && sf.Type == TIME {
+			af.Layout = sf.Layout
+		}
+		ar.fields = append(ar.fields, af)
+		ar.schema.Fields = append(ar.schema.Fields, af.Field)
+	}
+	return ar, nil
+}
+
+// Schema returns the Schema of the Frames.
+func (ar *ArrowReader) Schema() Schema {
+	return ar.schema
+}
+
+// readMessage reads the next encapsulated message.
+// It returns io.EOF at the end of the stream.
+func (ar *ArrowReader) readMessage() (fbTable, []byte, error) {
+	var prefix [4]byte
+	if _, err := io.ReadFull(ar.r, prefix[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return fbTable{}, nil, errArrowCorrupt
+		}
+		return fbTable{}, nil, err
+	}
+	size := binary.LittleEndian.Uint32(prefix[:])
+	if size == arrowContinuation {
+		if _, err := io.ReadFull(ar.r, prefix[:]); err != nil {
+			return fbTable{}, nil, errArrowCorrupt
+		}
+		size = binary.LittleEndian.Uint32(prefix[:])
+	}
+	if size == 0 {
+		return fbTable{}, nil, io.EOF
+	}
+	if size > math.MaxInt32 {
+		return fbTable{}, nil, errArrowCorrupt
+	}
+	meta, err := ar.read(int64(size))
+	if err != nil {
+		return fbTable{}, nil, err
+	}
+
+	var bodyLength int64
+	err = func() (err error) {
+		defer recoverFlatbuf(&err)
+		bodyLength = fbRoot(meta).int64(3, 0)
+		return nil
+	}()
+	if err != nil {
+		return fbTable{}, nil, err
+	}
+	if bodyLength < 0 {
+		return fbTable{}, nil, errArrowCorrupt
+	}
+	body, err := ar.read(bodyLength)
+	if err != nil {
+		return fbTable{}, nil, err
+	}
+	return fbRoot(meta), body, nil
+}
+
+// read reads n bytes, growing the buffer as the data arrives
+// rather than trusting the size in a corrupt input.
+func (ar *ArrowReader) read(n int64) ([]byte, error) {
+	var buf bytes.Buffer
+	if n <= 1<<20 {
+		buf.Grow(int(n))
+	}
+	if _, err := io.CopyN(&buf, ar.r, n); err != nil {
+		if err == io.EOF {
+			return nil, errArrowCorrupt
+		}
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (ar *ArrowReader) newBatch(rb fbTable, body []byte) *arrowBatch {
+	ab := &arrowBatch{body: body}
+	ab.nodes, _ = rb.structs(1, 16)
+	ab.buffers, _ = rb.structs(2, 16)
+	if ct, ok := rb.table(3); ok {
+		ab.compressed, ab.codec = true, int(ct.uint8(0, arrowLZ4Frame))
+	}
+	return ab
+}
+
+func (ar *ArrowReader) readDictionary(db fbTable, body []byte) error {
+	id := db.int64(0, 0)
+	rb, ok := db.table(1)
+	if !ok {
+		return errArrowCorrupt
+	}
+	var af *arrowField
+	for i := range ar.fields {
+		if f := ar.fields[i]; f.dictionary != nil && *f.dictionary == id {
+			af = &arrowField{}
+			*af = f
+			af.dictionary = nil
+			break
+		}
+	}
+	if af == nil {
+		return fmt.Errorf("arrow: dictionary %d is not used", id)
+	}
+
+	col := NewColumnField(Field{Name: af.Name, Type: af.Type, Nullable: true, Layout: af.Layout})
+	if err := ar.readColumn(ar.newBatch(rb, body), af, col); err != nil {
+		return err
+	}
+	vals := ar.dicts[id]
+	if !db.bool(2) { // not delta
+		vals = nil
+	}
+	for i := 0; i < col.Count(); i++ {
+		v, _ := col.Value(i)
+		vals = append(vals, v)
+	}
+	ar.dicts[id] = vals
+	return nil
+}
+
+// readBatch appends the next record batch to the Columns.
+// It returns io.EOF at the end of the stream.
+func (ar *ArrowReader) readBatch(cols []Column) error {
+	for {
+		msg, body, err := ar.readMessage()
+		if err != nil {
+			return err
+		}
+		batch, err := ar.handleMessage(msg, body, cols)
+		if err != nil {
+			return err
+		}
+		if batch {
+			return nil
+		}
+	}
+}
+
+// handleMessage reads the dictionary or the record batch of the message.
+// It returns true if the message is a record batch.
+func (ar *ArrowReader) handleMessage(msg fbTable, body []byte, cols []Column) (batch bool, err error) {
+	defer recoverFlatbuf(&err)
+	switch msg.uint8(1, 0) {
+	case arrowHeaderDictionaryBatch:
+		db, ok := msg.table(2)
+		if !ok {
+			return false, errArrowCorrupt
+		}
+		return false, ar.readDictionary(db, body)
+	case arrowHeaderRecordBatch:
+		rb, ok := msg.table(2)
+		if !ok {
+			return false, errArrowCorrupt
+		}
+		ab := ar.newBatch(rb, body)
+		for i := range ar.fields {
+			if err := ar.readColumn(ab, &ar.fields[i], cols[i]); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	case arrowHeaderSchema:
+		return false, fmt.Errorf("arrow: unexpected schema message")
+	default:
+		return false, nil // skip
+	}
+}
+
+func (ar *ArrowReader) newColumns() []Column {
+	cols := make([]Column, len(ar.fields))
+	for i, af := range ar.fields {
+		cols[i] = NewColumnField(af.Field)
+	}
+	return cols
+}
+
+// Next returns the next record batch as Frame.
+// It returns io.EOF when there are no more record batches.
+func (ar *ArrowReader) Next() (Frame, error) {
+	cols := ar.newColumns()
+	if err := ar.readBatch(cols); err != nil {
+		return nil, err
+	}
+	fr := New()
+	for _, col := range cols {
+		if err := fr.AddColumn(col); err != nil {
+			return nil, err
+		}
+	}
+	return fr, nil
+}
+
+// NewFromArrowIPC creates a new Frame from Arrow IPC data in the streaming
+// or the file format, with all the record batches. Arrow types are mapped
+// to the data types:
+//
+//	Int (signed), Int (unsigned)        INT64, UINT64
+//	FloatingPoint, Decimal              FLOAT64
+//	Bool                                BOOL
+//	Utf8, Binary, LargeUtf8, Null       STRING
+//	Timestamp, Date                     TIME
+//	Duration, Time                      DURATION
+//
+// Timestamps are in the time zone of the field, or in UTC if it has none.
+// Dictionary encoded columns are decoded. Nested types are not supported.
+// Use ArrowReader to read one record batch at a time.
+func NewFromArrowIPC(r io.Reader) (Frame, error) {
+	ar, err := NewArrowReader(r)
+	if err != nil {
+		return nil, err
+	}
+	cols := ar.newColumns()
+	for {
+		if err := ar.readBatch(cols); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+	}
+	fr := New()
+	for _, col := range cols {
+		if err := fr.AddColumn(col); err != nil {
+			return nil, err
+		}
+	}
+	return fr, nil
+}
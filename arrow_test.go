@@ -0,0 +1,204 @@
+package dataframe
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestArrowIPC(t *testing.T) {
+	fr, schema := newTypedTestFrame(t)
+	_, expected := fr.Rows()
+
+	for _, format := range []ArrowFormat{ArrowFormat_Stream, ArrowFormat_File} {
+		var buf bytes.Buffer
+		if err := fr.WriteArrowIPC(&buf, ArrowWriteOptions{Format: format, BatchSize: 30}); err != nil {
+			t.Fatal(err)
+		}
+		got, err := NewFromArrowIPC(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatalf("format %d: %v", format, err)
+		}
+		if !got.Schema().Equal(schema) {
+			t.Fatalf("format %d: expected %v, got %v", format, schema, got.Schema())
+		}
+		if _, grows := got.Rows(); !reflect.DeepEqual(grows, expected) {
+			t.Fatalf("format %d: expected %v, got %v", format, expected, grows)
+		}
+
+		// one Frame per record batch
+		ar, err := NewArrowReader(bytes.NewReader(buf.Bytes()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ar.Schema().Equal(schema) {
+			t.Fatalf("expected %v, got %v", schema, ar.Schema())
+		}
+		var counts []int
+		for {
+			batch, err := ar.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			col, err := batch.Column("STATE")
+			if err != nil {
+				t.Fatal(err)
+			}
+			counts = append(counts, col.Count())
+		}
+		if !reflect.DeepEqual(counts, []int{30, 30, 30, 10}) {
+			t.Fatalf("expected record batches of [30 30 30 10] rows, got %v", counts)
+		}
+	}
+
+	var serr *SchemaError
+	aw, err := NewArrowWriter(io.Discard, Schema{Fields: []Field{{Name: "unknown", Type: STRING}}}, ArrowWriteOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := aw.Write(fr); !errors.As(err, &serr) || !errors.Is(err, ErrFieldNotFound) {
+		t.Fatalf("expected ErrFieldNotFound, got %v", err)
+	}
+	if _, err := NewFromArrowIPC(bytes.NewReader([]byte("ARROW1\x00\x00"))); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestArrowIPCTimeZone(t *testing.T) {
+	ist := time.FixedZone("IST", 5*3600+1800)
+	col := NewColumnField(Field{Name: "ts", Type: TIME, Nullable: true})
+	col.PushBack(GoTime(time.Date(2024, 1, 2, 3, 4, 5, 6, ist)))
+	col.PushBack(NewNullValue())
+	fr := New()
+	if err := fr.AddColumn(col); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := fr.WriteArrowIPC(&buf, ArrowWriteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := NewFromArrowIPC(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcol, err := got.Column("ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := gcol.Value(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tv, _ := v.Time("")
+	if _, offset := tv.Zone(); offset != 5*3600+1800 || !tv.Equal(time.Date(2024, 1, 2, 3, 4, 5, 6, ist)) {
+		t.Fatalf("expected time in +05:30, got %v", tv)
+	}
+	if v, _ = gcol.Value(1); !v.IsNull() {
+		t.Fatalf("expected null, got %v", v)
+	}
+}
+
+func TestNewFromArrowIPCForeign(t *testing.T) {
+	// written by another implementation, in the streaming format with
+	// Zstandard body compression, a dictionary encoded column and
+	// narrower integer, float, date and timestamp types
+	f, err := os.Open("testdata/records-zstd.arrow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	fr, err := NewFromArrowIPC(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Field{
+		{Name: "i8", Type: INT64, Nullable: true},
+		{Name: "u32", Type: UINT64},
+		{Name: "f32", Type: FLOAT64, Nullable: true},
+		{Name: "ts", Type: TIME, Nullable: true},
+		{Name: "naive", Type: TIME},
+		{Name: "d32", Type: TIME, Layout: "2006-01-02"},
+		{Name: "dur", Type: DURATION},
+		{Name: "ls", Type: STRING, Nullable: true},
+		{Name: "dict", Type: STRING, Nullable: true},
+		{Name: "b", Type: BOOL, Nullable: true},
+	}
+	if fields := fr.Schema().Fields; !reflect.DeepEqual(fields, expected) {
+		t.Fatalf("expected %v, got %v", expected, fields)
+	}
+
+	// two record batches of 5 rows, with nulls in every third row
+	row := 7
+	for header, v := range map[string]Value{
+		"i8":   Int64(-7),
+		"u32":  Uint64(4000000007),
+		"f32":  Float64(7.5),
+		"d32":  GoTime(time.Date(2022, 1, 15, 0, 0, 0, 0, time.UTC)),
+		"dur":  GoDuration(10500 * time.Millisecond),
+		"ls":   String("large-7"),
+		"dict": String("y"),
+		"b":    Bool(false),
+	} {
+		col, err := fr.Column(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := col.Value(row)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.EqualTo(v) {
+			t.Fatalf("%s: expected %v, got %v", header, v, got)
+		}
+	}
+	col, err := fr.Column("ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col.Count() != 10 || col.NullCount() != 4 {
+		t.Fatalf("expected 10 rows with 4 nulls, got %d rows with %d nulls", col.Count(), col.NullCount())
+	}
+	v, err := col.Value(row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tv, _ := v.Time(""); tv.UnixMilli() != 1650000000000+7*3600000 {
+		t.Fatalf("expected %d, got %d", 1650000000000+7*3600000, tv.UnixMilli())
+	}
+}
+
+func TestParseArrowFieldPrecision(t *testing.T) {
+	for precision, bitWidth := range map[int16]int{0: 16, 1: 32, 2: 64, 3: 0, -1: 0, 16: 0} {
+		b := newFBBuilder()
+		name := b.createString("f")
+		b.startTable(1)
+		b.addInt16(0, precision)
+		typ := b.endTable()
+		b.startTable(4)
+		b.addOffset(0, name)
+		b.addUint8(2, arrowTypeFloatingPoint)
+		b.addOffset(3, typ)
+		af, err := parseArrowField(fbRoot(b.finish(b.endTable())))
+		if bitWidth == 0 {
+			if !errors.Is(err, errArrowCorrupt) {
+				t.Fatalf("precision %d: expected %v, got %v", precision, errArrowCorrupt, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if af.bitWidth != bitWidth {
+			t.Fatalf("precision %d: expected bit width %d, got %d", precision, bitWidth, af.bitWidth)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// AsOfDirection defines which row of the right Frame
+// an as-of join matches.
+type AsOfDirection int
+
+const (
+	// AsOfDirection_Backward matches the last row whose key
+	// is less than or equal to the left key.
+	AsOfDirection_Backward AsOfDirection = iota
+
+	// AsOfDirection_Forward matches the first row whose key
+	// is greater than or equal to the left key.
+	AsOfDirection_Forward
+
+	// AsOfDirection_Nearest matches the row whose key is the closest
+	// to the left key, preferring the backward row on a tie.
+	AsOfDirection_Nearest
+)
+
+// AsOfOptions configures the as-of join.
+type AsOfOptions struct {
+	// LeftOn and RightOn are the headers of the key columns of each
+	// Frame, of TIME, DURATION, INT64, UINT64 or FLOAT64. If RightOn
+	// is empty, it is LeftOn.
+	LeftOn  string
+	RightOn string
+
+	// By are the headers of the columns in both Frames, whose
+	// values must be equal in the matched rows, such as the node name.
+	By []string
+
+	// Direction is the direction to search the right rows.
+	Direction AsOfDirection
+
+	// Tolerance is the maximum distance between the matched keys,
+	// such as GoDuration(time.Second) for TIME keys. Nil is unlimited.
+	Tolerance Value
+
+	// LeftSuffix and RightSuffix are appended to the headers of the
+	// columns that exist in both Frames. If both are empty,
+	// RightSuffix is "_right".
+	LeftSuffix  string
+	RightSuffix string
+}
+
+// asofKeys are the keys of a Column in int64 or float64.
+type asofKeys struct {
+	ints   []int64
+	floats []float64
+	valid  []bool
+}
+
+// newAsOfKeys converts the keys. TIME is in Unix nanoseconds.
+func newAsOfKeys(col Column, n int) asofKeys {
+	fd := col.Field()
+	ks := asofKeys{valid: make([]bool, n)}
+	isFloat := fd.Type == FLOAT64 || fd.Type == UINT64
+	if isFloat {
+		ks.floats = make([]float64, n)
+	} else {
+		ks.ints = make([]int64, n)
+	}
+	for row := 0; row < n; row++ {
+		v := valueAt(col, row)
+		if v.IsNull() {
+			continue
+		}
+		ok := false
+		switch fd.Type {
+		case TIME:
+			if t, tok := v.Time(fd.Layout); tok && !t.Before(minUnixNano) && !t.After(maxUnixNano) {
+				ks.ints[row], ok = t.UnixNano(), true
+			}
+		case DURATION:
+			d, dok := v.Duration()
+			ks.ints[row], ok = int64(d), dok
+		case INT64:
+			ks.ints[row], ok = v.Int64()
+		default:
+			ks.floats[row] = valueFloat64(fd.Type, v)
+			ok = !math.IsNaN(ks.floats[row])
+		}
+		ks.valid[row] = ok
+	}
+	return ks
+}
+
+// matchAsOf returns the matched right row of each left row, or -1.
+// The right rows of each group are searched in the order of the keys.
+func matchAsOf[T int64 | float64](lk, rk []T, lok, rok []bool, lgroup, rgroup []string, dir AsOfDirection, tol T, hasTol bool) []int {
+	groups := make(map[string][]int)
+	for r := range rk {
+		if rok[r] {
+			groups[rgroup[r]] = append(groups[rgroup[r]], r)
+		}
+	}
+	for _, rows := range groups {
+		sort.SliceStable(rows, func(i, j int) bool { return rk[rows[i]] < rk[rows[j]] })
+	}
+
+	matches := make([]int, len(lk))
+	for l, key := range lk {
+		matches[l] = -1
+		if !lok[l] {
+			continue
+		}
+		rows := groups[lgroup[l]]
+		back, fwd := -1, -1
+		if i := sort.Search(len(rows), func(i int) bool { return rk[rows[i]] > key }); i > 0 {
+			back = rows[i-1]
+		}
+		if j := sort.Search(len(rows), func(i int) bool { return rk[rows[i]] >= key }); j < len(rows) {
+			fwd = rows[j]
+		}
+		if hasTol {
+			if back >= 0 && key-rk[back] > tol {
+				back = -1
+			}
+			if fwd >= 0 && rk[fwd]-key > tol {
+				fwd = -1
+			}
+		}
+		switch dir {
+		case AsOfDirection_Backward:
+			matches[l] = back
+		case AsOfDirection_Forward:
+			matches[l] = fwd
+		default:
+			matches[l] = back
+			if back < 0 || (fwd >= 0 && rk[fwd]-key < key-rk[back]) {
+				matches[l] = fwd
+			}
+		}
+	}
+	return matches
+}
+
+// JoinAsOf joins each row of the left Frame with the row of the right
+// Frame whose key is the closest in the direction, such as the latest
+// sample at or before the left time, within the tolerance. Each left row
+// is kept, with nulls if no row matches. The result has the columns of
+// the left Frame followed by the columns of the right Frame, other than
+// the By columns and the right key column of the same header.
+func JoinAsOf(left, right Frame, opts AsOfOptions) (Frame, error) {
+	if opts.RightOn == "" {
+		opts.RightOn = opts.LeftOn
+	}
+	if opts.Direction < AsOfDirection_Backward || opts.Direction > AsOfDirection_Nearest {
+		return nil, fmt.Errorf("as-of direction %d is unknown", opts.Direction)
+	}
+	if opts.LeftSuffix == "" && opts.RightSuffix == "" {
+		opts.RightSuffix = "_right"
+	}
+	lcol, err := left.Column(opts.LeftOn)
+	if err != nil {
+		return nil, err
+	}
+	rcol, err := right.Column(opts.RightOn)
+	if err != nil {
+		return nil, err
+	}
+	lby, err := joinKeys(left, opts.By)
+	if err != nil {
+		return nil, err
+	}
+	rby, err := joinKeys(right, opts.By)
+	if err != nil {
+		return nil, err
+	}
+
+	ltp, rtp := lcol.DataType(), rcol.DataType()
+	isFloat := func(tp DATA_TYPE) bool { return tp == FLOAT64 || tp == UINT64 }
+	switch {
+	case ltp == STRING || ltp == BOOL || rtp == STRING || rtp == BOOL:
+		return nil, fmt.Errorf("as-of keys %q and %q must be TIME or numeric", opts.LeftOn, opts.RightOn)
+	case isFloat(ltp) != isFloat(rtp) || (!isFloat(ltp) && ltp != rtp):
+		return nil, fmt.Errorf("as-of key %q of %s does not match %q of %s", opts.LeftOn, ltp, opts.RightOn, rtp)
+	}
+
+	leftN, rightN := rowCount(left.Columns()), rowCount(right.Columns())
+	lk, rk := newAsOfKeys(lcol, leftN), newAsOfKeys(rcol, rightN)
+
+	// the rows with null By values do not match
+	groupKeys := func(by []Column, n int, valid []bool) []string {
+		keys := make([]string, n)
+		fields := keyFields(by)
+		for row := range keys {
+			k, ok := joinKey(by, fields, row)
+			keys[row] = k
+			valid[row] = valid[row] && ok
+		}
+		return keys
+	}
+	lgroup, rgroup := groupKeys(lby, leftN, lk.valid), groupKeys(rby, rightN, rk.valid)
+
+	var matches []int
+	hasTol := opts.Tolerance != nil && !opts.Tolerance.IsNull()
+	if isFloat(ltp) {
+		var tol float64
+		if hasTol {
+			var ok bool
+			if tol, ok = opts.Tolerance.Float64(); !ok {
+				return nil, fmt.Errorf("as-of tolerance %v is not a number", opts.Tolerance)
+			}
+		}
+		matches = matchAsOf(lk.floats, rk.floats, lk.valid, rk.valid, lgroup, rgroup, opts.Direction, tol, hasTol)
+	} else {
+		var tol int64
+		if hasTol {
+			var ok bool
+			if ltp == INT64 {
+				tol, ok = opts.Tolerance.Int64()
+			} else {
+				var d time.Duration
+				d, ok = opts.Tolerance.Duration()
+				tol = int64(d)
+			}
+			if !ok {
+				return nil, fmt.Errorf("as-of tolerance %v does not match %s", opts.Tolerance, ltp)
+			}
+		}
+		matches = matchAsOf(lk.ints, rk.ints, lk.valid, rk.valid, lgroup, rgroup, opts.Direction, tol, hasTol)
+	}
+
+	leftRows := make([]int, leftN)
+	for i := range leftRows {
+		leftRows[i] = i
+	}
+	drop := make(map[string]bool)
+	for _, k := range opts.By {
+		drop[k] = true
+	}
+	if opts.RightOn == opts.LeftOn {
+		drop[opts.RightOn] = true
+	}
+	return joinResult(left, right, leftRows, matches, nil, drop, opts.LeftSuffix, opts.RightSuffix)
+}
@@ -0,0 +1,131 @@
+package dataframe
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestJoinAsOf(t *testing.T) {
+	left, err := NewFromRowsTyped([]string{"ts", "node", "latency"}, [][]string{
+		{"10", "a", "1"},
+		{"20", "a", "2"},
+		{"20", "b", "3"},
+		{"35", "a", "4"},
+		{"", "a", "5"},
+	}, Schema{Fields: []Field{
+		{Name: "ts", Type: INT64, Nullable: true},
+		{Name: "node", Type: STRING},
+		{Name: "latency", Type: FLOAT64},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	right, err := NewFromRowsTyped([]string{"ts", "node", "cpu"}, [][]string{
+		{"12", "a", "0.1"},
+		{"19", "b", "0.2"},
+		{"18", "a", "0.3"},
+		{"30", "a", "0.4"},
+		{"33", "b", "0.5"},
+	}, Schema{Fields: []Field{
+		{Name: "ts", Type: INT64},
+		{Name: "node", Type: STRING},
+		{Name: "cpu", Type: FLOAT64},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		opts     AsOfOptions
+		expected []string
+	}{
+		{
+			AsOfOptions{LeftOn: "ts", Direction: AsOfDirection_Backward},
+			[]string{"", "0.2", "0.2", "0.5", ""},
+		},
+		{
+			AsOfOptions{LeftOn: "ts", Direction: AsOfDirection_Forward},
+			[]string{"0.1", "0.4", "0.4", "", ""},
+		},
+		{
+			AsOfOptions{LeftOn: "ts", Direction: AsOfDirection_Nearest},
+			[]string{"0.1", "0.2", "0.2", "0.5", ""},
+		},
+		{
+			AsOfOptions{LeftOn: "ts", Direction: AsOfDirection_Nearest, Tolerance: Int64(1)},
+			[]string{"", "0.2", "0.2", "", ""},
+		},
+		{
+			AsOfOptions{LeftOn: "ts", By: []string{"node"}, Direction: AsOfDirection_Backward},
+			[]string{"", "0.3", "0.2", "0.4", ""},
+		},
+		{
+			AsOfOptions{LeftOn: "ts", By: []string{"node"}, Direction: AsOfDirection_Forward, Tolerance: Int64(13)},
+			[]string{"0.1", "0.4", "0.5", "", ""},
+		},
+	}
+	for i, tt := range tests {
+		fr, err := JoinAsOf(left, right, tt.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		col, err := fr.Column("cpu")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rows := col.Rows(); !reflect.DeepEqual(rows, tt.expected) {
+			t.Fatalf("#%d: expected %v, got %v", i, tt.expected, rows)
+		}
+		expected := []string{"ts", "node", "latency", "node_right", "cpu"}
+		if tt.opts.By != nil {
+			expected = []string{"ts", "node", "latency", "cpu"}
+		}
+		if hd := fr.Headers(); !reflect.DeepEqual(hd, expected) {
+			t.Fatalf("#%d: expected %v, got %v", i, expected, hd)
+		}
+	}
+
+	if _, err := JoinAsOf(left, right, AsOfOptions{LeftOn: "node"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := JoinAsOf(left, right, AsOfOptions{LeftOn: "ts", RightOn: "cpu"}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestJoinAsOfMonitor(t *testing.T) {
+	// the monitors of the nodes start one second apart
+	node1, _, err := NewFromCSVInfer(nil, "testdata/bench-01-etcd-1-monitor.csv", InferOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	node2, _, err := NewFromCSVInfer(nil, "testdata/bench-01-etcd-2-monitor.csv", InferOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fr, err := JoinAsOf(node1, node2, AsOfOptions{
+		LeftOn:      "unix_ts",
+		Direction:   AsOfDirection_Forward,
+		Tolerance:   GoDuration(time.Second),
+		LeftSuffix:  "_1",
+		RightSuffix: "_2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cpu1, err := fr.Column("CpuUsageFloat64_1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cpu2, err := fr.Column("CpuUsageFloat64_2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cpu1.Count() != cpu2.Count() || cpu1.NullCount() != 0 {
+		t.Fatalf("expected the rows of the left Frame, got %d and %d rows", cpu1.Count(), cpu2.Count())
+	}
+	if cpu2.NullCount() >= cpu2.Count()/10 {
+		t.Fatalf("expected most rows to match, got %d nulls in %d rows", cpu2.NullCount(), cpu2.Count())
+	}
+}
@@ -0,0 +1,99 @@
+package dataframe
+
+import "math/bits"
+
+// bitmap is a validity bitmap that marks null rows, one bit per row.
+// The zero value has no null rows, so columns without nulls
+// do not allocate the bitmap.
+type bitmap []uint64
+
+// get returns true if the row is null.
+func (b bitmap) get(row int) bool {
+	w := row / 64
+	return w < len(b) && b[w]&(1<<uint(row%64)) != 0
+}
+
+// set marks the row as null or valid.
+func (b *bitmap) set(row int, null bool) {
+	w := row / 64
+	if w >= len(*b) {
+		if !null {
+			return
+		}
+		nb := make(bitmap, w+1)
+		copy(nb, *b)
+		*b = nb
+	}
+	if null {
+		(*b)[w] |= 1 << uint(row%64)
+	} else {
+		(*b)[w] &^= 1 << uint(row%64)
+	}
+}
+
+// count returns the number of null rows.
+func (b bitmap) count() int {
+	n := 0
+	for _, w := range b {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// take returns the bitmap for the rows in the order of idx.
+func (b bitmap) take(idx []int) bitmap {
+	if b.count() == 0 {
+		return nil
+	}
+	var nb bitmap
+	for i, row := range idx {
+		if b.get(row) {
+			nb.set(i, true)
+		}
+	}
+	return nb
+}
+
+// delete returns the bitmap without rows [start, end).
+func (b bitmap) delete(start, end int) bitmap {
+	if b.count() == 0 {
+		return nil
+	}
+	var nb bitmap
+	for row := 0; row < len(b)*64; row++ {
+		if !b.get(row) || (start <= row && row < end) {
+			continue
+		}
+		if row < start {
+			nb.set(row, true)
+		} else {
+			nb.set(row-(end-start), true)
+		}
+	}
+	return nb
+}
+
+// shift returns the bitmap with all rows moved back by one,
+// leaving the first row valid.
+func (b bitmap) shift() bitmap {
+	if b.count() == 0 {
+		return nil
+	}
+	nb := make(bitmap, len(b)+1)
+	var carry uint64
+	for i, w := range b {
+		nb[i] = w<<1 | carry
+		carry = w >> 63
+	}
+	nb[len(b)] = carry
+	return nb
+}
+
+func (b bitmap) copy() bitmap {
+	if len(b) == 0 {
+		return nil
+	}
+	nb := make(bitmap, len(b))
+	copy(nb, b)
+	return nb
+}
@@ -15,6 +15,12 @@ type Column interface {
 	// Header returns the header of the Column.
 	Header() string
 
+	// DataType returns the data type of the Column.
+	DataType() DATA_TYPE
+
+	// Field returns the Field that describes the Column.
+	Field() Field
+
 	// Rows returns all the data in string slice.
 	Rows() []string
 
@@ -27,6 +33,17 @@ type Column interface {
 	// Float64s returns all the data in float64 slice.
 	Float64s() ([]float64, bool)
 
+	// Int64sMask returns all the data in int64 slice, with the mask
+	// that is false for null rows and rows that are not int64.
+	Int64sMask() ([]int64, []bool)
+
+	// Float64sMask returns all the data in float64 slice, with the mask
+	// that is false for null rows and rows that are not float64.
+	Float64sMask() ([]float64, []bool)
+
+	// NullCount returns the number of null rows.
+	NullCount() int
+
 	// Times returns all the data in time.Time slice.
 	Times(layout string) ([]time.Time, bool)
 
@@ -37,7 +54,8 @@ type Column interface {
 	// is out of index range.
 	Value(row int) (Value, error)
 
-	// Set overwrites the value
+	// Set overwrites the value. It returns error if the Value
+	// cannot be converted to the column's data type.
 	Set(row int, v Value) error
 
 	// FindFirst finds the first Value, and returns the row number.
@@ -52,30 +70,36 @@ type Column interface {
 	Front() (Value, bool)
 
 	// FrontNonNil returns the first non-nil Value from the first row.
+	// Null Values are skipped.
 	FrontNonNil() (Value, bool)
 
 	// Back returns the last row Value.
 	Back() (Value, bool)
 
 	// BackNonNil returns the first non-nil Value from the last row.
+	// Null Values are skipped.
 	BackNonNil() (Value, bool)
 
 	// PushFront adds a Value to the front of the Column.
 	// This does not prevent inserting wrong data types.
-	// Assumes all data are string.
+	// If the Value cannot be converted to the column's
+	// data type, a null is inserted.
 	PushFront(v Value) int
 
 	// PushFrontTyped adds a Value to the front of the Column.
-	// It returns error if the value doesn't match the type of the column.
+	// It returns *SchemaError if the value doesn't match the type of the column.
+	// A nil value is inserted as null, unless the column is not nullable.
 	PushFrontTyped(v interface{}) (int, error)
 
 	// PushBack appends the Value to the Column.
 	// This does not prevent inserting wrong data types.
-	// Assumes all data are string.
+	// If the Value cannot be converted to the column's
+	// data type, a null is appended.
 	PushBack(v Value) int
 
 	// PushBackTyped appends the Value to the Column.
-	// It returns error if the value doesn't match the type of the column.
+	// It returns *SchemaError if the value doesn't match the type of the column.
+	// A nil value is appended as null, unless the column is not nullable.
 	PushBackTyped(v interface{}) (int, error)
 
 	// Delete deletes a row by index.
@@ -99,6 +123,94 @@ type Column interface {
 	// Copy deep-copies a column.
 	Copy() Column
 
+	// Rolling returns the Rolling of the numeric Column over the windows
+	// of the rows, aggregating the windows with at least minPeriods
+	// values. If minPeriods is 0, it is the window.
+	Rolling(window, minPeriods int) (Rolling, error)
+
+	// RollingTime returns the Rolling of the numeric Column over the time
+	// windows, such as 10 seconds, by the times in the Column on, of TIME
+	// or INT64 Unix seconds in ascending order. If minPeriods is 0, it is 1.
+	RollingTime(on Column, window time.Duration, minPeriods int) (Rolling, error)
+
+	// CumSum returns the cumulative sums of the numeric Column, in a new
+	// Column of "header_cumsum". Null rows stay null, and are skipped.
+	CumSum() (Column, error)
+
+	// CumProd returns the cumulative products of the numeric Column.
+	CumProd() (Column, error)
+
+	// CumMax returns the cumulative maximums of the numeric Column.
+	CumMax() (Column, error)
+
+	// CumMin returns the cumulative minimums of the numeric Column.
+	CumMin() (Column, error)
+
+	// Diff returns the differences from the rows periods before, which
+	// are null if either row is null. UINT64 differences are in INT64,
+	// and TIME differences are in DURATION.
+	Diff(periods int) (Column, error)
+
+	// PctChange returns the fractional changes from the rows periods
+	// before in FLOAT64, which are null if either row is null.
+	PctChange(periods int) (Column, error)
+
+	// Shift returns the Column shifted by the periods, which are
+	// negative to shift backward. The vacated rows are the fill
+	// Value, or null if fill is nil.
+	Shift(periods int, fill Value) (Column, error)
+
+	// Rank returns the ascending ranks of the values from 1 in FLOAT64,
+	// with the ties ranked by the method. Null rows stay null.
+	Rank(method RankMethod) (Column, error)
+
+	// FillNull returns a copy of the Column with the null rows filled
+	// with v. It returns *SchemaError if v does not match the Column.
+	FillNull(v Value) (Column, error)
+
+	// FillForward returns a copy of the Column with each null row filled
+	// with the previous non-null row, at most limit rows before it if
+	// limit is positive.
+	FillForward(limit int) (Column, error)
+
+	// FillBackward returns a copy of the Column with each null row filled
+	// with the next non-null row, at most limit rows after it if limit
+	// is positive.
+	FillBackward(limit int) (Column, error)
+
+	// Interpolate returns a copy of the numeric Column with the null rows
+	// linearly interpolated between the closest non-null rows, in FLOAT64
+	// for the integer Columns. The leading and trailing nulls stay null.
+	Interpolate() (Column, error)
+
+	// InterpolateTime is Interpolate weighted by the times in the Column
+	// on, of TIME or INT64 Unix seconds in ascending order.
+	InterpolateTime(on Column) (Column, error)
+
+	// Sketch returns the Sketch of the non-null values of the numeric
+	// Column, of the relative accuracy in (0, 1). DURATION values are
+	// in nanoseconds.
+	Sketch(relativeAccuracy float64) (*Sketch, error)
+
+	// ValueCounts returns a new Frame of the distinct values of the Column
+	// and their counts, from the most frequent. The first column is
+	// the values, and the second is "count" or "proportion".
+	ValueCounts(opts ValueCountsOptions) (Frame, error)
+
+	// Histogram returns a new Frame of the bins of the numeric Column,
+	// with the columns "lower", "upper" and "count". DURATION values
+	// are in nanoseconds for the Bins, and the edges are in DURATION.
+	Histogram(bins Bins) (Frame, error)
+
+	// Corr returns the correlation coefficient of the numeric Columns,
+	// over the rows where both are not null. It is NaN for less than
+	// 2 rows or the constant Columns.
+	Corr(other Column, method CorrMethod) (float64, error)
+
+	// Cov returns the sample covariance of the numeric Columns,
+	// over the rows where both are not null.
+	Cov(other Column) (float64, error)
+
 	// SortByStringAscending sorts Column in string ascending order.
 	SortByStringAscending()
 
@@ -122,8 +234,9 @@ type column struct {
 	mu       sync.Mutex
 	dataType DATA_TYPE
 	header   string
-	size     int
-	data     []Value
+	nullable bool
+	layout   string
+	data     columnData
 }
 
 // NewColumn creates a new Column.
@@ -131,18 +244,31 @@ func NewColumn(hd string) Column {
 	return &column{
 		dataType: STRING,
 		header:   hd,
-		size:     0,
-		data:     []Value{},
+		nullable: true,
+		data:     newColumnData(STRING),
 	}
 }
 
 // NewColumnTyped creates a new Column with data type.
+// INT64, UINT64, FLOAT64, BOOL and DURATION columns
+// are stored in native Go slices.
 func NewColumnTyped(hd string, tp DATA_TYPE) Column {
 	return &column{
 		dataType: tp,
 		header:   hd,
-		size:     0,
-		data:     []Value{},
+		nullable: true,
+		data:     newColumnData(tp),
+	}
+}
+
+// NewColumnField creates a new Column described by the Field.
+func NewColumnField(f Field) Column {
+	return &column{
+		dataType: f.Type,
+		header:   f.Name,
+		nullable: f.Nullable,
+		layout:   f.Layout,
+		data:     newColumnData(f.Type),
 	}
 }
 
@@ -150,7 +276,7 @@ func (c *column) Count() int {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	return c.size
+	return c.data.Len()
 }
 
 func (c *column) Header() string {
@@ -160,13 +286,32 @@ func (c *column) Header() string {
 	return c.header
 }
 
+func (c *column) DataType() DATA_TYPE {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.dataType
+}
+
+func (c *column) Field() Field {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return Field{
+		Name:     c.header,
+		Type:     c.dataType,
+		Nullable: c.nullable,
+		Layout:   c.layout,
+	}
+}
+
 func (c *column) Rows() (rows []string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	rows = make([]string, len(c.data))
-	for i := range c.data {
-		v, _ := c.data[i].String()
+	rows = make([]string, c.data.Len())
+	for i := range rows {
+		v, _ := c.data.Value(i).String()
 		rows[i] = v
 	}
 	return
@@ -176,10 +321,15 @@ func (c *column) Uint64s() (rows []uint64, ok bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	rows = make([]uint64, len(c.data))
-	for i := range c.data {
+	if d, typed := c.data.(*typedData[uint64]); typed && d.NullCount() == 0 {
+		rows, _ = d.clone()
+		return rows, true
+	}
+
+	rows = make([]uint64, c.data.Len())
+	for i := range rows {
 		var v uint64
-		v, ok = c.data[i].Uint64()
+		v, ok = c.data.Value(i).Uint64()
 		if !ok {
 			break
 		}
@@ -192,10 +342,15 @@ func (c *column) Int64s() (rows []int64, ok bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	rows = make([]int64, len(c.data))
-	for i := range c.data {
+	if d, typed := c.data.(*typedData[int64]); typed && d.NullCount() == 0 {
+		rows, _ = d.clone()
+		return rows, true
+	}
+
+	rows = make([]int64, c.data.Len())
+	for i := range rows {
 		var v int64
-		v, ok = c.data[i].Int64()
+		v, ok = c.data.Value(i).Int64()
 		if !ok {
 			break
 		}
@@ -208,10 +363,15 @@ func (c *column) Float64s() (rows []float64, ok bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	rows = make([]float64, len(c.data))
-	for i := range c.data {
+	if d, typed := c.data.(*typedData[float64]); typed && d.NullCount() == 0 {
+		rows, _ = d.clone()
+		return rows, true
+	}
+
+	rows = make([]float64, c.data.Len())
+	for i := range rows {
 		var v float64
-		v, ok = c.data[i].Float64()
+		v, ok = c.data.Value(i).Float64()
 		if !ok {
 			break
 		}
@@ -220,14 +380,59 @@ func (c *column) Float64s() (rows []float64, ok bool) {
 	return
 }
 
+func (c *column) Int64sMask() (rows []int64, mask []bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	mask = make([]bool, c.data.Len())
+	if d, typed := c.data.(*typedData[int64]); typed {
+		rows, _ = d.clone()
+		for i := range mask {
+			mask[i] = !d.IsNull(i)
+		}
+		return
+	}
+	rows = make([]int64, c.data.Len())
+	for i := range rows {
+		rows[i], mask[i] = c.data.Value(i).Int64()
+	}
+	return
+}
+
+func (c *column) Float64sMask() (rows []float64, mask []bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	mask = make([]bool, c.data.Len())
+	if d, typed := c.data.(*typedData[float64]); typed {
+		rows, _ = d.clone()
+		for i := range mask {
+			mask[i] = !d.IsNull(i)
+		}
+		return
+	}
+	rows = make([]float64, c.data.Len())
+	for i := range rows {
+		rows[i], mask[i] = c.data.Value(i).Float64()
+	}
+	return
+}
+
+func (c *column) NullCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.data.NullCount()
+}
+
 func (c *column) Times(layout string) (rows []time.Time, ok bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	rows = make([]time.Time, len(c.data))
-	for i := range c.data {
+	rows = make([]time.Time, c.data.Len())
+	for i := range rows {
 		var v time.Time
-		v, ok = c.data[i].Time(layout)
+		v, ok = c.data.Value(i).Time(layout)
 		if !ok {
 			break
 		}
@@ -247,20 +452,22 @@ func (c *column) Value(row int) (Value, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if row > c.size-1 {
-		return nil, fmt.Errorf("index out of range (got %d for size %d)", row, c.size)
+	if row > c.data.Len()-1 {
+		return nil, fmt.Errorf("index out of range (got %d for size %d)", row, c.data.Len())
 	}
-	return c.data[row], nil
+	return c.data.Value(row), nil
 }
 
 func (c *column) Set(row int, v Value) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if row > c.size-1 {
-		return fmt.Errorf("index out of range (got %d for size %d)", row, c.size)
+	if row > c.data.Len()-1 {
+		return fmt.Errorf("index out of range (got %d for size %d)", row, c.data.Len())
+	}
+	if !c.data.Set(row, v) {
+		return fmt.Errorf("column %q cannot set %v as data type %q", c.header, v, c.dataType)
 	}
-	c.data[row] = v
 	return nil
 }
 
@@ -268,8 +475,8 @@ func (c *column) FindFirst(v Value) (int, bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	for i := range c.data {
-		if c.data[i].EqualTo(v) {
+	for i := 0; i < c.data.Len(); i++ {
+		if c.data.Value(i).EqualTo(v) {
 			return i, true
 		}
 	}
@@ -281,8 +488,8 @@ func (c *column) FindLast(v Value) (int, bool) {
 	defer c.mu.Unlock()
 
 	var idx int
-	for i := range c.data {
-		if c.data[i].EqualTo(v) {
+	for i := 0; i < c.data.Len(); i++ {
+		if c.data.Value(i).EqualTo(v) {
 			idx = i
 		}
 	}
@@ -296,10 +503,10 @@ func (c *column) Front() (Value, bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.size == 0 {
+	if c.data.Len() == 0 {
 		return nil, false
 	}
-	v := c.data[0]
+	v := c.data.Value(0)
 	return v, true
 }
 
@@ -307,10 +514,11 @@ func (c *column) FrontNonNil() (Value, bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.size == 0 {
+	if c.data.Len() == 0 {
 		return nil, false
 	}
-	for _, v := range c.data {
+	for i := 0; i < c.data.Len(); i++ {
+		v := c.data.Value(i)
 		if !v.IsNil() {
 			return v, true
 		}
@@ -322,10 +530,10 @@ func (c *column) Back() (Value, bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.size == 0 {
+	if c.data.Len() == 0 {
 		return nil, false
 	}
-	v := c.data[c.size-1]
+	v := c.data.Value(c.data.Len() - 1)
 	return v, true
 }
 
@@ -333,11 +541,11 @@ func (c *column) BackNonNil() (Value, bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.size == 0 {
+	if c.data.Len() == 0 {
 		return nil, false
 	}
-	for i := c.size - 1; i > 0; i-- {
-		v := c.data[i]
+	for i := c.data.Len() - 1; i >= 0; i-- {
+		v := c.data.Value(i)
 		if !v.IsNil() {
 			return v, true
 		}
@@ -349,79 +557,82 @@ func (c *column) PushFront(v Value) int {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	temp := make([]Value, c.size+1)
-	temp[0] = v
-	copy(temp[1:], c.data)
-	c.data = temp
-	c.size++
-	return c.size
+	c.data.Prepend(v)
+	return c.data.Len()
 }
 
-func (c *column) PushFrontTyped(v interface{}) (int, error) {
-	c.mu.Lock()
-	defer c.mu.Unlock()
+// toTyped converts v to Value to be stored in the row,
+// checking the data type and the nullability of the column.
+func (c *column) toTyped(row int, v interface{}) (Value, error) {
+	return goTypedValue(Field{Name: c.header, Type: c.dataType, Nullable: c.nullable}, row, v)
+}
 
-	var value Value
-	switch expected := c.dataType; expected {
+// goTypedValue converts the Go value to the Value of the Field in the row.
+func goTypedValue(fd Field, row int, v interface{}) (Value, error) {
+	if v == nil {
+		if !fd.Nullable {
+			return nil, &SchemaError{Row: row, Column: fd.Name, Err: ErrNotNullable}
+		}
+		return NewNullValue(), nil
+	}
+	switch expected := fd.Type; expected {
 	case STRING:
-		value = NewStringValue(v)
+		return NewStringValue(v), nil
 	default:
 		t := ReflectTypeOf(v)
 		if expected != t { // column is typed
-			return -1, fmt.Errorf("column %q expected data type %q, got %q", c.header, expected, t)
+			return nil, &SchemaError{
+				Row:    row,
+				Column: fd.Name,
+				Value:  fmt.Sprintf("%v", v),
+				Err:    fmt.Errorf("%w (expected %q, got %q)", ErrTypeMismatch, expected, t),
+			}
 		}
-		value = ToValue(v)
+		return ToValue(v), nil
 	}
+}
 
-	temp := make([]Value, c.size+1)
-	temp[0] = value
-	copy(temp[1:], c.data)
-	c.data = temp
-	c.size++
-	return c.size, nil
+func (c *column) PushFrontTyped(v interface{}) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	value, err := c.toTyped(0, v)
+	if err != nil {
+		return -1, err
+	}
+	c.data.Prepend(value)
+	return c.data.Len(), nil
 }
 
 func (c *column) PushBack(v Value) int {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.data = append(c.data, v)
-	c.size++
-	return c.size
+	c.data.Append(v)
+	return c.data.Len()
 }
 
 func (c *column) PushBackTyped(v interface{}) (int, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	var value Value
-	switch expected := c.dataType; expected {
-	case STRING:
-		value = NewStringValue(v)
-	default:
-		t := ReflectTypeOf(v)
-		if expected != t { // column is typed
-			return -1, fmt.Errorf("column %q expected data type %q, got %q", c.header, expected, t)
-		}
-		value = ToValue(v)
+	value, err := c.toTyped(c.data.Len(), v)
+	if err != nil {
+		return -1, err
 	}
-
-	c.data = append(c.data, value)
-	c.size++
-	return c.size, nil
+	c.data.Append(value)
+	return c.data.Len(), nil
 }
 
 func (c *column) Delete(row int) (Value, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if row > c.size-1 {
-		return nil, fmt.Errorf("index out of range (got %d for size %d)", row, c.size)
+	if row > c.data.Len()-1 {
+		return nil, fmt.Errorf("index out of range (got %d for size %d)", row, c.data.Len())
 	}
-	v := c.data[row]
-	copy(c.data[row:], c.data[row+1:])
-	c.data = c.data[:len(c.data)-1 : len(c.data)-1]
-	c.size--
+	v := c.data.Value(row)
+	c.data.Delete(row, row+1)
 	return v, nil
 }
 
@@ -429,29 +640,21 @@ func (c *column) Deletes(start, end int) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	size := c.data.Len()
 	if start < 0 || end < 0 || start > end {
 		return fmt.Errorf("wrong range %d %d", start, end)
 	}
-	if start > c.size {
-		return fmt.Errorf("index out of range (start %d, size %d)", start, c.size)
+	if start > size {
+		return fmt.Errorf("index out of range (start %d, size %d)", start, size)
 	}
-	if end > c.size {
-		return fmt.Errorf("index out of range (end %d, size %d)", end, c.size)
+	if end > size {
+		return fmt.Errorf("index out of range (end %d, size %d)", end, size)
 	}
 	if start == end {
 		return nil
 	}
 
-	delta := end - start
-	c.size = c.size - delta
-	var nds []Value
-	for i := range c.data {
-		if i >= start && i < end {
-			continue
-		}
-		nds = append(nds, c.data[i])
-	}
-	c.data = nds
+	c.data.Delete(start, end)
 	return nil
 }
 
@@ -459,26 +662,22 @@ func (c *column) Keep(start, end int) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	size := c.data.Len()
 	if start < 0 || end < 0 || start > end {
 		return fmt.Errorf("wrong range %d %d", start, end)
 	}
-	if start > c.size {
-		return fmt.Errorf("index out of range (start %d, size %d)", start, c.size)
+	if start > size {
+		return fmt.Errorf("index out of range (start %d, size %d)", start, size)
 	}
-	if end > c.size {
-		return fmt.Errorf("index out of range (end %d, size %d)", end, c.size)
+	if end > size {
+		return fmt.Errorf("index out of range (end %d, size %d)", end, size)
 	}
 	if start == end {
 		return nil
 	}
 
-	delta := end - start
-	c.size = delta
-	var nds []Value
-	for _, v := range c.data[start:end] {
-		nds = append(nds, v)
-	}
-	c.data = nds
+	c.data.Delete(end, size)
+	c.data.Delete(0, start)
 	return nil
 }
 
@@ -486,12 +685,11 @@ func (c *column) PopFront() (Value, bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.size == 0 {
+	if c.data.Len() == 0 {
 		return nil, false
 	}
-	v := c.data[0]
-	c.data = c.data[1:len(c.data):len(c.data)]
-	c.size--
+	v := c.data.Value(0)
+	c.data.Delete(0, 1)
 	return v, true
 }
 
@@ -499,12 +697,12 @@ func (c *column) PopBack() (Value, bool) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.size == 0 {
+	size := c.data.Len()
+	if size == 0 {
 		return nil, false
 	}
-	v := c.data[c.size-1]
-	c.data = c.data[:len(c.data)-1 : len(c.data)-1]
-	c.size--
+	v := c.data.Value(size - 1)
+	c.data.Delete(size-1, size)
 	return v, true
 }
 
@@ -512,32 +710,124 @@ func (c *column) Appends(v Value, targetSize int) error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if c.size > 0 && c.size > targetSize {
-		return fmt.Errorf("cannot append with target size %d, which is less than the column size %d (can't overwrite)", targetSize, c.size)
+	size := c.data.Len()
+	if size > 0 && size > targetSize {
+		return fmt.Errorf("cannot append with target size %d, which is less than the column size %d (can't overwrite)", targetSize, size)
 	}
 
-	for i := c.size; i < targetSize; i++ {
-		c.data = append(c.data, v)
-		c.size++
+	for i := size; i < targetSize; i++ {
+		c.data.Append(v)
 	}
 	return nil
 }
 
 func (c *column) Copy() Column {
-	c2 := &column{
-		header: c.header,
-		size:   c.size,
-		data:   make([]Value, len(c.data)),
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return &column{
+		dataType: c.dataType,
+		header:   c.header,
+		nullable: c.nullable,
+		layout:   c.layout,
+		data:     c.data.Copy(),
+	}
+}
+
+// takeColumn returns a new Column with the rows of col in the order of
+// rows, which are null if out of col. The storage is shared with col
+// until either is written, so taking the rows does not copy them.
+func takeColumn(col Column, rows []int) Column {
+	if c, ok := col.(*column); ok {
+		if nc, ok := c.take(rows); ok {
+			return nc
+		}
+	}
+	nc := NewColumnField(col.Field())
+	for _, row := range rows {
+		nc.PushBack(valueAt(col, row))
+	}
+	return nc
+}
+
+// take returns a new Column with the rows, or false if any of
+// the rows is out of the Column.
+func (c *column) take(rows []int) (Column, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	n := c.data.Len()
+	for _, row := range rows {
+		if row < 0 || row >= n {
+			return nil, false
+		}
+	}
+	return &column{
+		dataType: c.dataType,
+		header:   c.header,
+		nullable: c.nullable,
+		layout:   c.layout,
+		data:     c.data.Take(rows),
+	}, true
+}
+
+// permuteColumn reorders the rows of col in place,
+// in the order of perm of all the rows.
+func permuteColumn(col Column, perm []int) {
+	if c, ok := col.(*column); ok {
+		c.mu.Lock()
+		c.data = c.data.Take(perm).Copy()
+		c.mu.Unlock()
+		return
+	}
+	vs := make([]Value, len(perm))
+	for i, row := range perm {
+		vs[i] = valueAt(col, row)
+	}
+	for i, v := range vs {
+		col.Set(i, v)
+	}
+}
+
+// sortValues sorts the rows as Values, and stores them back
+// in the data type of the column.
+func (c *column) sortValues(by func([]Value) sort.Interface) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	vs := make([]Value, c.data.Len())
+	for i := range vs {
+		vs[i] = c.data.Value(i)
 	}
-	for i := range c.data {
-		c2.data[i] = c.data[i].Copy()
+	sort.Sort(by(vs))
+
+	data := newColumnData(c.dataType)
+	for _, v := range vs {
+		data.Append(v)
 	}
-	return c2
+	c.data = data
+}
+
+func (c *column) SortByStringAscending() {
+	c.sortValues(func(vs []Value) sort.Interface { return ByStringAscending(vs) })
+}
+
+func (c *column) SortByStringDescending() {
+	c.sortValues(func(vs []Value) sort.Interface { return ByStringDescending(vs) })
 }
 
-func (c *column) SortByStringAscending()    { sort.Sort(ByStringAscending(c.data)) }
-func (c *column) SortByStringDescending()   { sort.Sort(ByStringDescending(c.data)) }
-func (c *column) SortByFloat64Ascending()   { sort.Sort(ByFloat64Ascending(c.data)) }
-func (c *column) SortByFloat64Descending()  { sort.Sort(ByFloat64Descending(c.data)) }
-func (c *column) SortByDurationAscending()  { sort.Sort(ByDurationAscending(c.data)) }
-func (c *column) SortByDurationDescending() { sort.Sort(ByDurationDescending(c.data)) }
+func (c *column) SortByFloat64Ascending() {
+	c.sortValues(func(vs []Value) sort.Interface { return ByFloat64Ascending(vs) })
+}
+
+func (c *column) SortByFloat64Descending() {
+	c.sortValues(func(vs []Value) sort.Interface { return ByFloat64Descending(vs) })
+}
+
+func (c *column) SortByDurationAscending() {
+	c.sortValues(func(vs []Value) sort.Interface { return ByDurationAscending(vs) })
+}
+
+func (c *column) SortByDurationDescending() {
+	c.sortValues(func(vs []Value) sort.Interface { return ByDurationDescending(vs) })
+}
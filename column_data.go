@@ -0,0 +1,242 @@
+package dataframe
+
+import "time"
+
+// columnData is the storage that backs a column.
+// STRING and TIME columns keep the Value as it is,
+// and other data types are stored in native Go slices.
+// Null rows are tracked in a validity bitmap.
+type columnData interface {
+	// Len returns the number of rows.
+	Len() int
+
+	// Value returns the row as Value. It returns Null if the row is null.
+	Value(row int) Value
+
+	// IsNull returns true if the row is null.
+	IsNull(row int) bool
+
+	// NullCount returns the number of null rows.
+	NullCount() int
+
+	// Set overwrites the row. It returns false if v cannot
+	// be converted to the data type of the storage.
+	Set(row int, v Value) bool
+
+	// Append appends v. If v cannot be converted to the data type
+	// of the storage, it appends a null and returns false.
+	Append(v Value) bool
+
+	// Prepend inserts v at front. If v cannot be converted to the data
+	// type of the storage, it inserts a null and returns false.
+	Prepend(v Value) bool
+
+	// Delete deletes rows by index [start, end).
+	Delete(start, end int)
+
+	// Take returns a new storage with the rows in the order of idx.
+	// The storages share the rows until either of them is written.
+	Take(idx []int) columnData
+
+	// Snapshot returns a new storage with the same rows, which does not
+	// change with the writes to the storage, without copying the rows.
+	Snapshot() columnData
+
+	// Copy deep-copies the storage.
+	Copy() columnData
+}
+
+// typedData stores rows in a Go slice, and converts
+// between the element type and Value.
+type typedData[T any] struct {
+	rows  []T
+	nulls bitmap
+
+	// from converts Value to the element type.
+	from func(v Value) (T, bool)
+
+	// to converts the element to Value.
+	to func(v T) Value
+
+	// sel selects the rows of the storage from rows, if not nil,
+	// so that Take does not copy the rows.
+	sel []int
+
+	// shared is true if rows and nulls are shared with other
+	// storages, and must be copied before written.
+	shared bool
+}
+
+// convert converts v to the element type. It returns the zero value
+// and null true if v is null or cannot be converted, and ok false
+// if the conversion failed.
+func (d *typedData[T]) convert(v Value) (tv T, null, ok bool) {
+	if v == nil || v.IsNull() {
+		return tv, true, true
+	}
+	tv, ok = d.from(v)
+	if !ok {
+		var zero T
+		return zero, true, false
+	}
+	return tv, false, true
+}
+
+func newColumnData(tp DATA_TYPE) columnData {
+	switch tp {
+	case INT64:
+		return &typedData[int64]{
+			from: func(v Value) (int64, bool) { return v.Int64() },
+			to:   func(v int64) Value { return Int64(v) },
+		}
+	case UINT64:
+		return &typedData[uint64]{
+			from: func(v Value) (uint64, bool) { return v.Uint64() },
+			to:   func(v uint64) Value { return Uint64(v) },
+		}
+	case FLOAT64:
+		return &typedData[float64]{
+			from: func(v Value) (float64, bool) { return v.Float64() },
+			to:   func(v float64) Value { return Float64(v) },
+		}
+	case BOOL:
+		return &typedData[bool]{
+			from: func(v Value) (bool, bool) { return v.Bool() },
+			to:   func(v bool) Value { return Bool(v) },
+		}
+	case DURATION:
+		return &typedData[time.Duration]{
+			from: func(v Value) (time.Duration, bool) { return v.Duration() },
+			to:   func(v time.Duration) Value { return GoDuration(v) },
+		}
+	default: // STRING, TIME
+		return &typedData[Value]{
+			from: func(v Value) (Value, bool) { return v, true },
+			to:   func(v Value) Value { return v },
+		}
+	}
+}
+
+// index returns the index in rows of the row.
+func (d *typedData[T]) index(row int) int {
+	if d.sel != nil {
+		return d.sel[row]
+	}
+	return row
+}
+
+// own copies the shared rows, before they are written.
+func (d *typedData[T]) own() {
+	if !d.shared {
+		return
+	}
+	d.rows, d.nulls = d.clone()
+	d.sel, d.shared = nil, false
+}
+
+// clone returns a copy of the rows and the nulls.
+func (d *typedData[T]) clone() ([]T, bitmap) {
+	if d.sel == nil {
+		rows := make([]T, len(d.rows))
+		copy(rows, d.rows)
+		return rows, d.nulls.copy()
+	}
+	rows := make([]T, len(d.sel))
+	for i, j := range d.sel {
+		rows[i] = d.rows[j]
+	}
+	return rows, d.nulls.take(d.sel)
+}
+
+func (d *typedData[T]) Len() int {
+	if d.sel != nil {
+		return len(d.sel)
+	}
+	return len(d.rows)
+}
+
+func (d *typedData[T]) Value(row int) Value {
+	i := d.index(row)
+	if d.nulls.get(i) {
+		return NewNullValue()
+	}
+	return d.to(d.rows[i])
+}
+
+func (d *typedData[T]) IsNull(row int) bool {
+	return d.nulls.get(d.index(row))
+}
+
+func (d *typedData[T]) NullCount() int {
+	if d.sel == nil || d.nulls.count() == 0 {
+		return d.nulls.count()
+	}
+	n := 0
+	for _, i := range d.sel {
+		if d.nulls.get(i) {
+			n++
+		}
+	}
+	return n
+}
+
+func (d *typedData[T]) Set(row int, v Value) bool {
+	tv, null, ok := d.convert(v)
+	if !ok {
+		return false
+	}
+	d.own()
+	d.rows[row] = tv
+	d.nulls.set(row, null)
+	return true
+}
+
+func (d *typedData[T]) Append(v Value) bool {
+	tv, null, ok := d.convert(v)
+	d.own()
+	d.rows = append(d.rows, tv)
+	d.nulls.set(len(d.rows)-1, null)
+	return ok
+}
+
+func (d *typedData[T]) Prepend(v Value) bool {
+	tv, null, ok := d.convert(v)
+	d.own()
+	temp := make([]T, len(d.rows)+1)
+	temp[0] = tv
+	copy(temp[1:], d.rows)
+	d.rows = temp
+	d.nulls = d.nulls.shift()
+	d.nulls.set(0, null)
+	return ok
+}
+
+func (d *typedData[T]) Delete(start, end int) {
+	d.own()
+	n := len(d.rows) - (end - start)
+	temp := make([]T, n)
+	copy(temp, d.rows[:start])
+	copy(temp[start:], d.rows[end:])
+	d.rows = temp
+	d.nulls = d.nulls.delete(start, end)
+}
+
+func (d *typedData[T]) Take(idx []int) columnData {
+	sel := make([]int, len(idx))
+	for i, row := range idx {
+		sel[i] = d.index(row)
+	}
+	d.shared = true
+	return &typedData[T]{rows: d.rows, nulls: d.nulls, from: d.from, to: d.to, sel: sel, shared: true}
+}
+
+func (d *typedData[T]) Snapshot() columnData {
+	d.shared = true
+	nd := *d
+	return &nd
+}
+
+func (d *typedData[T]) Copy() columnData {
+	rows, nulls := d.clone()
+	return &typedData[T]{rows: rows, nulls: nulls, from: d.from, to: d.to}
+}
@@ -88,6 +88,128 @@ func TestColumnTyped(t *testing.T) {
 	}
 }
 
+func TestColumnTypedNative(t *testing.T) {
+	col := NewColumnTyped("avg_latency_ms", FLOAT64)
+	if dt := col.DataType(); dt != FLOAT64 {
+		t.Fatalf("data type expected %q, got %q", FLOAT64, dt)
+	}
+	for _, v := range []float64{1.5, 2.25, 3} {
+		if _, err := col.PushBackTyped(v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := col.PushBackTyped(int64(1)); err == nil {
+		t.Fatal("expected error")
+	}
+	col.PushFront(NewStringValue("0.5"))
+	expected := []float64{0.5, 1.5, 2.25, 3}
+	rows, ok := col.Float64s()
+	if !ok {
+		t.Fatalf("ok expected true, got %v", ok)
+	}
+	if !reflect.DeepEqual(expected, rows) {
+		t.Fatalf("rows expected %+v, got %+v", expected, rows)
+	}
+	if v, err := col.Value(2); err != nil || !v.EqualTo(NewFloat64Value(2.25)) {
+		t.Fatalf("expected 2.25, got %v(%v)", v, err)
+	}
+	if err := col.Set(0, NewStringValue("a")); err == nil {
+		t.Fatal("expected error")
+	}
+	if srows := col.Rows(); !reflect.DeepEqual(srows, []string{"0.5", "1.5", "2.25", "3"}) {
+		t.Fatalf("rows expected %+v, got %+v", []string{"0.5", "1.5", "2.25", "3"}, srows)
+	}
+
+	col.SortByFloat64Descending()
+	if v, ok := col.Front(); !ok || !v.EqualTo(NewFloat64Value(3.0)) {
+		t.Fatalf("expected 3, got %v", v)
+	}
+	cp := col.Copy()
+	if cp.DataType() != FLOAT64 || cp.Count() != 4 {
+		t.Fatalf("expected %q with 4 rows, got %q with %d rows", FLOAT64, cp.DataType(), cp.Count())
+	}
+
+	ic := NewColumnTyped("VmRSSBytes", UINT64)
+	if _, err := ic.PushBackTyped(uint64(1024)); err != nil {
+		t.Fatal(err)
+	}
+	if irows, ok := ic.Int64s(); !ok || !reflect.DeepEqual(irows, []int64{1024}) {
+		t.Fatalf("rows expected [1024], got %+v(%v)", irows, ok)
+	}
+
+	dc := NewColumnTyped("took", DURATION)
+	if _, err := dc.PushBackTyped(time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dc.PushBackTyped(int64(1)); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestColumnNull(t *testing.T) {
+	col := NewColumnTyped("avg_latency_ms", FLOAT64)
+	for i := 0; i < 130; i++ {
+		col.PushBack(NewFloat64Value(float64(i)))
+	}
+	col.PushBack(NewNullValue())
+	col.PushBack(NewStringValue("a"))
+	col.PushFront(NewNullValue())
+	if _, err := col.PushBackTyped(nil); err != nil {
+		t.Fatal(err)
+	}
+	if n := col.NullCount(); n != 4 {
+		t.Fatalf("null count expected 4, got %d", n)
+	}
+	if _, ok := col.Float64s(); ok {
+		t.Fatalf("ok expected false, got %v", ok)
+	}
+	rows, mask := col.Float64sMask()
+	if len(rows) != 134 || len(mask) != 134 {
+		t.Fatalf("expected 134 rows, got %d, %d", len(rows), len(mask))
+	}
+	if mask[0] || !mask[1] || rows[1] != 0 || !mask[130] || rows[130] != 129 || mask[131] || mask[132] || mask[133] {
+		t.Fatalf("unexpected mask %v", mask)
+	}
+
+	fv, ok := col.FrontNonNil()
+	if !ok || !fv.EqualTo(NewFloat64Value(0.0)) {
+		t.Fatalf("expected 0, got %v", fv)
+	}
+	bv, ok := col.BackNonNil()
+	if !ok || !bv.EqualTo(NewFloat64Value(129.0)) {
+		t.Fatalf("expected 129, got %v", bv)
+	}
+
+	if err := col.Deletes(0, 65); err != nil {
+		t.Fatal(err)
+	}
+	if v, err := col.Value(0); err != nil || !v.EqualTo(NewFloat64Value(64.0)) {
+		t.Fatalf("expected 64, got %v(%v)", v, err)
+	}
+	if v, err := col.Value(66); err != nil || !v.IsNull() {
+		t.Fatalf("expected null, got %v(%v)", v, err)
+	}
+	if err := col.Set(66, NewFloat64Value(1.5)); err != nil {
+		t.Fatal(err)
+	}
+	if n := col.Copy().NullCount(); n != 2 {
+		t.Fatalf("null count expected 2, got %d", n)
+	}
+
+	sc := NewColumn("STATE")
+	sc.PushBack(NewStringValue(""))
+	sc.PushBack(NewNullValue())
+	if v, _ := sc.Value(0); v.IsNull() {
+		t.Fatalf("expected non-null, got %v", v)
+	}
+	if v, _ := sc.Value(1); !v.IsNull() {
+		t.Fatalf("expected null, got %v", v)
+	}
+	if rows := sc.Rows(); !reflect.DeepEqual(rows, []string{"", ""}) {
+		t.Fatalf("rows expected %q, got %q", []string{"", ""}, rows)
+	}
+}
+
 func TestColumnRow(t *testing.T) {
 	c := NewColumn("A")
 	for i := 0; i < 3; i++ {
@@ -0,0 +1,97 @@
+package dataframe
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+)
+
+// Compression defines the compression codec of binary formats.
+type Compression int
+
+const (
+	// Compression_None does not compress.
+	Compression_None Compression = iota
+
+	// Compression_Snappy compresses in Snappy.
+	Compression_Snappy
+
+	// Compression_Gzip compresses in gzip.
+	Compression_Gzip
+
+	// Compression_Zstd compresses in Zstandard.
+	Compression_Zstd
+)
+
+func (c Compression) String() string {
+	switch c {
+	case Compression_None:
+		return "NONE"
+	case Compression_Snappy:
+		return "SNAPPY"
+	case Compression_Gzip:
+		return "GZIP"
+	case Compression_Zstd:
+		return "ZSTD"
+	default:
+		return fmt.Sprintf("Compression(%d)", int(c))
+	}
+}
+
+func (c Compression) compress(src []byte) ([]byte, error) {
+	switch c {
+	case Compression_None:
+		return src, nil
+	case Compression_Snappy:
+		return snappyEncode(src), nil
+	case Compression_Gzip:
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(src); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	case Compression_Zstd:
+		return zstdEncode(src), nil
+	default:
+		return nil, fmt.Errorf("compression %v is not supported", c)
+	}
+}
+
+// decompress decompresses src, whose decompressed size is n
+// if known, or -1 if not.
+func (c Compression) decompress(src []byte, n int) ([]byte, error) {
+	var dst []byte
+	var err error
+	switch c {
+	case Compression_None:
+		dst = src
+	case Compression_Snappy:
+		if n < 0 {
+			return nil, fmt.Errorf("%v: decompressed size is unknown", c)
+		}
+		dst, err = snappyDecode(src, n)
+	case Compression_Gzip:
+		var zr *gzip.Reader
+		zr, err = gzip.NewReader(bytes.NewReader(src))
+		if err != nil {
+			return nil, err
+		}
+		dst, err = io.ReadAll(zr)
+	case Compression_Zstd:
+		dst, err = zstdDecode(src)
+	default:
+		return nil, fmt.Errorf("compression %v is not supported", c)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if n >= 0 && len(dst) != n {
+		return nil, fmt.Errorf("%v: decompressed %d bytes (expected %d)", c, len(dst), n)
+	}
+	return dst, nil
+}
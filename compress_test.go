@@ -0,0 +1,70 @@
+package dataframe
+
+import (
+	"bytes"
+	"math/rand"
+	"os"
+	"testing"
+)
+
+func TestCompression(t *testing.T) {
+	csv, err := os.ReadFile("testdata/bench-01-all-aggregated.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	random := make([]byte, 200000)
+	rand.New(rand.NewSource(1)).Read(random)
+	inputs := [][]byte{
+		nil,
+		[]byte("a"),
+		bytes.Repeat([]byte("abc"), 100),
+		csv,
+		bytes.Repeat(csv, 4), // more than a zstd block
+		random,
+	}
+	for _, c := range []Compression{Compression_None, Compression_Snappy, Compression_Gzip, Compression_Zstd} {
+		for i, in := range inputs {
+			comp, err := c.compress(in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			out, err := c.decompress(comp, len(in))
+			if err != nil {
+				t.Fatalf("%v #%d: %v", c, i, err)
+			}
+			if !bytes.Equal(out, in) {
+				t.Fatalf("%v #%d: expected %d bytes, got %d bytes", c, i, len(in), len(out))
+			}
+			if c != Compression_None && len(in) == len(csv) && len(comp) >= len(in)*2/3 {
+				t.Fatalf("%v #%d: expected compression, got %d bytes from %d bytes", c, i, len(comp), len(in))
+			}
+			if _, err := c.decompress(comp, len(in)+1); err == nil {
+				t.Fatalf("%v #%d: expected error for the wrong size", c, i)
+			}
+		}
+	}
+}
+
+func TestZstdDecode(t *testing.T) {
+	// compressed by the reference implementation at level 19,
+	// with Huffman coded literals and FSE coded sequences
+	comp, err := os.ReadFile("testdata/bench-01-all-aggregated.csv.zst")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := os.ReadFile("testdata/bench-01-all-aggregated.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := zstdDecode(comp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, expected) {
+		t.Fatalf("expected %d bytes, got %d bytes", len(expected), len(out))
+	}
+
+	if _, err := zstdDecode(comp[:len(comp)/2]); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,130 @@
+package dataframe
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ConcatColumns defines which columns Concat keeps.
+type ConcatColumns int
+
+const (
+	// ConcatColumns_Union keeps the columns in any of the Frames,
+	// with nulls in the rows of the Frames without them.
+	ConcatColumns_Union ConcatColumns = iota
+
+	// ConcatColumns_Intersection keeps the columns in all the Frames.
+	ConcatColumns_Intersection
+)
+
+// ConcatOptions configures Concat.
+type ConcatOptions struct {
+	// Columns defines which columns to keep.
+	Columns ConcatColumns
+
+	// Label is the header of the column of the source Frame of each
+	// row, added as the first column, such as "node". If empty,
+	// the column is not added.
+	Label string
+
+	// Labels are the labels of the Frames in order. If empty,
+	// they are "1", "2", "3" and so on.
+	Labels []string
+}
+
+// Concat stacks the rows of the Frames in order into a new Frame, aligning
+// the columns by header. The columns are in the order of first seen. It
+// returns *SchemaError if the columns of the same header have different
+// data types or layouts.
+func Concat(opts ConcatOptions, frames ...Frame) (Frame, error) {
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("no frames to concatenate")
+	}
+	if opts.Columns != ConcatColumns_Union && opts.Columns != ConcatColumns_Intersection {
+		return nil, fmt.Errorf("concat columns %d is unknown", opts.Columns)
+	}
+	labels := opts.Labels
+	if len(labels) == 0 {
+		labels = make([]string, len(frames))
+		for i := range labels {
+			labels[i] = strconv.Itoa(i + 1)
+		}
+	}
+	if len(labels) != len(frames) {
+		return nil, fmt.Errorf("%d labels do not match %d frames", len(labels), len(frames))
+	}
+
+	// the Field of each header, and the number of Frames with it
+	var fields []Field
+	fieldIdx := make(map[string]int)
+	var seen []int
+	for i, fr := range frames {
+		n := rowCount(fr.Columns())
+		for _, col := range fr.Columns() {
+			fd := col.Field()
+			if col.Count() < n {
+				fd.Nullable = true
+			}
+			j, ok := fieldIdx[fd.Name]
+			if !ok {
+				fieldIdx[fd.Name] = len(fields)
+				fields = append(fields, fd)
+				seen = append(seen, 1)
+				continue
+			}
+			if prev := fields[j]; prev.Type != fd.Type || prev.Layout != fd.Layout {
+				return nil, &SchemaError{
+					Row:    -1,
+					Column: fd.Name,
+					Err:    fmt.Errorf("%w (expected %q with layout %q, got %q with layout %q in frame %d)", ErrTypeMismatch, prev.Type, prev.Layout, fd.Type, fd.Layout, i),
+				}
+			}
+			fields[j].Nullable = fields[j].Nullable || fd.Nullable
+			seen[j]++
+		}
+	}
+
+	var label Column
+	if opts.Label != "" {
+		label = NewColumnField(Field{Name: opts.Label, Type: STRING})
+	}
+	var cols []Column
+	for j, fd := range fields {
+		if seen[j] < len(frames) {
+			if opts.Columns == ConcatColumns_Intersection {
+				continue
+			}
+			fd.Nullable = true
+		}
+		cols = append(cols, NewColumnField(fd))
+	}
+	for i, fr := range frames {
+		n := rowCount(fr.Columns())
+		for _, col := range cols {
+			src, err := fr.Column(col.Header())
+			for row := 0; row < n; row++ {
+				if err != nil {
+					col.PushBack(NewNullValue())
+				} else {
+					col.PushBack(valueAt(src, row))
+				}
+			}
+		}
+		if label != nil {
+			for row := 0; row < n; row++ {
+				label.PushBack(NewStringValue(labels[i]))
+			}
+		}
+	}
+
+	fr := New()
+	if label != nil {
+		cols = append([]Column{label}, cols...)
+	}
+	for _, col := range cols {
+		if err := fr.AddColumn(col); err != nil {
+			return nil, err
+		}
+	}
+	return fr, nil
+}
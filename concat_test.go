@@ -0,0 +1,119 @@
+package dataframe
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestConcat(t *testing.T) {
+	a, err := NewFromRowsTyped([]string{"ts", "cpu"}, [][]string{
+		{"1", "0.5"},
+		{"2", "1.5"},
+	}, Schema{Fields: []Field{
+		{Name: "ts", Type: INT64},
+		{Name: "cpu", Type: FLOAT64},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := NewFromRowsTyped([]string{"mem", "ts"}, [][]string{
+		{"100", "3"},
+	}, Schema{Fields: []Field{
+		{Name: "mem", Type: INT64},
+		{Name: "ts", Type: INT64},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fr, err := Concat(ConcatOptions{Label: "node", Labels: []string{"etcd-1", "etcd-2"}}, a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]string{
+		{"etcd-1", "1", "0.5", ""},
+		{"etcd-1", "2", "1.5", ""},
+		{"etcd-2", "3", "", "100"},
+	}
+	hd, rows := fr.Rows()
+	if expectedHeaders := []string{"node", "ts", "cpu", "mem"}; !reflect.DeepEqual(hd, expectedHeaders) {
+		t.Fatalf("expected %v, got %v", expectedHeaders, hd)
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("expected %v, got %v", expected, rows)
+	}
+	expectedSchema := Schema{Fields: []Field{
+		{Name: "node", Type: STRING},
+		{Name: "ts", Type: INT64},
+		{Name: "cpu", Type: FLOAT64, Nullable: true},
+		{Name: "mem", Type: INT64, Nullable: true},
+	}}
+	if !fr.Schema().Equal(expectedSchema) {
+		t.Fatalf("expected %v, got %v", expectedSchema, fr.Schema())
+	}
+
+	fr, err = Concat(ConcatOptions{Columns: ConcatColumns_Intersection}, a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hd, rows := fr.Rows(); !reflect.DeepEqual(hd, []string{"ts"}) || !reflect.DeepEqual(rows, [][]string{{"1"}, {"2"}, {"3"}}) {
+		t.Fatalf("unexpected %v %v", hd, rows)
+	}
+
+	c, err := NewFromRows([]string{"ts"}, [][]string{{"4"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Concat(ConcatOptions{}, a, c); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("expected ErrTypeMismatch, got %v", err)
+	}
+	if _, err := Concat(ConcatOptions{Labels: []string{"1"}}, a, b); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestConcatMonitor(t *testing.T) {
+	var frames []Frame
+	total := 0
+	for _, fpath := range []string{
+		"testdata/bench-01-etcd-1-monitor.csv",
+		"testdata/bench-01-etcd-2-monitor.csv",
+		"testdata/bench-01-etcd-3-monitor.csv",
+	} {
+		fr, _, err := NewFromCSVInfer(nil, fpath, InferOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		frames = append(frames, fr)
+		total += rowCount(fr.Columns())
+	}
+	fr, err := Concat(ConcatOptions{Label: "node"}, frames...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fr.Count() != frames[0].Count()+1 {
+		t.Fatalf("expected %d columns, got %d", frames[0].Count()+1, fr.Count())
+	}
+	g, err := fr.GroupBy("node")
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts, err := g.Agg(Agg{Column: "unix_ts", Func: AggFunc_Count})
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, err := counts.Column("unix_ts_count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vs, _ := col.Int64s()
+	for i, v := range vs {
+		if n := int64(rowCount(frames[i].Columns())); v != n {
+			t.Fatalf("node %d: expected %d rows, got %d", i+1, n, v)
+		}
+	}
+	if n := rowCount(fr.Columns()); n != total {
+		t.Fatalf("expected %d rows, got %d", total, n)
+	}
+}
@@ -0,0 +1,286 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// CorrMethod defines the correlation coefficient.
+type CorrMethod int
+
+const (
+	// CorrMethod_Pearson is the linear correlation of the values.
+	CorrMethod_Pearson CorrMethod = iota
+
+	// CorrMethod_Spearman is the Pearson correlation of the ranks,
+	// with the ties of the average ranks.
+	CorrMethod_Spearman
+
+	// CorrMethod_Kendall is the Kendall tau-b of the values.
+	CorrMethod_Kendall
+)
+
+// corrFloat64s returns the values of the numeric Column in float64,
+// and whether each row is neither null nor NaN. The rows beyond
+// the Column are not valid.
+func corrFloat64s(col Column, n int) ([]float64, []bool, error) {
+	fd := col.Field()
+	switch fd.Type {
+	case INT64, UINT64, FLOAT64, DURATION:
+	default:
+		return nil, nil, fmt.Errorf("%q of %s is not numeric", fd.Name, fd.Type)
+	}
+	fs, valid := make([]float64, n), make([]bool, n)
+	for row, cn := 0, min(n, col.Count()); row < cn; row++ {
+		if v := valueAt(col, row); !v.IsNull() {
+			fs[row] = valueFloat64(fd.Type, v)
+			valid[row] = !math.IsNaN(fs[row])
+		}
+	}
+	return fs, valid, nil
+}
+
+// pairwise returns the pairs of the rows that are valid in both.
+func pairwise(xs, ys []float64, xok, yok []bool) ([]float64, []float64) {
+	var px, py []float64
+	for row := range xs {
+		if xok[row] && yok[row] {
+			px, py = append(px, xs[row]), append(py, ys[row])
+		}
+	}
+	return px, py
+}
+
+// covariance returns the sample covariance, or NaN for less than 2 pairs.
+func covariance(xs, ys []float64) float64 {
+	if len(xs) < 2 {
+		return math.NaN()
+	}
+	mx, my := mean(xs), mean(ys)
+	var sum float64
+	for i := range xs {
+		sum += (xs[i] - mx) * (ys[i] - my)
+	}
+	return sum / float64(len(xs)-1)
+}
+
+// correlation returns the coefficient of the pairs, or NaN for less
+// than 2 pairs or the constant values.
+func correlation(xs, ys []float64, method CorrMethod) float64 {
+	if len(xs) < 2 {
+		return math.NaN()
+	}
+	switch method {
+	case CorrMethod_Spearman:
+		return pearson(averageRanks(xs), averageRanks(ys))
+	case CorrMethod_Kendall:
+		return kendall(xs, ys)
+	default:
+		return pearson(xs, ys)
+	}
+}
+
+func pearson(xs, ys []float64) float64 {
+	mx, my := mean(xs), mean(ys)
+	var sxy, sxx, syy float64
+	for i := range xs {
+		dx, dy := xs[i]-mx, ys[i]-my
+		sxy += dx * dy
+		sxx += dx * dx
+		syy += dy * dy
+	}
+	if sxx == 0 || syy == 0 {
+		return math.NaN()
+	}
+	return sxy / math.Sqrt(sxx*syy)
+}
+
+// averageRanks returns the ranks of the values from 1,
+// with the ties of the average ranks.
+func averageRanks(fs []float64) []float64 {
+	idx := make([]int, len(fs))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool { return fs[idx[i]] < fs[idx[j]] })
+	ranks := make([]float64, len(fs))
+	for i := 0; i < len(idx); {
+		j := i + 1
+		for j < len(idx) && fs[idx[j]] == fs[idx[i]] {
+			j++
+		}
+		for k := i; k < j; k++ {
+			ranks[idx[k]] = float64(i+j+1) / 2
+		}
+		i = j
+	}
+	return ranks
+}
+
+// tiedPairs returns the number of the pairs of the equal adjacent values.
+func tiedPairs(n int, equal func(i, j int) bool) int64 {
+	var pairs int64
+	for i := 0; i < n; {
+		j := i + 1
+		for j < n && equal(i, j) {
+			j++
+		}
+		pairs += int64(j-i) * int64(j-i-1) / 2
+		i = j
+	}
+	return pairs
+}
+
+// kendall returns the Kendall tau-b in O(n log n), counting the
+// discordant pairs as the swaps to merge sort the ys in the order
+// of the xs (Knight's algorithm).
+func kendall(xs, ys []float64) float64 {
+	n := len(xs)
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool {
+		a, b := idx[i], idx[j]
+		if xs[a] != xs[b] {
+			return xs[a] < xs[b]
+		}
+		return ys[a] < ys[b]
+	})
+	xTies := tiedPairs(n, func(i, j int) bool { return xs[idx[i]] == xs[idx[j]] })
+	jointTies := tiedPairs(n, func(i, j int) bool {
+		return xs[idx[i]] == xs[idx[j]] && ys[idx[i]] == ys[idx[j]]
+	})
+
+	sorted := make([]float64, n)
+	for i, row := range idx {
+		sorted[i] = ys[row]
+	}
+	swaps := mergeSwaps(sorted, make([]float64, n))
+	yTies := tiedPairs(n, func(i, j int) bool { return sorted[i] == sorted[j] })
+
+	pairs := int64(n) * int64(n-1) / 2
+	den := math.Sqrt(float64(pairs-xTies) * float64(pairs-yTies))
+	if den == 0 {
+		return math.NaN()
+	}
+	return float64(pairs-xTies-yTies+jointTies-2*swaps) / den
+}
+
+// mergeSwaps merge sorts the values, and returns the number of
+// the pairs in the descending order.
+func mergeSwaps(fs, buf []float64) int64 {
+	if len(fs) < 2 {
+		return 0
+	}
+	mid := len(fs) / 2
+	swaps := mergeSwaps(fs[:mid], buf[:mid]) + mergeSwaps(fs[mid:], buf[mid:])
+	i, j, k := 0, mid, 0
+	for i < mid && j < len(fs) {
+		if fs[j] < fs[i] {
+			buf[k] = fs[j]
+			swaps += int64(mid - i)
+			j++
+		} else {
+			buf[k] = fs[i]
+			i++
+		}
+		k++
+	}
+	k += copy(buf[k:], fs[i:mid])
+	copy(buf[k:], fs[j:])
+	copy(fs, buf[:len(fs)])
+	return swaps
+}
+
+// matrix returns the square Frame of fn over the pairs of each two
+// numeric columns, with the headers in the column "column".
+func (f *frame) matrix(fn func(xs, ys []float64) float64) (Frame, error) {
+	cols := f.Columns()
+	n := rowCount(cols)
+	var headers []string
+	var fss [][]float64
+	var valids [][]bool
+	for _, col := range cols {
+		fs, valid, err := corrFloat64s(col, n)
+		if err != nil {
+			continue
+		}
+		headers = append(headers, col.Header())
+		fss, valids = append(fss, fs), append(valids, valid)
+	}
+	if len(headers) == 0 {
+		return nil, fmt.Errorf("no numeric columns")
+	}
+
+	hcol := NewColumnField(Field{Name: "column", Type: STRING})
+	out := make([]Column, len(headers))
+	for i, header := range headers {
+		hcol.PushBack(NewStringValue(header))
+		out[i] = NewColumnField(Field{Name: header, Type: FLOAT64, Nullable: true})
+	}
+	for i := range headers {
+		for j := range headers {
+			fv := fn(pairwise(fss[i], fss[j], valids[i], valids[j]))
+			if math.IsNaN(fv) {
+				out[j].PushBack(NewNullValue())
+			} else {
+				out[j].PushBack(Float64(fv))
+			}
+		}
+	}
+
+	fr := New()
+	for _, col := range append([]Column{hcol}, out...) {
+		if err := fr.AddColumn(col); err != nil {
+			return nil, err
+		}
+	}
+	return fr, nil
+}
+
+func (f *frame) Corr(method CorrMethod) (Frame, error) {
+	if method < CorrMethod_Pearson || method > CorrMethod_Kendall {
+		return nil, fmt.Errorf("correlation method %d is unknown", method)
+	}
+	return f.matrix(func(xs, ys []float64) float64 { return correlation(xs, ys, method) })
+}
+
+func (f *frame) Cov() (Frame, error) {
+	return f.matrix(covariance)
+}
+
+// pairs returns the pairs of the valid rows of the Columns.
+func (c *column) pairs(other Column) ([]float64, []float64, error) {
+	n := max(c.Count(), other.Count())
+	xs, xok, err := corrFloat64s(c, n)
+	if err != nil {
+		return nil, nil, err
+	}
+	ys, yok, err := corrFloat64s(other, n)
+	if err != nil {
+		return nil, nil, err
+	}
+	px, py := pairwise(xs, ys, xok, yok)
+	return px, py, nil
+}
+
+func (c *column) Corr(other Column, method CorrMethod) (float64, error) {
+	if method < CorrMethod_Pearson || method > CorrMethod_Kendall {
+		return 0, fmt.Errorf("correlation method %d is unknown", method)
+	}
+	xs, ys, err := c.pairs(other)
+	if err != nil {
+		return 0, err
+	}
+	return correlation(xs, ys, method), nil
+}
+
+func (c *column) Cov(other Column) (float64, error) {
+	xs, ys, err := c.pairs(other)
+	if err != nil {
+		return 0, err
+	}
+	return covariance(xs, ys), nil
+}
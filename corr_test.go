@@ -0,0 +1,145 @@
+package dataframe
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestCorr(t *testing.T) {
+	fr, err := NewFromRowsTyped([]string{"host", "x", "y", "z"}, [][]string{
+		{"a", "1", "2", "5"},
+		{"b", "2", "4", "4"},
+		{"c", "3", "", "3"},
+		{"d", "4", "8", "3"},
+		{"e", "5", "100", "1"},
+	}, Schema{Fields: []Field{
+		{Name: "host", Type: STRING},
+		{Name: "x", Type: INT64},
+		{Name: "y", Type: FLOAT64, Nullable: true},
+		{Name: "z", Type: FLOAT64},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	x, _ := fr.Column("x")
+	y, _ := fr.Column("y")
+	z, _ := fr.Column("z")
+
+	tests := []struct {
+		a, b     Column
+		method   CorrMethod
+		expected float64
+	}{
+		{x, y, CorrMethod_Spearman, 1},
+		{x, y, CorrMethod_Kendall, 1},
+		{x, z, CorrMethod_Spearman, -0.9746794344808963},
+		{x, z, CorrMethod_Kendall, -0.9486832980505138},
+		{x, z, CorrMethod_Pearson, -0.9594032236002469},
+	}
+	for i, tt := range tests {
+		fv, err := tt.a.Corr(tt.b, tt.method)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if math.Abs(fv-tt.expected) > 1e-12 {
+			t.Fatalf("#%d: expected %v, got %v", i, tt.expected, fv)
+		}
+	}
+	if cov, _ := x.Cov(z); cov != -2.25 {
+		t.Fatalf("expected -2.25, got %v", cov)
+	}
+
+	m, err := fr.Corr(CorrMethod_Kendall)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hd, expected := m.Headers(), []string{"column", "x", "y", "z"}; !reflect.DeepEqual(hd, expected) {
+		t.Fatalf("expected %v, got %v", expected, hd)
+	}
+	col, _ := m.Column("y")
+	if rows, expected := col.Rows(), []string{"1", "1", "-1"}; !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("expected %v, got %v", expected, rows)
+	}
+	m, err = fr.Cov()
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, _ = m.Column("x")
+	if rows, expected := col.Rows(), []string{"2.5", "66.66666666666667", "-2.25"}; !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("expected %v, got %v", expected, rows)
+	}
+
+	host, _ := fr.Column("host")
+	if _, err := host.Corr(x, CorrMethod_Pearson); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := fr.Corr(CorrMethod(9)); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestKendallNaive(t *testing.T) {
+	rd := rand.New(rand.NewSource(1))
+	xs, ys := make([]float64, 300), make([]float64, 300)
+	for i := range xs {
+		xs[i], ys[i] = float64(rd.Intn(10)), float64(rd.Intn(7))+xs[i]/3
+	}
+	var c, d, tx, ty float64
+	for i := range xs {
+		for j := i + 1; j < len(xs); j++ {
+			s := (xs[i] - xs[j]) * (ys[i] - ys[j])
+			switch {
+			case s > 0:
+				c++
+			case s < 0:
+				d++
+			case xs[i] == xs[j] && ys[i] != ys[j]:
+				tx++
+			case xs[i] != xs[j] && ys[i] == ys[j]:
+				ty++
+			}
+		}
+	}
+	expected := (c - d) / math.Sqrt((c+d+tx)*(c+d+ty))
+	if fv := kendall(xs, ys); math.Abs(fv-expected) > 1e-12 {
+		t.Fatalf("expected %v, got %v", expected, fv)
+	}
+}
+
+func TestCorrAggregated(t *testing.T) {
+	fr, _, err := NewFromCSVInfer(nil, "testdata/bench-01-all-aggregated.csv", InferOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cpu, err := fr.Column("avg_cpu_etcd3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	latency, err := fr.Column("avg_latency_ms_etcd3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := fr.Corr(CorrMethod_Spearman)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Count() != len(fr.Headers())+1 {
+		t.Fatalf("expected %d columns, got %d", len(fr.Headers())+1, m.Count())
+	}
+	fv, err := cpu.Corr(latency, CorrMethod_Spearman)
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, _ := m.Column("avg_cpu_etcd3")
+	headers, _ := m.Column("column")
+	for row, n := 0, headers.Count(); row < n; row++ {
+		if v, _ := headers.Value(row); v.EqualTo(NewStringValue("avg_latency_ms_etcd3")) {
+			got, _ := col.Value(row)
+			if gv, _ := got.Float64(); gv != fv || !(fv >= -1 && fv <= 1) {
+				t.Fatalf("expected %v, got %v", fv, gv)
+			}
+		}
+	}
+}
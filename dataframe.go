@@ -3,7 +3,10 @@ package dataframe
 import (
 	"encoding/csv"
 	"fmt"
+	"io"
+	"iter"
 	"sync"
+	"time"
 )
 
 // Frame contains data.
@@ -11,7 +14,9 @@ type Frame interface {
 	// Headers returns the slice of headers in order. Header name is unique among its Frame.
 	Headers() []string
 
-	// AddColumn adds a Column to Frame.
+	// AddColumn adds a Column to Frame. If the Frame was created
+	// with a Schema, it returns *SchemaError when the Column does not
+	// match its Field.
 	AddColumn(c Column) error
 
 	// Column returns the Column by its header name.
@@ -20,6 +25,9 @@ type Frame interface {
 	// Columns returns all Columns.
 	Columns() []Column
 
+	// Schema returns the Schema derived from the Columns.
+	Schema() Schema
+
 	// Count returns the number of Columns in the Frame.
 	Count() int
 
@@ -35,6 +43,26 @@ type Frame interface {
 	// CSV saves the Frame to a CSV file.
 	CSV(fpath string) error
 
+	// WriteCSV writes the Frame to io.Writer in CSV, or in other
+	// delimiter-separated formats such as TSV.
+	WriteCSV(w io.Writer, opts WriteOptions) error
+
+	// WriteNDJSON writes the Frame to io.Writer in newline-delimited
+	// JSON, one object per row with the keys in the order of Headers.
+	WriteNDJSON(w io.Writer) error
+
+	// WriteParquet writes the Frame to io.Writer in Parquet.
+	WriteParquet(w io.Writer, opts ParquetWriteOptions) error
+
+	// WriteArrowIPC writes the Frame to io.Writer in Arrow IPC.
+	WriteArrowIPC(w io.Writer, opts ArrowWriteOptions) error
+
+	// MarshalJSON encodes the Frame in JSON with its Schema.
+	MarshalJSON() ([]byte, error)
+
+	// UnmarshalJSON decodes the JSON from MarshalJSON into the Frame.
+	UnmarshalJSON(data []byte) error
+
 	// CSVHorizontal saves the Frame to a CSV file
 	// in a horizontal way. The first column is header.
 	// And data are aligned from left to right.
@@ -43,14 +71,95 @@ type Frame interface {
 	// Rows returns the header and data slices.
 	Rows() ([]string, [][]string)
 
+	// Row returns the row of the index.
+	Row(i int) (Row, error)
+
+	// IterRows returns the iterator of the rows, to be used in
+	// "for i, row := range fr.IterRows()". The rows do not change
+	// with the writes to the Frame during the iteration.
+	IterRows() iter.Seq2[int, Row]
+
+	// AppendRow appends the row of the Values by header, which are
+	// Values or Go values as in PushBackTyped. The missing headers are
+	// null. It returns *SchemaError if a Value does not match its Column.
+	AppendRow(row map[string]interface{}) error
+
+	// AppendRowValues appends the row of the Values in the order of
+	// the Columns. It returns *SchemaError if a Value does not match
+	// its Column.
+	AppendRowValues(row []Value) error
+
 	// Sort sorts the Frame.
 	Sort(header string, st SortType, so SortOption) error
+
+	// SortBy sorts the rows of the Frame in place by the keys in order,
+	// comparing the Values in the data types of the key columns.
+	// The sort is stable.
+	SortBy(keys ...SortKey) error
+
+	// GroupBy groups the rows by the values of the key columns,
+	// to be aggregated into a new Frame with one row per group.
+	GroupBy(keys ...string) (Grouped, error)
+
+	// Resample buckets the rows into the intervals of the times in the
+	// Column on, and returns a new Frame with one row for each bucket,
+	// aggregating each numeric column by agg. The empty buckets are
+	// filled by the fill.
+	Resample(on string, interval time.Duration, agg AggFunc, fill ResampleFill) (Frame, error)
+
+	// Melt returns a new Frame in the long format, unpivoting the
+	// valueVars columns into the rows of varName and valueName.
+	Melt(idVars, valueVars []string, varName, valueName string) (Frame, error)
+
+	// Pivot returns a new Frame in the wide format, with one row for
+	// each index, and one column for each value of the columns column
+	// and each of the values columns, aggregated by agg.
+	Pivot(index, columns string, values []string, agg AggFunc, opts PivotOptions) (Frame, error)
+
+	// DropNulls returns a new Frame without the rows with any or all
+	// nulls in the subset columns, or in all the columns if subset
+	// is empty.
+	DropNulls(subset []string, how DropHow) (Frame, error)
+
+	// NullCounts returns the number of null rows of each Column by header.
+	NullCounts() map[string]int
+
+	// Describe returns a new Frame of the summary statistics of the
+	// numeric and STRING columns, with one row for each statistic,
+	// such as count, mean, std, min, the percentiles and max.
+	Describe(percentiles ...float64) (Frame, error)
+
+	// Corr returns the square Frame of the correlation coefficients of
+	// each two numeric columns, over the rows where both are not null.
+	// The first column "column" has the headers. The coefficients are
+	// null for less than 2 rows or the constant columns.
+	Corr(method CorrMethod) (Frame, error)
+
+	// Cov returns the square Frame of the sample covariances of each
+	// two numeric columns, as in Corr.
+	Cov() (Frame, error)
+
+	// Filter returns a new Frame of the rows for which fn returns true.
+	// The new Frame shares the storage of the Columns until either Frame
+	// is written, so that chained filters do not copy the rows.
+	Filter(fn func(Row) bool) (Frame, error)
+
+	// FilterMask returns a new Frame of the rows that are true in
+	// the mask, which must have the same number of rows as the Frame.
+	FilterMask(mask []bool) (Frame, error)
+
+	// Where returns a new Frame of the rows whose Value in the Column
+	// satisfies the comparison with v, such as Where("cpu", CompareOp_Gt, 0.5).
+	Where(header string, op CompareOp, v interface{}) (Frame, error)
 }
 
 type frame struct {
 	mu       sync.Mutex
 	columns  []Column
 	headerTo map[string]int
+
+	// schema is enforced by AddColumn, if not nil.
+	schema *Schema
 }
 
 // New returns a new Frame.
@@ -61,67 +170,117 @@ func New() Frame {
 	}
 }
 
+// NewTyped returns a new Frame that only accepts
+// the Columns matching the Fields of the Schema.
+func NewTyped(schema Schema) Frame {
+	sc := Schema{Fields: make([]Field, len(schema.Fields))}
+	copy(sc.Fields, schema.Fields)
+	return &frame{
+		columns:  []Column{},
+		headerTo: make(map[string]int),
+		schema:   &sc,
+	}
+}
+
 // NewFromRows creates Frame from rows.
 // Pass 'nil' header if first row is used as header strings.
 // Pass 'non-nil' header if the data starts from the first row, without header strings.
+// Rows shorter than the header are padded with null Values,
+// and it returns *SchemaError for a row longer than the header.
 func NewFromRows(header []string, rows [][]string) (Frame, error) {
 	if len(rows) < 1 {
 		return nil, fmt.Errorf("empty row %q", rows)
 	}
-	fr := New()
-	headerN := len(header)
-	if headerN > 0 { // use this as header
-		// assume no header string at top
-		cols := make([]Column, headerN)
-		for i := range cols {
-			cols[i] = NewColumn(header[i])
-		}
-		for _, row := range rows {
-			rowN := len(row)
-			if rowN > headerN {
-				return nil, fmt.Errorf("header %q is not specified correctly for %q", header, row)
-			}
-			for j, v := range row {
-				cols[j].PushBack(NewStringValue(v))
-			}
-			if rowN < headerN { // fill in empty values
-				for k := rowN; k < headerN; k++ {
-					cols[k].PushBack(NewStringValue(""))
-				}
-			}
-		}
-		for _, c := range cols {
-			if err := fr.AddColumn(c); err != nil {
-				return nil, err
-			}
-		}
-		return fr, nil
+	if len(header) == 0 { // use first row as header
+		header, rows = rows[0], rows[1:]
 	}
-	// use first row as header
-	// assume header string at top
-	header = rows[0]
-	headerN = len(header)
+	headerN := len(header)
 	cols := make([]Column, headerN)
 	for i := range cols {
 		cols[i] = NewColumn(header[i])
 	}
 	for i, row := range rows {
-		if i == 0 {
-			continue
-		}
-		rowN := len(row)
-		if rowN > headerN {
-			return nil, fmt.Errorf("header %q is not specified correctly for %q", header, row)
+		if err := checkRowLength(i, row, headerN); err != nil {
+			return nil, err
 		}
 		for j, v := range row {
 			cols[j].PushBack(NewStringValue(v))
 		}
-		if rowN < headerN { // fill in empty values
-			for k := rowN; k < headerN; k++ {
-				cols[k].PushBack(NewStringValue(""))
+		for k := len(row); k < headerN; k++ { // fill in null values
+			cols[k].PushBack(NewNullValue())
+		}
+	}
+	fr := New()
+	for _, c := range cols {
+		if err := fr.AddColumn(c); err != nil {
+			return nil, err
+		}
+	}
+	return fr, nil
+}
+
+// checkRowLength returns *SchemaError if the data row i
+// has more cells than the headers, counting from 0.
+func checkRowLength(i int, row []string, headerN int) error {
+	if len(row) <= headerN {
+		return nil
+	}
+	// the extra cells have no header, nor Field
+	return &SchemaError{
+		Row:   i,
+		Value: row[headerN],
+		Err:   fmt.Errorf("%w (%d values for %d headers)", ErrFieldNotFound, len(row), headerN),
+	}
+}
+
+// NewFromRowsTyped creates Frame from rows, parsing each cell
+// by the Field of the same header name in the Schema.
+// It returns *SchemaError if a cell does not match its Field,
+// or a row is longer than the header.
+// Pass 'nil' header if first row is used as header strings.
+// Pass 'non-nil' header if the data starts from the first row, without header strings.
+// Rows shorter than the header are padded with null Values.
+func NewFromRowsTyped(header []string, rows [][]string, schema Schema) (Frame, error) {
+	if len(header) == 0 {
+		if len(rows) < 1 {
+			return nil, fmt.Errorf("empty row %q", rows)
+		}
+		header, rows = rows[0], rows[1:]
+	}
+	headerN := len(header)
+	fields := make([]Field, headerN)
+	cols := make([]Column, headerN)
+	for i, hd := range header {
+		fd, ok := schema.Field(hd)
+		if !ok {
+			return nil, &SchemaError{Row: -1, Column: hd, Err: ErrFieldNotFound}
+		}
+		fields[i] = fd
+		cols[i] = NewColumnField(fd)
+	}
+	for i, row := range rows {
+		if err := checkRowLength(i, row, headerN); err != nil {
+			return nil, err
+		}
+		for j, s := range row {
+			v, err := fields[j].Parse(s)
+			if err != nil {
+				return nil, &SchemaError{Row: i, Column: header[j], Value: s, Err: err}
+			}
+			if v.IsNull() && !fields[j].Nullable {
+				return nil, &SchemaError{Row: i, Column: header[j], Value: s, Err: ErrNotNullable}
+			}
+			cols[j].PushBack(v)
+		}
+		for k := len(row); k < headerN; k++ { // fill in null values
+			if !fields[k].Nullable {
+				return nil, &SchemaError{Row: i, Column: header[k], Err: ErrNotNullable}
 			}
+			cols[k].PushBack(NewNullValue())
 		}
 	}
+
+	fr := New()
 	for _, c := range cols {
 		if err := fr.AddColumn(c); err != nil {
 			return nil, err
@@ -140,22 +299,39 @@ func NewFromCSV(header []string, fpath string) (Frame, error) {
 	}
 	defer f.Close()
 
-	rd := csv.NewReader(f)
-
-	// FieldsPerRecord is the number of expected fields per record.
-	// If FieldsPerRecord is positive, Read requires each record to
-	// have the given number of fields. If FieldsPerRecord is 0, Read sets it to
-	// the number of fields in the first record, so that future records must
-	// have the same field count. If FieldsPerRecord is negative, no check is
-	// made and records may have a variable number of fields.
-	rd.FieldsPerRecord = -1
+	return NewFromReader(f, ReadOptions{Header: header})
+}
 
-	rows, err := rd.ReadAll()
+// NewFromCSVTyped creates a new Frame from CSV with the Schema.
+// Pass 'nil' header if first row is used as header strings.
+// Pass 'non-nil' header if the data starts from the first row, without header strings.
+func NewFromCSVTyped(header []string, fpath string, schema Schema) (Frame, error) {
+	f, err := openToRead(fpath)
 	if err != nil {
 		return nil, err
 	}
+	defer f.Close()
 
-	return NewFromRows(header, rows)
+	return NewFromReader(f, ReadOptions{Header: header, Schema: &schema})
+}
+
+// NewFromCSVInfer creates a new Frame from CSV, inferring the Schema.
+// It returns the inferred Schema, which can be modified and
+// reused with NewFromCSVTyped.
+// Pass 'nil' header if first row is used as header strings.
+// Pass 'non-nil' header if the data starts from the first row, without header strings.
+func NewFromCSVInfer(header []string, fpath string, opts InferOptions) (Frame, Schema, error) {
+	f, err := openToRead(fpath)
+	if err != nil {
+		return nil, Schema{}, err
+	}
+	defer f.Close()
+
+	fr, err := NewFromReader(f, ReadOptions{Header: header, Infer: &opts})
+	if err != nil {
+		return nil, Schema{}, err
+	}
+	return fr, fr.Schema(), nil
 }
 
 // NewFromColumns combines multiple columns into one data frame.
@@ -229,6 +405,15 @@ func (f *frame) AddColumn(c Column) error {
 	if _, ok := f.headerTo[header]; ok {
 		return fmt.Errorf("%q already exists", header)
 	}
+	if f.schema != nil {
+		fd, ok := f.schema.Field(header)
+		if !ok {
+			return &SchemaError{Row: -1, Column: header, Err: ErrFieldNotFound}
+		}
+		if err := fd.Validate(c); err != nil {
+			return err
+		}
+	}
 	f.columns = append(f.columns, c)
 	f.headerTo[header] = len(f.columns) - 1
 	return nil
@@ -252,6 +437,17 @@ func (f *frame) Columns() []Column {
 	return f.columns
 }
 
+func (f *frame) Schema() Schema {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	sc := Schema{Fields: make([]Field, len(f.columns))}
+	for i, col := range f.columns {
+		sc.Fields[i] = col.Field()
+	}
+	return sc
+}
+
 func (f *frame) Count() int {
 	f.mu.Lock()
 	defer f.mu.Unlock()
@@ -414,18 +610,7 @@ func (f *frame) CSV(fpath string) error {
 	}
 	defer file.Close()
 
-	wr := csv.NewWriter(file)
-
-	headers, rows := f.Rows()
-	if err := wr.Write(headers); err != nil {
-		return err
-	}
-	if err := wr.WriteAll(rows); err != nil {
-		return err
-	}
-
-	wr.Flush()
-	return wr.Error()
+	return f.WriteCSV(file, WriteOptions{})
 }
 
 func (f *frame) CSVHorizontal(fpath string) error {
@@ -451,63 +636,19 @@ func (f *frame) CSVHorizontal(fpath string) error {
 	return wr.Error()
 }
 
-// Sort sorts the data frame.
-// TODO: use tree?
+// Sort sorts the data frame by the Column, comparing
+// the Values in the sort type.
 func (f *frame) Sort(header string, st SortType, so SortOption) error {
-	f.mu.Lock()
-	idx, ok := f.headerTo[header]
-	if !ok {
-		f.mu.Unlock()
-		return fmt.Errorf("%q does not exist", header)
-	}
-	f.mu.Unlock()
-
-	var lesses []LessFunc
+	var tp DATA_TYPE
 	switch st {
 	case SortType_String:
-		switch so {
-		case SortOption_Ascending:
-			lesses = []LessFunc{StringAscendingFunc(idx)}
-
-		case SortOption_Descending:
-			lesses = []LessFunc{StringDescendingFunc(idx)}
-		}
-
+		tp = STRING
 	case SortType_Float64:
-		switch so {
-		case SortOption_Ascending:
-			lesses = []LessFunc{Float64AscendingFunc(idx)}
-
-		case SortOption_Descending:
-			lesses = []LessFunc{Float64DescendingFunc(idx)}
-		}
-
+		tp = FLOAT64
 	case SortType_Duration:
-		switch so {
-		case SortOption_Ascending:
-			lesses = []LessFunc{DurationAscendingFunc(idx)}
-
-		case SortOption_Descending:
-			lesses = []LessFunc{DurationDescendingFunc(idx)}
-		}
+		tp = DURATION
+	default:
+		return fmt.Errorf("sort type %d is unknown", st)
 	}
-
-	headers, rows := f.Rows()
-	SortBy(
-		rows,
-		lesses...,
-	).Sort(rows)
-
-	nf, err := NewFromRows(headers, rows)
-	if err != nil {
-		return err
-	}
-	v, ok := nf.(*frame)
-	if !ok {
-		return fmt.Errorf("cannot type assert on frame")
-	}
-	// *f = *v
-	f.columns = v.columns
-	f.headerTo = v.headerTo
-	return nil
+	return f.sortBy([]SortKey{{Header: header, Option: so}}, []DATA_TYPE{tp})
 }
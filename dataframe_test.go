@@ -1,11 +1,13 @@
 package dataframe
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -243,6 +245,48 @@ func TestNewFromRows(t *testing.T) {
 	}
 }
 
+func TestNewFromRowsNull(t *testing.T) {
+	fr, err := NewFromRows([]string{"A", "B"}, [][]string{{"1", ""}, {"2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, err := fr.Column("B")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := col.Value(0); err != nil || v.IsNull() {
+		t.Fatalf("expected non-null, got %v(%v)", v, err)
+	}
+	if v, err := col.Value(1); err != nil || !v.IsNull() {
+		t.Fatalf("expected null, got %v(%v)", v, err)
+	}
+	if n := col.NullCount(); n != 1 {
+		t.Fatalf("null count expected 1, got %d", n)
+	}
+}
+
+func TestNewFromRowsTooLong(t *testing.T) {
+	for _, tt := range []struct {
+		header []string
+		rows   [][]string
+	}{
+		{[]string{"A", "B"}, [][]string{{"1", "2"}, {"3", "4", "5"}}},
+		{nil, [][]string{{"A", "B"}, {"1", "2"}, {"3", "4", "5"}}},
+	} {
+		_, err := NewFromRows(tt.header, tt.rows)
+		var se *SchemaError
+		if !errors.As(err, &se) || se.Row != 1 || se.Value != "5" || !errors.Is(err, ErrFieldNotFound) {
+			t.Fatalf("header %q: expected *SchemaError of row 1, got %v", tt.header, err)
+		}
+	}
+
+	_, err := NewFromReader(strings.NewReader("A,B\n1,2\n3,4,5\n"), ReadOptions{})
+	var se *SchemaError
+	if !errors.As(err, &se) || se.Row != 1 {
+		t.Fatalf("expected *SchemaError of row 1, got %v", err)
+	}
+}
+
 func TestNewFromColumns(t *testing.T) {
 	colA := NewColumn("A")
 	colA.PushBack(NewStringValue("1"))
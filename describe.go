@@ -0,0 +1,176 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// DefaultPercentiles are the percentiles of Describe by default.
+var DefaultPercentiles = []float64{0.25, 0.5, 0.75, 0.9, 0.99}
+
+// Describe returns a new Frame of the summary statistics of the numeric
+// and STRING columns, with one row for each statistic named in the first
+// column "statistic". The numeric columns report count, mean, std, min,
+// the percentiles such as p99 and max in FLOAT64, and the STRING columns
+// report count, unique, top and freq. The statistics that do not apply to
+// a column are null. Null and NaN values are not counted. If percentiles
+// are empty, they are DefaultPercentiles.
+func (f *frame) Describe(percentiles ...float64) (Frame, error) {
+	if len(percentiles) == 0 {
+		percentiles = DefaultPercentiles
+	}
+	seen := make(map[string]bool, len(percentiles))
+	for _, q := range percentiles {
+		if !(q >= 0 && q <= 1) {
+			return nil, fmt.Errorf("percentile %v is out of range [0, 1]", q)
+		}
+		name := percentileName(q)
+		if seen[name] {
+			return nil, fmt.Errorf("percentile %v is repeated as %q", q, name)
+		}
+		seen[name] = true
+	}
+
+	var numeric, strs []Column
+	for _, col := range f.Columns() {
+		switch col.DataType() {
+		case INT64, UINT64, FLOAT64:
+			numeric = append(numeric, col)
+		case STRING:
+			strs = append(strs, col)
+		}
+	}
+	if len(numeric) == 0 && len(strs) == 0 {
+		return nil, fmt.Errorf("no numeric or STRING columns to describe")
+	}
+
+	names := []string{"count"}
+	if len(strs) > 0 {
+		names = append(names, "unique", "top", "freq")
+	}
+	if len(numeric) > 0 {
+		names = append(names, "mean", "std", "min")
+		for _, q := range percentiles {
+			names = append(names, percentileName(q))
+		}
+		names = append(names, "max")
+	}
+	index := make(map[string]int, len(names))
+	stats := NewColumnField(Field{Name: "statistic", Type: STRING})
+	for i, name := range names {
+		index[name] = i
+		stats.PushBack(NewStringValue(name))
+	}
+
+	fr := New()
+	if err := fr.AddColumn(stats); err != nil {
+		return nil, err
+	}
+	for _, col := range f.Columns() {
+		var vs map[string]Value
+		switch col.DataType() {
+		case INT64, UINT64, FLOAT64:
+			vs = describeNumeric(col, percentiles)
+		case STRING:
+			vs = describeString(col)
+		default:
+			continue
+		}
+		fd := col.Field()
+		fd.Nullable = true
+		if fd.Type != STRING {
+			fd.Type = FLOAT64
+		}
+		out := make([]Value, len(names))
+		for name, v := range vs {
+			out[index[name]] = v
+		}
+		dc := NewColumnField(fd)
+		for _, v := range out {
+			if v == nil {
+				v = NewNullValue()
+			}
+			dc.PushBack(v)
+		}
+		if err := fr.AddColumn(dc); err != nil {
+			return nil, err
+		}
+	}
+	return fr, nil
+}
+
+// percentileName returns the name of the percentile, such as "p99.9",
+// rounded to 4 decimal places to drop the float error of 0.07*100.
+func percentileName(q float64) string {
+	return "p" + strconv.FormatFloat(math.Round(q*1e6)/1e4, 'f', -1, 64)
+}
+
+// describeNumeric returns the statistics of the numeric Column by name.
+func describeNumeric(col Column, percentiles []float64) map[string]Value {
+	tp := col.DataType()
+	var fs []float64
+	for row, n := 0, col.Count(); row < n; row++ {
+		if v := valueAt(col, row); !v.IsNull() {
+			if fv := valueFloat64(tp, v); !math.IsNaN(fv) {
+				fs = append(fs, fv)
+			}
+		}
+	}
+	stats := map[string]Value{"count": Float64(float64(len(fs)))}
+	if len(fs) == 0 {
+		return stats
+	}
+	sort.Float64s(fs)
+	m := mean(fs)
+	stats["mean"] = Float64(m)
+	if len(fs) > 1 {
+		var ss float64
+		for _, fv := range fs {
+			ss += (fv - m) * (fv - m)
+		}
+		stats["std"] = Float64(math.Sqrt(ss / float64(len(fs)-1)))
+	}
+	stats["min"] = Float64(fs[0])
+	for _, q := range percentiles {
+		stats[percentileName(q)] = Float64(sortedQuantile(fs, q))
+	}
+	stats["max"] = Float64(fs[len(fs)-1])
+	return stats
+}
+
+// describeString returns the statistics of the STRING Column by name.
+// The top is the most frequent value, and the first seen of the ties.
+func describeString(col Column) map[string]Value {
+	counts := make(map[string]int)
+	var seen []string
+	count := 0
+	for row, n := 0, col.Count(); row < n; row++ {
+		v := valueAt(col, row)
+		if v.IsNull() {
+			continue
+		}
+		s, _ := v.String()
+		if counts[s] == 0 {
+			seen = append(seen, s)
+		}
+		count++
+		counts[s]++
+	}
+	stats := map[string]Value{
+		"count":  NewStringValue(fmt.Sprint(count)),
+		"unique": NewStringValue(fmt.Sprint(len(counts))),
+	}
+	if count > 0 {
+		top := seen[0]
+		for _, s := range seen[1:] {
+			if counts[s] > counts[top] {
+				top = s
+			}
+		}
+		stats["top"] = NewStringValue(top)
+		stats["freq"] = NewStringValue(fmt.Sprint(counts[top]))
+	}
+	return stats
+}
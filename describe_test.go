@@ -0,0 +1,78 @@
+package dataframe
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDescribe(t *testing.T) {
+	fr, err := NewFromRowsTyped([]string{"host", "cpu", "mem", "ok"}, [][]string{
+		{"b", "1", "10", "true"},
+		{"a", "", "20", "true"},
+		{"a", "3", "30", "false"},
+		{"c", "4", "40", "true"},
+		{"b", "2", "50", "true"},
+	}, Schema{Fields: []Field{
+		{Name: "host", Type: STRING},
+		{Name: "cpu", Type: FLOAT64, Nullable: true},
+		{Name: "mem", Type: INT64},
+		{Name: "ok", Type: BOOL},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	desc, err := fr.Describe(0.5, 0.9)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hd, expected := desc.Headers(), []string{"statistic", "host", "cpu", "mem"}; !reflect.DeepEqual(hd, expected) {
+		t.Fatalf("expected %v, got %v", expected, hd)
+	}
+	expected := map[string][]string{
+		"statistic": {"count", "unique", "top", "freq", "mean", "std", "min", "p50", "p90", "max"},
+		"host":      {"5", "3", "b", "2", "", "", "", "", "", ""},
+		"cpu":       {"4", "", "", "", "2.5", "1.2909944487358056", "1", "2.5", "3.7", "4"},
+		"mem":       {"5", "", "", "", "30", "15.811388300841896", "10", "30", "46", "50"},
+	}
+	for header, rows := range expected {
+		col, err := desc.Column(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := col.Rows(); !reflect.DeepEqual(got, rows) {
+			t.Fatalf("%q: expected %v, got %v", header, rows, got)
+		}
+	}
+
+	buf := new(bytes.Buffer)
+	if err := desc.WriteCSV(buf, WriteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if line := strings.SplitN(buf.String(), "\n", 2)[0]; line != "statistic,host,cpu,mem" {
+		t.Fatalf("expected the header line, got %q", line)
+	}
+
+	desc, err = fr.Describe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col, _ := desc.Column("statistic"); col.Count() != 13 {
+		t.Fatalf("expected the default percentiles, got %v", col.Rows())
+	}
+	desc, err = fr.Describe(0.07, 0.29, 0.999)
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, _ := desc.Column("statistic")
+	if rows, expected := col.Rows()[7:10], []string{"p7", "p29", "p99.9"}; !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("expected %v, got %v", expected, rows)
+	}
+	if _, err := fr.Describe(1.5); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := fr.Describe(0.5, 0.5); err == nil {
+		t.Fatal("expected error")
+	}
+}
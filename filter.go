@@ -0,0 +1,165 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+)
+
+// CompareOp defines how Where compares the Values with the operand.
+type CompareOp int
+
+const (
+	CompareOp_Eq CompareOp = iota
+	CompareOp_Ne
+	CompareOp_Lt
+	CompareOp_Le
+	CompareOp_Gt
+	CompareOp_Ge
+)
+
+var compareOpNames = [...]string{
+	CompareOp_Eq: "==",
+	CompareOp_Ne: "!=",
+	CompareOp_Lt: "<",
+	CompareOp_Le: "<=",
+	CompareOp_Gt: ">",
+	CompareOp_Ge: ">=",
+}
+
+func (op CompareOp) String() string {
+	if op < 0 || int(op) >= len(compareOpNames) {
+		return fmt.Sprintf("CompareOp(%d)", int(op))
+	}
+	return compareOpNames[op]
+}
+
+// match returns true if the result of compareValues satisfies op.
+func (op CompareOp) match(c int) bool {
+	switch op {
+	case CompareOp_Eq:
+		return c == 0
+	case CompareOp_Ne:
+		return c != 0
+	case CompareOp_Lt:
+		return c < 0
+	case CompareOp_Le:
+		return c <= 0
+	case CompareOp_Gt:
+		return c > 0
+	default:
+		return c >= 0
+	}
+}
+
+// orderable returns true if the Value is not null or NaN,
+// and can be compared in the data type.
+func orderable(tp DATA_TYPE, layout string, v Value) bool {
+	if v == nil || v.IsNull() {
+		return false
+	}
+	var ok bool
+	switch tp {
+	case INT64:
+		_, ok = v.Int64()
+	case UINT64:
+		_, ok = v.Uint64()
+	case FLOAT64:
+		var fv float64
+		fv, ok = v.Float64()
+		ok = ok && !math.IsNaN(fv)
+	case BOOL:
+		_, ok = v.Bool()
+	case DURATION:
+		_, ok = v.Duration()
+	case TIME:
+		_, ok = v.Time(layout)
+	default:
+		_, ok = v.String()
+	}
+	return ok
+}
+
+// take returns a new Frame of the rows in the order of rows. The Columns
+// share the storage with the Frame until either is written, so that
+// taking the rows of a taken Frame does not copy the rows either.
+func (f *frame) take(rows []int) Frame {
+	f.mu.Lock()
+	cols := make([]Column, len(f.columns))
+	copy(cols, f.columns)
+	var schema *Schema
+	if f.schema != nil {
+		sc := Schema{Fields: make([]Field, len(f.schema.Fields))}
+		copy(sc.Fields, f.schema.Fields)
+		schema = &sc
+	}
+	f.mu.Unlock()
+
+	nf := &frame{
+		columns:  make([]Column, len(cols)),
+		headerTo: make(map[string]int, len(cols)),
+		schema:   schema,
+	}
+	for i, col := range cols {
+		nf.columns[i] = takeColumn(col, rows)
+		nf.headerTo[col.Header()] = i
+	}
+	return nf
+}
+
+// Filter returns a new Frame of the rows for which fn returns true.
+func (f *frame) Filter(fn func(Row) bool) (Frame, error) {
+	rs := f.snapshot()
+	var rows []int
+	for i := 0; i < rs.n; i++ {
+		if fn(Row{index: i, set: rs}) {
+			rows = append(rows, i)
+		}
+	}
+	return f.take(rows), nil
+}
+
+// FilterMask returns a new Frame of the rows that are true in the mask,
+// which must have the same number of rows as the Frame.
+func (f *frame) FilterMask(mask []bool) (Frame, error) {
+	if n := rowCount(f.Columns()); len(mask) != n {
+		return nil, fmt.Errorf("mask has %d rows, expected %d", len(mask), n)
+	}
+	var rows []int
+	for i, ok := range mask {
+		if ok {
+			rows = append(rows, i)
+		}
+	}
+	return f.take(rows), nil
+}
+
+// Where returns a new Frame of the rows whose Value in the Column
+// satisfies the comparison with v, such as Where("cpu", CompareOp_Gt, 0.5).
+// v is a Value or a Go value converted by ToValue. Null and NaN Values
+// do not satisfy any comparison.
+func (f *frame) Where(header string, op CompareOp, v interface{}) (Frame, error) {
+	if op < CompareOp_Eq || op > CompareOp_Ge {
+		return nil, fmt.Errorf("%v is unknown", op)
+	}
+	col, err := f.Column(header)
+	if err != nil {
+		return nil, err
+	}
+	operand, ok := v.(Value)
+	if !ok && v != nil {
+		operand = ToValue(v)
+	}
+	fd := col.Field()
+	if !orderable(fd.Type, fd.Layout, operand) {
+		return nil, fmt.Errorf("%v cannot be compared with %q of %s", v, header, fd.Type)
+	}
+
+	var rows []int
+	for i, n := 0, rowCount(f.Columns()); i < n; i++ {
+		rv := valueAt(col, i)
+		if orderable(fd.Type, fd.Layout, rv) && op.match(compareValues(fd.Type, fd.Layout, rv, operand)) {
+			rows = append(rows, i)
+		}
+	}
+	return f.take(rows), nil
+}
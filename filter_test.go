@@ -0,0 +1,147 @@
+package dataframe
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilter(t *testing.T) {
+	schema := Schema{Fields: []Field{
+		{Name: "host", Type: STRING},
+		{Name: "cpu", Type: FLOAT64, Nullable: true},
+		{Name: "fd", Type: INT64},
+	}}
+	fr, err := NewFromRowsTyped(schema.Headers(), [][]string{
+		{"a", "0.5", "10"},
+		{"b", "", "20"},
+		{"c", "1.5", "30"},
+		{"d", "2.5", "40"},
+		{"e", "NaN", "50"},
+	}, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		op       CompareOp
+		v        interface{}
+		expected []string
+	}{
+		{CompareOp_Eq, 1.5, []string{"c"}},
+		{CompareOp_Ne, 1.5, []string{"a", "d"}},
+		{CompareOp_Lt, 1.5, []string{"a"}},
+		{CompareOp_Le, 1.5, []string{"a", "c"}},
+		{CompareOp_Gt, 1, []string{"c", "d"}},
+		{CompareOp_Ge, Float64(0.5), []string{"a", "c", "d"}},
+	}
+	for _, tt := range tests {
+		got, err := fr.Where("cpu", tt.op, tt.v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		col, err := got.Column("host")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rows := col.Rows(); !reflect.DeepEqual(rows, tt.expected) {
+			t.Fatalf("cpu %v %v: expected %v, got %v", tt.op, tt.v, tt.expected, rows)
+		}
+	}
+
+	got, err := fr.Filter(func(r Row) bool {
+		v, err := r.Value("host")
+		if err != nil {
+			t.Fatal(err)
+		}
+		s, _ := v.String()
+		return s != "c"
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = got.FilterMask([]bool{true, true, false, true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = got.Where("fd", CompareOp_Gt, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]string{{"b", "", "20"}, {"e", "NaN", "50"}}
+	if _, rows := got.Rows(); !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("expected %v, got %v", expected, rows)
+	}
+	if !got.Schema().Equal(fr.Schema()) {
+		t.Fatalf("expected %v, got %v", fr.Schema(), got.Schema())
+	}
+
+	// the filtered Frame does not change with the original
+	col, err := fr.Column("fd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := col.Set(1, Int64(21)); err != nil {
+		t.Fatal(err)
+	}
+	if err := col.Deletes(0, 2); err != nil {
+		t.Fatal(err)
+	}
+	fcol, err := got.Column("fd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows, ok := fcol.Int64s(); !ok || !reflect.DeepEqual(rows, []int64{20, 50}) {
+		t.Fatalf("expected [20 50], got %v", rows)
+	}
+	fcol.PushBack(Int64(60))
+	if err := fcol.Set(0, Int64(22)); err != nil {
+		t.Fatal(err)
+	}
+	if rows, ok := col.Int64s(); !ok || !reflect.DeepEqual(rows, []int64{30, 40, 50}) {
+		t.Fatalf("expected [30 40 50], got %v", rows)
+	}
+
+	if _, err := fr.FilterMask([]bool{true}); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := fr.Where("mem", CompareOp_Gt, 1); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := fr.Where("fd", CompareOp_Gt, "many"); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestWhereTimeseries(t *testing.T) {
+	fr, _, err := NewFromCSVInfer(nil, "testdata/bench-01-etcd-timeseries.csv", InferOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := fr.Where("throughput", CompareOp_Gt, 200)
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, err := fr.Column("throughput")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vs, _ := col.Int64s()
+	var expected []int64
+	for _, v := range vs {
+		if v > 200 {
+			expected = append(expected, v)
+		}
+	}
+	for _, gc := range got.Columns() {
+		if gc.Count() != len(expected) {
+			t.Fatalf("%q: expected %d rows, got %d", gc.Header(), len(expected), gc.Count())
+		}
+	}
+	gc, err := got.Column("throughput")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows, _ := gc.Int64s(); !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("expected %v, got %v", expected, rows)
+	}
+}
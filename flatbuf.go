@@ -0,0 +1,322 @@
+package dataframe
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// flatbuf implements the subset of FlatBuffers that the Arrow IPC
+// metadata needs: tables of scalars, strings, vectors of tables
+// and vectors of structs.
+
+var errFlatbufCorrupt = errors.New("flatbuffers: corrupt input")
+
+// fbBuilder builds a FlatBuffer from back to front, so that the offsets
+// to the objects created earlier point forward. Offsets of the objects
+// are counted from the end of the buffer.
+type fbBuilder struct {
+	buf      []byte // the data is buf[head:]
+	head     int
+	minAlign int
+
+	// vtable is the field offsets of the current table, or 0 if absent.
+	vtable    []int
+	objectEnd int
+}
+
+func newFBBuilder() *fbBuilder {
+	return &fbBuilder{buf: make([]byte, 256), head: 256, minAlign: 1}
+}
+
+func (b *fbBuilder) offset() int {
+	return len(b.buf) - b.head
+}
+
+func (b *fbBuilder) grow(n int) {
+	for b.head < n {
+		size := len(b.buf)
+		buf := make([]byte, 2*size)
+		copy(buf[size+b.head:], b.buf[b.head:])
+		b.buf = buf
+		b.head += size
+	}
+}
+
+// prep pads so that the next 'size' bytes are aligned
+// after writing 'additional' bytes.
+func (b *fbBuilder) prep(size, additional int) {
+	if size > b.minAlign {
+		b.minAlign = size
+	}
+	pad := (-(b.offset() + additional)) & (size - 1)
+	b.grow(pad + size + additional)
+	for i := 0; i < pad; i++ {
+		b.head--
+		b.buf[b.head] = 0
+	}
+}
+
+func (b *fbBuilder) placeUint8(v uint8) {
+	b.head--
+	b.buf[b.head] = v
+}
+
+func (b *fbBuilder) placeUint16(v uint16) {
+	b.head -= 2
+	binary.LittleEndian.PutUint16(b.buf[b.head:], v)
+}
+
+func (b *fbBuilder) placeUint32(v uint32) {
+	b.head -= 4
+	binary.LittleEndian.PutUint32(b.buf[b.head:], v)
+}
+
+func (b *fbBuilder) placeUint64(v uint64) {
+	b.head -= 8
+	binary.LittleEndian.PutUint64(b.buf[b.head:], v)
+}
+
+func (b *fbBuilder) prependUOffset(off int) {
+	b.prep(4, 0)
+	b.placeUint32(uint32(b.offset() - off + 4))
+}
+
+func (b *fbBuilder) createString(s string) int {
+	b.prep(4, len(s)+1)
+	b.placeUint8(0)
+	b.head -= len(s)
+	copy(b.buf[b.head:], s)
+	b.placeUint32(uint32(len(s)))
+	return b.offset()
+}
+
+// createOffsetVector creates the vector of objects, such as tables and strings.
+func (b *fbBuilder) createOffsetVector(offs []int) int {
+	b.prep(4, 4*len(offs))
+	for i := len(offs) - 1; i >= 0; i-- {
+		b.prependUOffset(offs[i])
+	}
+	b.placeUint32(uint32(len(offs)))
+	return b.offset()
+}
+
+// createStructVector creates the vector of structs of 'size' bytes
+// aligned to 'align' bytes. Each struct is written by put from its last byte.
+func (b *fbBuilder) createStructVector(n, size, align int, put func(i int)) int {
+	b.prep(4, size*n)
+	b.prep(align, size*n)
+	for i := n - 1; i >= 0; i-- {
+		put(i)
+	}
+	b.prep(4, 0)
+	b.placeUint32(uint32(n))
+	return b.offset()
+}
+
+func (b *fbBuilder) startTable(numFields int) {
+	b.vtable = make([]int, numFields)
+	b.objectEnd = b.offset()
+}
+
+func (b *fbBuilder) addUint8(slot int, v uint8) {
+	b.prep(1, 0)
+	b.placeUint8(v)
+	b.vtable[slot] = b.offset()
+}
+
+func (b *fbBuilder) addBool(slot int, v bool) {
+	if v {
+		b.addUint8(slot, 1)
+	} else {
+		b.addUint8(slot, 0)
+	}
+}
+
+func (b *fbBuilder) addInt16(slot int, v int16) {
+	b.prep(2, 0)
+	b.placeUint16(uint16(v))
+	b.vtable[slot] = b.offset()
+}
+
+func (b *fbBuilder) addInt32(slot int, v int32) {
+	b.prep(4, 0)
+	b.placeUint32(uint32(v))
+	b.vtable[slot] = b.offset()
+}
+
+func (b *fbBuilder) addInt64(slot int, v int64) {
+	b.prep(8, 0)
+	b.placeUint64(uint64(v))
+	b.vtable[slot] = b.offset()
+}
+
+func (b *fbBuilder) addOffset(slot int, off int) {
+	b.prependUOffset(off)
+	b.vtable[slot] = b.offset()
+}
+
+func (b *fbBuilder) endTable() int {
+	b.prep(4, 0)
+	b.placeUint32(0) // soffset to the vtable
+	obj := b.offset()
+
+	b.grow(2 * (len(b.vtable) + 2))
+	for i := len(b.vtable) - 1; i >= 0; i-- {
+		var off uint16
+		if b.vtable[i] != 0 {
+			off = uint16(obj - b.vtable[i])
+		}
+		b.placeUint16(off)
+	}
+	b.placeUint16(uint16(obj - b.objectEnd))
+	b.placeUint16(uint16((len(b.vtable) + 2) * 2))
+
+	vt := b.offset()
+	binary.LittleEndian.PutUint32(b.buf[len(b.buf)-obj:], uint32(int32(vt-obj)))
+	b.vtable = nil
+	return obj
+}
+
+// finish writes the offset to the root table, and returns the buffer.
+func (b *fbBuilder) finish(root int) []byte {
+	b.prep(b.minAlign, 4)
+	b.prependUOffset(root)
+	return b.buf[b.head:]
+}
+
+// fbTable is a table in a FlatBuffer. Reading out of the buffer
+// panics with errFlatbufCorrupt, which the callers recover.
+type fbTable struct {
+	buf []byte
+	pos int
+}
+
+func fbCheck(buf []byte, pos, n int) {
+	if pos < 0 || n < 0 || pos > len(buf)-n {
+		panic(errFlatbufCorrupt)
+	}
+}
+
+func fbUint32(buf []byte, pos int) uint32 {
+	fbCheck(buf, pos, 4)
+	return binary.LittleEndian.Uint32(buf[pos:])
+}
+
+func fbRoot(buf []byte) fbTable {
+	return fbTable{buf: buf, pos: int(fbUint32(buf, 0))}
+}
+
+// field returns the position of the field, or 0 if absent.
+// All the fields of the zero fbTable are absent.
+func (t fbTable) field(slot int) int {
+	if t.buf == nil {
+		return 0
+	}
+	vt := t.pos - int(int32(fbUint32(t.buf, t.pos)))
+	fbCheck(t.buf, vt, 4)
+	vtSize := int(binary.LittleEndian.Uint16(t.buf[vt:]))
+	idx := 4 + 2*slot
+	if idx+2 > vtSize {
+		return 0
+	}
+	fbCheck(t.buf, vt+idx, 2)
+	off := int(binary.LittleEndian.Uint16(t.buf[vt+idx:]))
+	if off == 0 {
+		return 0
+	}
+	return t.pos + off
+}
+
+func (t fbTable) uint8(slot int, def uint8) uint8 {
+	pos := t.field(slot)
+	if pos == 0 {
+		return def
+	}
+	fbCheck(t.buf, pos, 1)
+	return t.buf[pos]
+}
+
+func (t fbTable) bool(slot int) bool {
+	return t.uint8(slot, 0) != 0
+}
+
+func (t fbTable) int16(slot int, def int16) int16 {
+	pos := t.field(slot)
+	if pos == 0 {
+		return def
+	}
+	fbCheck(t.buf, pos, 2)
+	return int16(binary.LittleEndian.Uint16(t.buf[pos:]))
+}
+
+func (t fbTable) int32(slot int, def int32) int32 {
+	pos := t.field(slot)
+	if pos == 0 {
+		return def
+	}
+	return int32(fbUint32(t.buf, pos))
+}
+
+func (t fbTable) int64(slot int, def int64) int64 {
+	pos := t.field(slot)
+	if pos == 0 {
+		return def
+	}
+	fbCheck(t.buf, pos, 8)
+	return int64(binary.LittleEndian.Uint64(t.buf[pos:]))
+}
+
+func (t fbTable) indirect(pos int) int {
+	return pos + int(fbUint32(t.buf, pos))
+}
+
+// table returns the table field, and false if absent.
+func (t fbTable) table(slot int) (fbTable, bool) {
+	pos := t.field(slot)
+	if pos == 0 {
+		return fbTable{}, false
+	}
+	return fbTable{buf: t.buf, pos: t.indirect(pos)}, true
+}
+
+func (t fbTable) string(slot int) string {
+	pos := t.field(slot)
+	if pos == 0 {
+		return ""
+	}
+	pos = t.indirect(pos)
+	n := int(fbUint32(t.buf, pos))
+	fbCheck(t.buf, pos+4, n)
+	return string(t.buf[pos+4 : pos+4+n])
+}
+
+// vector returns the position of the first element and the length.
+func (t fbTable) vector(slot int) (int, int) {
+	pos := t.field(slot)
+	if pos == 0 {
+		return 0, 0
+	}
+	pos = t.indirect(pos)
+	n := int(fbUint32(t.buf, pos))
+	if n < 0 || n > len(t.buf) {
+		panic(errFlatbufCorrupt)
+	}
+	return pos + 4, n
+}
+
+// tables returns the vector of tables.
+func (t fbTable) tables(slot int) []fbTable {
+	pos, n := t.vector(slot)
+	tables := make([]fbTable, n)
+	for i := range tables {
+		tables[i] = fbTable{buf: t.buf, pos: t.indirect(pos + 4*i)}
+	}
+	return tables
+}
+
+// structs returns the bytes of the vector of structs of 'size' bytes.
+func (t fbTable) structs(slot, size int) ([]byte, int) {
+	pos, n := t.vector(slot)
+	fbCheck(t.buf, pos, n*size)
+	return t.buf[pos : pos+n*size], n
+}
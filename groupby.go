@@ -0,0 +1,504 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AggFunc defines the aggregation of the values in a group.
+// Null Values are skipped.
+type AggFunc int
+
+const (
+	AggFunc_Sum AggFunc = iota
+	AggFunc_Mean
+	AggFunc_Min
+	AggFunc_Max
+
+	// AggFunc_Count counts the non-null values.
+	AggFunc_Count
+
+	// AggFunc_CountDistinct counts the distinct non-null values.
+	AggFunc_CountDistinct
+
+	// AggFunc_First is the first non-null value.
+	AggFunc_First
+
+	// AggFunc_Last is the last non-null value.
+	AggFunc_Last
+
+	AggFunc_Median
+
+	// AggFunc_Std is the sample standard deviation.
+	AggFunc_Std
+
+	// AggFunc_Var is the sample variance.
+	AggFunc_Var
+
+	// AggFunc_Quantile is the quantile of Agg.Quantile,
+	// linearly interpolated between the closest values.
+	AggFunc_Quantile
+
+	// AggFunc_Sketch is the Sketch of the values, in the STRING of
+	// MarshalText, with the relative accuracy of Agg.Accuracy. The
+	// STRING values of Sketches are merged into one Sketch.
+	AggFunc_Sketch
+
+	// AggFunc_SketchQuantile is the quantile of Agg.Quantile, estimated
+	// by the Sketch of AggFunc_Sketch, in bounded memory.
+	AggFunc_SketchQuantile
+)
+
+var aggFuncNames = [...]string{
+	AggFunc_Sum:           "sum",
+	AggFunc_Mean:          "mean",
+	AggFunc_Min:           "min",
+	AggFunc_Max:           "max",
+	AggFunc_Count:         "count",
+	AggFunc_CountDistinct: "count_distinct",
+	AggFunc_First:         "first",
+	AggFunc_Last:          "last",
+	AggFunc_Median:        "median",
+	AggFunc_Std:           "std",
+	AggFunc_Var:           "var",
+	AggFunc_Quantile:      "quantile",
+
+	AggFunc_Sketch:         "sketch",
+	AggFunc_SketchQuantile: "sketch_quantile",
+}
+
+func (a AggFunc) String() string {
+	if a < 0 || int(a) >= len(aggFuncNames) {
+		return fmt.Sprintf("AggFunc(%d)", int(a))
+	}
+	return aggFuncNames[a]
+}
+
+// Agg aggregates a Column of each group into a row of the result.
+type Agg struct {
+	// Column is the header of the Column to aggregate.
+	Column string
+
+	// Func is the aggregation function.
+	Func AggFunc
+
+	// Quantile is the quantile in [0, 1] of AggFunc_Quantile
+	// and AggFunc_SketchQuantile.
+	Quantile float64
+
+	// Accuracy is the relative accuracy of the Sketch of AggFunc_Sketch
+	// and AggFunc_SketchQuantile. If 0, it is DefaultSketchAccuracy.
+	Accuracy float64
+
+	// Name is the header of the result Column. If empty, it is
+	// the Column and the function, such as "cpu_1_mean" or "cpu_1_q0.99".
+	Name string
+}
+
+func (a Agg) name() string {
+	switch {
+	case a.Name != "":
+		return a.Name
+	case a.Func == AggFunc_Quantile || a.Func == AggFunc_SketchQuantile:
+		return fmt.Sprintf("%s_q%g", a.Column, a.Quantile)
+	default:
+		return a.Column + "_" + a.Func.String()
+	}
+}
+
+func (a Agg) accuracy() float64 {
+	if a.Accuracy == 0 {
+		return DefaultSketchAccuracy
+	}
+	return a.Accuracy
+}
+
+// field returns the Field of the result Column,
+// aggregating the Column of the Field.
+func (a Agg) field(fd Field) (Field, error) {
+	out := Field{Name: a.name(), Type: fd.Type, Nullable: true}
+	numeric := fd.Type == INT64 || fd.Type == UINT64 || fd.Type == FLOAT64
+	ok := true
+	switch a.Func {
+	case AggFunc_Count, AggFunc_CountDistinct:
+		out.Type, out.Nullable = INT64, false
+	case AggFunc_First, AggFunc_Last, AggFunc_Min, AggFunc_Max:
+		out.Layout = fd.Layout
+	case AggFunc_Sum:
+		ok = numeric || fd.Type == DURATION || fd.Type == BOOL
+		if fd.Type == BOOL {
+			out.Type = INT64
+		}
+	case AggFunc_Mean, AggFunc_Median, AggFunc_Quantile, AggFunc_Std:
+		ok = numeric || fd.Type == DURATION
+		if numeric {
+			out.Type = FLOAT64
+		}
+		if a.Func == AggFunc_Quantile && !(a.Quantile >= 0 && a.Quantile <= 1) {
+			return out, fmt.Errorf("quantile %v of %q is out of range [0, 1]", a.Quantile, a.Column)
+		}
+	case AggFunc_Var:
+		ok = numeric
+		out.Type = FLOAT64
+	case AggFunc_Sketch, AggFunc_SketchQuantile:
+		ok = numeric || fd.Type == DURATION || fd.Type == STRING
+		switch {
+		case a.Func == AggFunc_Sketch:
+			out.Type = STRING
+		case fd.Type != DURATION:
+			out.Type = FLOAT64
+		}
+		if _, err := NewSketch(a.accuracy()); err != nil {
+			return out, fmt.Errorf("%v of %q: %w", a.Func, a.Column, err)
+		}
+		if a.Func == AggFunc_SketchQuantile && !(a.Quantile >= 0 && a.Quantile <= 1) {
+			return out, fmt.Errorf("quantile %v of %q is out of range [0, 1]", a.Quantile, a.Column)
+		}
+	default:
+		return out, fmt.Errorf("%v is unknown", a.Func)
+	}
+	if !ok {
+		return out, fmt.Errorf("%v is not supported for %q of %s", a.Func, a.Column, fd.Type)
+	}
+	return out, nil
+}
+
+// aggregate aggregates the non-null values of the Column of the Field.
+func (a Agg) aggregate(fd Field, vs []Value) Value {
+	switch a.Func {
+	case AggFunc_Count:
+		return Int64(len(vs))
+	case AggFunc_CountDistinct:
+		seen := make(map[string]struct{}, len(vs))
+		for _, v := range vs {
+			seen[groupKey(fd, v)] = struct{}{}
+		}
+		return Int64(len(seen))
+	}
+	if len(vs) == 0 {
+		return NewNullValue()
+	}
+
+	switch a.Func {
+	case AggFunc_First:
+		return vs[0]
+	case AggFunc_Last:
+		return vs[len(vs)-1]
+	case AggFunc_Sketch, AggFunc_SketchQuantile:
+		// the invalid STRING values of Sketches are null
+		s, err := aggSketch(fd, vs, a.accuracy())
+		if err != nil {
+			return NewNullValue()
+		}
+		if a.Func == AggFunc_Sketch {
+			text, _ := s.MarshalText()
+			return NewStringValue(string(text))
+		}
+		fv, err := s.Quantile(a.Quantile)
+		if err != nil {
+			return NewNullValue()
+		}
+		if fd.Type == DURATION {
+			return GoDuration(time.Duration(math.Round(fv)))
+		}
+		return Float64(fv)
+	case AggFunc_Min, AggFunc_Max:
+		m := vs[0]
+		for _, v := range vs[1:] {
+			c := compareValues(fd.Type, fd.Layout, v, m)
+			if (a.Func == AggFunc_Min && c < 0) || (a.Func == AggFunc_Max && c > 0) {
+				m = v
+			}
+		}
+		return m
+	case AggFunc_Sum:
+		switch fd.Type {
+		case INT64, BOOL:
+			var sum int64
+			for _, v := range vs {
+				if fd.Type == BOOL {
+					if bv, _ := v.Bool(); bv {
+						sum++
+					}
+					continue
+				}
+				iv, _ := v.Int64()
+				sum += iv
+			}
+			return Int64(sum)
+		case UINT64:
+			var sum uint64
+			for _, v := range vs {
+				uv, _ := v.Uint64()
+				sum += uv
+			}
+			return Uint64(sum)
+		case DURATION:
+			var sum time.Duration
+			for _, v := range vs {
+				dv, _ := v.Duration()
+				sum += dv
+			}
+			return GoDuration(sum)
+		}
+	}
+
+	// the others aggregate the values in float64
+	fs := make([]float64, len(vs))
+	for i, v := range vs {
+		fs[i] = valueFloat64(fd.Type, v)
+	}
+	var fv float64
+	switch a.Func {
+	case AggFunc_Sum:
+		for _, f := range fs {
+			fv += f
+		}
+	case AggFunc_Mean:
+		fv = mean(fs)
+	case AggFunc_Median:
+		fv = quantile(fs, 0.5)
+	case AggFunc_Quantile:
+		fv = quantile(fs, a.Quantile)
+	case AggFunc_Std, AggFunc_Var:
+		if len(fs) < 2 {
+			return NewNullValue()
+		}
+		m := mean(fs)
+		for _, f := range fs {
+			fv += (f - m) * (f - m)
+		}
+		fv /= float64(len(fs) - 1)
+		if a.Func == AggFunc_Std {
+			fv = math.Sqrt(fv)
+		}
+	}
+	if fd.Type == DURATION {
+		return GoDuration(time.Duration(math.Round(fv)))
+	}
+	return Float64(fv)
+}
+
+// valueFloat64 converts the numeric Value of the data type to float64.
+func valueFloat64(tp DATA_TYPE, v Value) float64 {
+	switch tp {
+	case INT64:
+		iv, _ := v.Int64()
+		return float64(iv)
+	case UINT64:
+		uv, _ := v.Uint64()
+		return float64(uv)
+	case DURATION:
+		dv, _ := v.Duration()
+		return float64(dv)
+	default:
+		fv, _ := v.Float64()
+		return fv
+	}
+}
+
+func mean(fs []float64) float64 {
+	var sum float64
+	for _, f := range fs {
+		sum += f
+	}
+	return sum / float64(len(fs))
+}
+
+// quantile returns the q-quantile of the values, linearly interpolated
+// between the closest ranks. It sorts the values.
+func quantile(fs []float64, q float64) float64 {
+	sort.Float64s(fs)
+	return sortedQuantile(fs, q)
+}
+
+// sortedQuantile returns the q-quantile of the sorted values.
+func sortedQuantile(fs []float64, q float64) float64 {
+	pos := q * float64(len(fs)-1)
+	lo := int(math.Floor(pos))
+	if lo+1 >= len(fs) {
+		return fs[len(fs)-1]
+	}
+	return fs[lo] + (fs[lo+1]-fs[lo])*(pos-float64(lo))
+}
+
+// groupKey returns the string that identifies the Value
+// of the Field in a group key.
+func groupKey(fd Field, v Value) string {
+	if v == nil || v.IsNull() {
+		return "\x00"
+	}
+	if fd.Type == TIME {
+		if tv, ok := v.Time(fd.Layout); ok {
+			return "\x01" + strconv.FormatInt(tv.UnixNano(), 10)
+		}
+	}
+	s, _ := v.String()
+	return "\x01" + s
+}
+
+// GroupOrder defines the order of the groups.
+type GroupOrder int
+
+const (
+	// GroupOrder_FirstSeen orders the groups by their first rows.
+	GroupOrder_FirstSeen GroupOrder = iota
+
+	// GroupOrder_Sorted orders the groups by the keys in ascending
+	// order, with the null keys last.
+	GroupOrder_Sorted
+)
+
+// Grouped is the Frame grouped by the key columns.
+type Grouped interface {
+	// Keys returns the headers of the key columns.
+	Keys() []string
+
+	// Count returns the number of groups.
+	Count() int
+
+	// Order returns the Grouped with the groups in the order.
+	Order(o GroupOrder) Grouped
+
+	// Agg aggregates each group, and returns a new Frame of the key
+	// columns and the aggregated columns, with one row per group.
+	Agg(aggs ...Agg) (Frame, error)
+}
+
+type grouped struct {
+	fr   Frame
+	keys []Column
+
+	// groups are the rows of each group, in the first-seen order.
+	groups [][]int
+	order  GroupOrder
+}
+
+// GroupBy groups the rows by the values of the key columns.
+// Rows with null keys form their own groups.
+func (f *frame) GroupBy(keys ...string) (Grouped, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no group keys")
+	}
+	g := &grouped{fr: f, keys: make([]Column, len(keys))}
+	for i, key := range keys {
+		col, err := f.Column(key)
+		if err != nil {
+			return nil, err
+		}
+		g.keys[i] = col
+	}
+
+	rowN := rowCount(f.Columns())
+	fields := make([]Field, len(g.keys))
+	for i, col := range g.keys {
+		fields[i] = col.Field()
+	}
+	index := make(map[string]int)
+	var sb strings.Builder
+	for row := 0; row < rowN; row++ {
+		sb.Reset()
+		for i, col := range g.keys {
+			k := groupKey(fields[i], valueAt(col, row))
+			sb.WriteString(strconv.Itoa(len(k)))
+			sb.WriteString(k)
+		}
+		id, ok := index[sb.String()]
+		if !ok {
+			id = len(g.groups)
+			index[sb.String()] = id
+			g.groups = append(g.groups, nil)
+		}
+		g.groups[id] = append(g.groups[id], row)
+	}
+	return g, nil
+}
+
+func (g *grouped) Keys() []string {
+	keys := make([]string, len(g.keys))
+	for i, col := range g.keys {
+		keys[i] = col.Header()
+	}
+	return keys
+}
+
+func (g *grouped) Count() int {
+	return len(g.groups)
+}
+
+func (g *grouped) Order(o GroupOrder) Grouped {
+	ng := *g
+	ng.order = o
+	return &ng
+}
+
+// sortedGroups returns the groups in the order.
+func (g *grouped) sortedGroups() [][]int {
+	groups := g.groups
+	if g.order != GroupOrder_Sorted {
+		return groups
+	}
+	groups = make([][]int, len(g.groups))
+	copy(groups, g.groups)
+
+	fields := make([]Field, len(g.keys))
+	for i, col := range g.keys {
+		fields[i] = col.Field()
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		for k, col := range g.keys {
+			c := compareValues(fields[k].Type, fields[k].Layout, valueAt(col, groups[i][0]), valueAt(col, groups[j][0]))
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+	return groups
+}
+
+func (g *grouped) Agg(aggs ...Agg) (Frame, error) {
+	groups := g.sortedGroups()
+
+	fr := New()
+	for _, col := range g.keys {
+		kc := NewColumnField(col.Field())
+		for _, rows := range groups {
+			kc.PushBack(valueAt(col, rows[0]))
+		}
+		if err := fr.AddColumn(kc); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, a := range aggs {
+		col, err := g.fr.Column(a.Column)
+		if err != nil {
+			return nil, err
+		}
+		fd := col.Field()
+		out, err := a.field(fd)
+		if err != nil {
+			return nil, err
+		}
+		ac := NewColumnField(out)
+		var vs []Value
+		for _, rows := range groups {
+			vs = vs[:0]
+			for _, row := range rows {
+				if v, err := col.Value(row); err == nil && !v.IsNull() {
+					vs = append(vs, v)
+				}
+			}
+			ac.PushBack(a.aggregate(fd, vs))
+		}
+		if err := fr.AddColumn(ac); err != nil {
+			return nil, err
+		}
+	}
+	return fr, nil
+}
@@ -0,0 +1,206 @@
+package dataframe
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGroupBy(t *testing.T) {
+	schema := Schema{Fields: []Field{
+		{Name: "host", Type: STRING, Nullable: true},
+		{Name: "zone", Type: INT64},
+		{Name: "cpu", Type: FLOAT64, Nullable: true},
+		{Name: "took", Type: DURATION},
+	}}
+	rows := [][]string{
+		{"b", "2", "1", "1s"},
+		{"a", "1", "2", "2s"},
+		{"b", "2", "", "3s"},
+		{"a", "1", "4", "4s"},
+		{"b", "1", "8", "5s"},
+		{"", "3", "16", "6s"},
+		{"a", "1", "2", "7s"},
+	}
+	fr, err := NewFromRowsTyped(schema.Headers(), rows, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := fr.GroupBy("host", "zone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Count() != 4 {
+		t.Fatalf("expected 4 groups, got %d", g.Count())
+	}
+
+	aggs := []Agg{
+		{Column: "cpu", Func: AggFunc_Sum},
+		{Column: "cpu", Func: AggFunc_Mean},
+		{Column: "cpu", Func: AggFunc_Min},
+		{Column: "cpu", Func: AggFunc_Max},
+		{Column: "cpu", Func: AggFunc_Count},
+		{Column: "cpu", Func: AggFunc_CountDistinct},
+		{Column: "cpu", Func: AggFunc_First},
+		{Column: "cpu", Func: AggFunc_Last},
+		{Column: "cpu", Func: AggFunc_Median},
+		{Column: "cpu", Func: AggFunc_Var},
+		{Column: "cpu", Func: AggFunc_Quantile, Quantile: 0.25},
+		{Column: "took", Func: AggFunc_Mean, Name: "avg_took"},
+	}
+	got, err := g.Agg(aggs...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedHeaders := []string{
+		"host", "zone",
+		"cpu_sum", "cpu_mean", "cpu_min", "cpu_max", "cpu_count", "cpu_count_distinct",
+		"cpu_first", "cpu_last", "cpu_median", "cpu_var", "cpu_q0.25", "avg_took",
+	}
+	hd, grows := got.Rows()
+	if !reflect.DeepEqual(hd, expectedHeaders) {
+		t.Fatalf("expected %v, got %v", expectedHeaders, hd)
+	}
+	expected := [][]string{
+		{"b", "2", "1", "1", "1", "1", "1", "1", "1", "1", "1", "", "1", "2s"},
+		{"a", "1", "8", "2.6666666666666665", "2", "4", "3", "2", "2", "2", "2", "1.3333333333333333", "2", "4.333333333s"},
+		{"b", "1", "8", "8", "8", "8", "1", "1", "8", "8", "8", "", "8", "5s"},
+		{"", "3", "16", "16", "16", "16", "1", "1", "16", "16", "16", "", "16", "6s"},
+	}
+	if !reflect.DeepEqual(grows, expected) {
+		t.Fatalf("expected %v, got %v", expected, grows)
+	}
+	col, err := got.Column("cpu_count")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tp := col.DataType(); tp != INT64 {
+		t.Fatalf("expected INT64, got %s", tp)
+	}
+
+	got, err = g.Order(GroupOrder_Sorted).Agg(Agg{Column: "took", Func: AggFunc_Sum})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, grows = got.Rows()
+	expected = [][]string{
+		{"", "3", "6s"},
+		{"a", "1", "13s"},
+		{"b", "1", "5s"},
+		{"b", "2", "4s"},
+	}
+	if !reflect.DeepEqual(grows, expected) {
+		t.Fatalf("expected %v, got %v", expected, grows)
+	}
+
+	if _, err := fr.GroupBy("unknown"); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := g.Agg(Agg{Column: "host", Func: AggFunc_Mean}); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := g.Agg(Agg{Column: "cpu", Func: AggFunc_Quantile, Quantile: 2}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestGroupByMonitor(t *testing.T) {
+	fr, _, err := NewFromCSVInfer(nil, "testdata/bench-01-etcd-1-monitor.csv", InferOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := fr.GroupBy("STATE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := g.Order(GroupOrder_Sorted).Agg(
+		Agg{Column: "CpuUsageFloat64", Func: AggFunc_Count},
+		Agg{Column: "CpuUsageFloat64", Func: AggFunc_Mean},
+		Agg{Column: "CpuUsageFloat64", Func: AggFunc_Std},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// compare with the loop over the rows
+	states, err := fr.Column("STATE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cpus, err := fr.Column("CpuUsageFloat64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sums, counts := make(map[string]float64), make(map[string]int)
+	for i := 0; i < states.Count(); i++ {
+		sv, _ := states.Value(i)
+		cv, _ := cpus.Value(i)
+		s, _ := sv.String()
+		f, _ := cv.Float64()
+		sums[s] += f
+		counts[s]++
+	}
+
+	keys, err := got.Column("STATE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if keys.Count() != len(counts) {
+		t.Fatalf("expected %d groups, got %d", len(counts), keys.Count())
+	}
+	ns, _ := got.Column("CpuUsageFloat64_count")
+	means, _ := got.Column("CpuUsageFloat64_mean")
+	prev := ""
+	for i := 0; i < keys.Count(); i++ {
+		kv, _ := keys.Value(i)
+		s, _ := kv.String()
+		if s < prev {
+			t.Fatalf("expected sorted groups, got %q after %q", s, prev)
+		}
+		prev = s
+		nv, _ := ns.Value(i)
+		mv, _ := means.Value(i)
+		n, _ := nv.Int64()
+		m, _ := mv.Float64()
+		if int(n) != counts[s] || math.Abs(m-sums[s]/float64(counts[s])) > 1e-9 {
+			t.Fatalf("%q: expected %d rows of mean %v, got %d rows of mean %v", s, counts[s], sums[s]/float64(counts[s]), n, m)
+		}
+	}
+}
+
+func TestGroupByTime(t *testing.T) {
+	loc := time.FixedZone("KST", 9*3600)
+	col := NewColumnTyped("ts", TIME)
+	val := NewColumnTyped("v", INT64)
+	for i, ts := range []time.Time{
+		time.Date(2024, 1, 1, 9, 0, 0, 0, loc),
+		time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), // same instant
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		col.PushBack(GoTime(ts))
+		val.PushBack(Int64(i + 1))
+	}
+	fr := New()
+	if err := fr.AddColumn(col); err != nil {
+		t.Fatal(err)
+	}
+	if err := fr.AddColumn(val); err != nil {
+		t.Fatal(err)
+	}
+	g, err := fr.GroupBy("ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := g.Order(GroupOrder_Sorted).Agg(Agg{Column: "v", Func: AggFunc_Sum})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sums, err := got.Column("v_sum")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vs, _ := sums.Int64s(); !reflect.DeepEqual(vs, []int64{3, 3}) {
+		t.Fatalf("expected [3 3], got %v", vs)
+	}
+}
@@ -0,0 +1,292 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// ValueCountsOptions configures ValueCounts.
+type ValueCountsOptions struct {
+	// Normalize returns the proportions of the values in FLOAT64
+	// in the column "proportion", instead of the counts in INT64
+	// in the column "count".
+	Normalize bool
+
+	// Nulls counts the null rows as a null value.
+	Nulls bool
+
+	// Ascending sorts the values from the least frequent,
+	// instead of the most frequent.
+	Ascending bool
+}
+
+func (c *column) ValueCounts(opts ValueCountsOptions) (Frame, error) {
+	fd := c.Field()
+	type valueCount struct {
+		v     Value
+		count int
+	}
+	var counts []*valueCount
+	keyTo := make(map[string]*valueCount)
+	total := 0
+	for row, n := 0, c.Count(); row < n; row++ {
+		v := valueAt(c, row)
+		if v.IsNull() {
+			if !opts.Nulls {
+				continue
+			}
+			v = NewNullValue()
+		}
+		total++
+		k := groupKey(fd, v)
+		vc, ok := keyTo[k]
+		if !ok {
+			vc = &valueCount{v: v}
+			keyTo[k] = vc
+			counts = append(counts, vc)
+		}
+		vc.count++
+	}
+
+	// the ties are in the order of the first rows
+	sort.SliceStable(counts, func(i, j int) bool {
+		if opts.Ascending {
+			return counts[i].count < counts[j].count
+		}
+		return counts[i].count > counts[j].count
+	})
+
+	fd.Nullable = fd.Nullable || opts.Nulls
+	vcol := NewColumnField(fd)
+	ccol := NewColumnField(Field{Name: "count", Type: INT64})
+	if opts.Normalize {
+		ccol = NewColumnField(Field{Name: "proportion", Type: FLOAT64})
+	}
+	for _, vc := range counts {
+		vcol.PushBack(vc.v)
+		if opts.Normalize {
+			ccol.PushBack(Float64(float64(vc.count) / float64(total)))
+		} else {
+			ccol.PushBack(Int64(vc.count))
+		}
+	}
+	fr := New()
+	for _, col := range []Column{vcol, ccol} {
+		if err := fr.AddColumn(col); err != nil {
+			return nil, err
+		}
+	}
+	return fr, nil
+}
+
+// BinKind defines how Histogram buckets the values.
+type BinKind int
+
+const (
+	// BinKind_Fixed buckets the values into Bins.Count bins
+	// of the same width.
+	BinKind_Fixed BinKind = iota
+
+	// BinKind_Edges buckets the values by Bins.Edges.
+	BinKind_Edges
+
+	// BinKind_Log buckets the positive values into Bins.Count bins
+	// of the same width in the logarithmic scale.
+	BinKind_Log
+
+	// BinKind_HDR buckets the positive values as in HdrHistogram: each
+	// power of two is split into the bins of the same width, so that
+	// each bin is within Bins.Digits significant decimal digits. Only
+	// the bins with values are returned, whose edges do not depend on
+	// the values, so that the Histograms of the same Digits can be
+	// joined by "lower".
+	BinKind_HDR
+)
+
+// Bins configures the bins of Histogram. Each bin is [lower, upper),
+// and the last bin of BinKind_Fixed, BinKind_Edges and BinKind_Log
+// is [lower, upper]. The values out of the bins are not counted.
+type Bins struct {
+	Kind BinKind
+
+	// Count is the number of the bins of BinKind_Fixed and BinKind_Log.
+	Count int
+
+	// Min and Max are the range of BinKind_Fixed and BinKind_Log.
+	// If both are 0, it is the range of the values.
+	Min, Max float64
+
+	// Edges are the ascending edges of the bins of BinKind_Edges,
+	// with one bin less than the edges.
+	Edges []float64
+
+	// Digits is the number of significant decimal digits of BinKind_HDR,
+	// in [1, 5]. If 0, it is 2.
+	Digits int
+}
+
+// edges returns the edges of the bins of the values,
+// other than of BinKind_HDR.
+func (b Bins) edges(fs []float64) ([]float64, error) {
+	lo, hi := b.Min, b.Max
+	if b.Kind == BinKind_Edges {
+		if len(b.Edges) < 2 {
+			return nil, fmt.Errorf("%d edges are less than 2", len(b.Edges))
+		}
+		for i, e := range b.Edges {
+			if math.IsNaN(e) || math.IsInf(e, 0) || (i > 0 && e <= b.Edges[i-1]) {
+				return nil, fmt.Errorf("edges %v are not finite and strictly ascending", b.Edges)
+			}
+		}
+		return b.Edges, nil
+	}
+
+	if b.Count < 1 {
+		return nil, fmt.Errorf("bin count %d must be positive", b.Count)
+	}
+	if lo == 0 && hi == 0 {
+		lo, hi = math.Inf(1), math.Inf(-1)
+		for _, fv := range fs {
+			if b.Kind == BinKind_Log && fv <= 0 {
+				continue
+			}
+			lo, hi = math.Min(lo, fv), math.Max(hi, fv)
+		}
+		switch {
+		case lo > hi && b.Kind == BinKind_Log:
+			lo, hi = 1, 10
+		case lo > hi:
+			lo, hi = 0, 1
+		case lo == hi && b.Kind == BinKind_Log:
+			hi = 2 * lo
+		case lo == hi:
+			lo, hi = lo-0.5, hi+0.5
+		}
+	} else if !(lo < hi) || math.IsInf(lo, 0) || math.IsInf(hi, 0) {
+		return nil, fmt.Errorf("bin range [%v, %v] is invalid", lo, hi)
+	}
+	if b.Kind == BinKind_Log && lo <= 0 {
+		return nil, fmt.Errorf("log bin range [%v, %v] must be positive", lo, hi)
+	}
+
+	edges := make([]float64, b.Count+1)
+	for i := range edges {
+		r := float64(i) / float64(b.Count)
+		if b.Kind == BinKind_Log {
+			edges[i] = lo * math.Pow(hi/lo, r)
+		} else {
+			edges[i] = lo + (hi-lo)*r
+		}
+	}
+	edges[0], edges[b.Count] = lo, hi
+	return edges, nil
+}
+
+// hdrBins returns the lower and upper edges of the HDR bins with values,
+// in ascending order, and the counts of the values.
+func hdrBins(fs []float64, digits int) (lowers, uppers []float64, counts []int) {
+	// the number of the bins in each power of two
+	sub := 1
+	for float64(sub) < 2*math.Pow10(digits) {
+		sub *= 2
+	}
+	binCounts := make(map[int]int)
+	for _, fv := range fs {
+		if fv > 0 {
+			frac, exp := math.Frexp(fv)
+			binCounts[exp*sub+int((2*frac-1)*float64(sub))]++
+		}
+	}
+	bins := make([]int, 0, len(binCounts))
+	for bin := range binCounts {
+		bins = append(bins, bin)
+	}
+	sort.Ints(bins)
+	for _, bin := range bins {
+		exp := floorDiv(int64(bin), int64(sub))
+		j := int64(bin) - exp*int64(sub)
+		width := math.Ldexp(1, int(exp)-1) / float64(sub)
+		lower := math.Ldexp(1, int(exp)-1) + float64(j)*width
+		lowers, uppers = append(lowers, lower), append(uppers, lower+width)
+		counts = append(counts, binCounts[bin])
+	}
+	return lowers, uppers, counts
+}
+
+func (c *column) Histogram(bins Bins) (Frame, error) {
+	fd := c.Field()
+	switch fd.Type {
+	case INT64, UINT64, FLOAT64, DURATION:
+	default:
+		return nil, fmt.Errorf("%q of %s is not numeric", fd.Name, fd.Type)
+	}
+	var fs []float64
+	for row, n := 0, c.Count(); row < n; row++ {
+		if v := valueAt(c, row); !v.IsNull() {
+			if fv := valueFloat64(fd.Type, v); !math.IsNaN(fv) {
+				fs = append(fs, fv)
+			}
+		}
+	}
+
+	var lowers, uppers []float64
+	var counts []int
+	switch bins.Kind {
+	case BinKind_Fixed, BinKind_Edges, BinKind_Log:
+		edges, err := bins.edges(fs)
+		if err != nil {
+			return nil, err
+		}
+		lowers, uppers = edges[:len(edges)-1], edges[1:]
+		counts = make([]int, len(lowers))
+		for _, fv := range fs {
+			if fv < edges[0] || fv > edges[len(edges)-1] {
+				continue
+			}
+			// the bin of the last edge is the last bin
+			i := sort.SearchFloat64s(edges, fv)
+			if edges[i] != fv || i == len(edges)-1 {
+				i--
+			}
+			counts[i]++
+		}
+	case BinKind_HDR:
+		digits := bins.Digits
+		if digits == 0 {
+			digits = 2
+		}
+		if digits < 1 || digits > 5 {
+			return nil, fmt.Errorf("digits %d is out of range [1, 5]", digits)
+		}
+		lowers, uppers, counts = hdrBins(fs, digits)
+	default:
+		return nil, fmt.Errorf("bin kind %d is unknown", bins.Kind)
+	}
+
+	// the edges of DURATION are in DURATION, and the others in FLOAT64
+	edgeField := Field{Type: FLOAT64}
+	edgeValue := func(fv float64) Value { return Float64(fv) }
+	if fd.Type == DURATION {
+		edgeField.Type = DURATION
+		edgeValue = func(fv float64) Value { return GoDuration(time.Duration(math.Round(fv))) }
+	}
+	edgeField.Name = "lower"
+	lcol := NewColumnField(edgeField)
+	edgeField.Name = "upper"
+	ucol := NewColumnField(edgeField)
+	ccol := NewColumnField(Field{Name: "count", Type: INT64})
+	for i := range counts {
+		lcol.PushBack(edgeValue(lowers[i]))
+		ucol.PushBack(edgeValue(uppers[i]))
+		ccol.PushBack(Int64(counts[i]))
+	}
+	fr := New()
+	for _, col := range []Column{lcol, ucol, ccol} {
+		if err := fr.AddColumn(col); err != nil {
+			return nil, err
+		}
+	}
+	return fr, nil
+}
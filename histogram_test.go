@@ -0,0 +1,153 @@
+package dataframe
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValueCounts(t *testing.T) {
+	fr, _, err := NewFromCSVInfer(nil, "testdata/bench-01-etcd-1-monitor.csv", InferOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, err := fr.Column("STATE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vc, err := col.ValueCounts(ValueCountsOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hd := vc.Headers(); !reflect.DeepEqual(hd, []string{"STATE", "count"}) {
+		t.Fatalf("expected [STATE count], got %v", hd)
+	}
+	states, _ := vc.Column("STATE")
+	counts, _ := vc.Column("count")
+	if rows, expected := states.Rows(), []string{"S (sleeping)", "D (disk sleep)", "R (running)"}; !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("expected %v, got %v", expected, rows)
+	}
+	if rows, expected := counts.Rows(), []string{"345", "15", "2"}; !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("expected %v, got %v", expected, rows)
+	}
+
+	col = NewColumnField(Field{Name: "host", Type: STRING, Nullable: true})
+	for _, v := range []Value{NewStringValue("a"), NewNullValue(), NewStringValue("b"), NewNullValue(), NewStringValue("a"), NewNullValue()} {
+		col.PushBack(v)
+	}
+	vc, err = col.ValueCounts(ValueCountsOptions{Normalize: true, Nulls: true, Ascending: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	hosts, _ := vc.Column("host")
+	props, _ := vc.Column("proportion")
+	if rows, expected := hosts.Rows(), []string{"b", "a", ""}; !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("expected %v, got %v", expected, rows)
+	}
+	if v, _ := hosts.Value(2); !v.IsNull() {
+		t.Fatalf("expected null, got %v", v)
+	}
+	if fs, _ := props.Float64s(); !reflect.DeepEqual(fs, []float64{1.0 / 6, 2.0 / 6, 3.0 / 6}) {
+		t.Fatalf("expected the proportions, got %v", fs)
+	}
+}
+
+func TestHistogram(t *testing.T) {
+	col := NewColumnField(Field{Name: "latency", Type: FLOAT64, Nullable: true})
+	for _, fv := range []float64{1, 2, 2.5, 4, 10, 100} {
+		col.PushBack(Float64(fv))
+	}
+	col.PushBack(NewNullValue())
+
+	tests := []struct {
+		bins     Bins
+		lowers   []string
+		counts   []string
+		hasError bool
+	}{
+		{Bins{Kind: BinKind_Fixed, Count: 3, Min: 0, Max: 12}, []string{"0", "4", "8"}, []string{"3", "1", "1"}, false},
+		{Bins{Kind: BinKind_Fixed, Count: 2}, []string{"1", "50.5"}, []string{"5", "1"}, false},
+		{Bins{Kind: BinKind_Edges, Edges: []float64{1, 2, 10}}, []string{"1", "2"}, []string{"1", "4"}, false},
+		{Bins{Kind: BinKind_Log, Count: 2, Min: 1, Max: 100}, []string{"1", "10"}, []string{"4", "2"}, false},
+		{Bins{Kind: BinKind_HDR, Digits: 1}, []string{"1", "2", "2.5", "4", "10", "100"}, []string{"1", "1", "1", "1", "1", "1"}, false},
+		{Bins{Kind: BinKind_Fixed}, nil, nil, true},
+		{Bins{Kind: BinKind_Edges, Edges: []float64{2, 1}}, nil, nil, true},
+		{Bins{Kind: BinKind_Log, Count: 2, Min: -1, Max: 1}, nil, nil, true},
+		{Bins{Kind: BinKind_HDR, Digits: 6}, nil, nil, true},
+	}
+	for i, tt := range tests {
+		hist, err := col.Histogram(tt.bins)
+		if tt.hasError {
+			if err == nil {
+				t.Fatalf("#%d: expected error", i)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		lowers, _ := hist.Column("lower")
+		counts, _ := hist.Column("count")
+		if rows := lowers.Rows(); !reflect.DeepEqual(rows, tt.lowers) {
+			t.Fatalf("#%d: expected %v, got %v", i, tt.lowers, rows)
+		}
+		if rows := counts.Rows(); !reflect.DeepEqual(rows, tt.counts) {
+			t.Fatalf("#%d: expected %v, got %v", i, tt.counts, rows)
+		}
+	}
+
+	// only the HDR bins with values, from nanoseconds to seconds
+	col = NewColumnField(Field{Name: "took", Type: FLOAT64})
+	col.PushBack(Float64(1e-9))
+	col.PushBack(Float64(1e9))
+	hist, err := col.Histogram(Bins{Kind: BinKind_HDR, Digits: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if uppers, _ := hist.Column("upper"); uppers.Count() != 2 {
+		t.Fatalf("expected 2 bins, got %v", uppers.Rows())
+	}
+
+	if _, err := NewColumn("host").Histogram(Bins{Count: 1}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestHistogramTimeseries(t *testing.T) {
+	// the latency distributions of the databases side by side
+	bins := Bins{Kind: BinKind_Log, Count: 8, Min: 0.1, Max: 1000}
+	var hists []Frame
+	for _, db := range []string{"etcd", "zk"} {
+		fr, _, err := NewFromCSVInfer(nil, "testdata/bench-01-"+db+"-timeseries.csv", InferOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		col, err := fr.Column("avg_latency_ms")
+		if err != nil {
+			t.Fatal(err)
+		}
+		hist, err := col.Histogram(bins)
+		if err != nil {
+			t.Fatal(err)
+		}
+		counts, _ := hist.Column("count")
+		cs, _ := counts.Int64s()
+		total := 0
+		for _, c := range cs {
+			total += int(c)
+		}
+		if total != col.Count() {
+			t.Fatalf("%s: expected %d values, got %d", db, col.Count(), total)
+		}
+		if err := hist.UpdateHeader("count", db); err != nil {
+			t.Fatal(err)
+		}
+		hists = append(hists, hist)
+	}
+	fr, err := Join(hists[0], hists[1], []string{"lower", "upper"}, JoinType_Inner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hd, expected := fr.Headers(), []string{"lower", "upper", "etcd", "zk"}; !reflect.DeepEqual(hd, expected) {
+		t.Fatalf("expected %v, got %v", expected, hd)
+	}
+}
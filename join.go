@@ -0,0 +1,229 @@
+package dataframe
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JoinType defines how to join the rows of two Frames.
+type JoinType int
+
+const (
+	// JoinType_Inner keeps the rows with the keys in both Frames.
+	JoinType_Inner JoinType = iota
+
+	// JoinType_Left keeps all the rows of the left Frame.
+	JoinType_Left
+
+	// JoinType_Right keeps all the rows of the right Frame.
+	JoinType_Right
+
+	// JoinType_Full keeps all the rows of both Frames.
+	JoinType_Full
+)
+
+// JoinOptions configures joining two Frames.
+type JoinOptions struct {
+	// LeftOn and RightOn are the headers of the key columns
+	// of each Frame, matched in order.
+	LeftOn  []string
+	RightOn []string
+
+	// LeftSuffix and RightSuffix are appended to the headers of the
+	// non-key columns that exist in both Frames, such as "_etcd3".
+	// If both are empty, RightSuffix is "_right".
+	LeftSuffix  string
+	RightSuffix string
+}
+
+// Join joins the rows of two Frames with the same values in
+// the key columns of the same headers. See JoinWith for the details.
+func Join(left, right Frame, on []string, how JoinType) (Frame, error) {
+	return JoinWith(left, right, how, JoinOptions{LeftOn: on, RightOn: on})
+}
+
+// JoinWith joins the rows of two Frames with the same values in the key
+// columns, in a hash join. The result has the columns of the left Frame
+// followed by the non-key columns of the right Frame. The key columns are
+// in the headers of the left Frame, with the right keys of the rows that
+// only exist in the right Frame. Null keys do not match any row.
+//
+// The rows are in the order of the left Frame, each followed by the rows
+// of the right Frame with the same keys in order. JoinType_Right is in
+// the order of the right Frame instead, and JoinType_Full appends the rows
+// that only exist in the right Frame.
+func JoinWith(left, right Frame, how JoinType, opts JoinOptions) (Frame, error) {
+	if len(opts.LeftOn) == 0 || len(opts.LeftOn) != len(opts.RightOn) {
+		return nil, fmt.Errorf("join keys %q and %q do not match", opts.LeftOn, opts.RightOn)
+	}
+	if how < JoinType_Inner || how > JoinType_Full {
+		return nil, fmt.Errorf("join type %d is unknown", how)
+	}
+	if opts.LeftSuffix == "" && opts.RightSuffix == "" {
+		opts.RightSuffix = "_right"
+	}
+	leftKeys, err := joinKeys(left, opts.LeftOn)
+	if err != nil {
+		return nil, err
+	}
+	rightKeys, err := joinKeys(right, opts.RightOn)
+	if err != nil {
+		return nil, err
+	}
+
+	// build the hash table on one side, and probe it with the other
+	// side in order, so that the rows of the probe side stay in order
+	probe, build := leftKeys, rightKeys
+	probeN, buildN := rowCount(left.Columns()), rowCount(right.Columns())
+	if how == JoinType_Right {
+		probe, build = build, probe
+		probeN, buildN = buildN, probeN
+	}
+	probeFields, buildFields := keyFields(probe), keyFields(build)
+	table := make(map[string][]int)
+	for row := 0; row < buildN; row++ {
+		if k, ok := joinKey(build, buildFields, row); ok {
+			table[k] = append(table[k], row)
+		}
+	}
+	var probeRows, buildRows []int
+	matched := make([]bool, buildN)
+	for row := 0; row < probeN; row++ {
+		k, ok := joinKey(probe, probeFields, row)
+		var rows []int
+		if ok {
+			rows = table[k]
+		}
+		for _, r := range rows {
+			probeRows = append(probeRows, row)
+			buildRows = append(buildRows, r)
+			matched[r] = true
+		}
+		if len(rows) == 0 && how != JoinType_Inner {
+			probeRows = append(probeRows, row)
+			buildRows = append(buildRows, -1)
+		}
+	}
+	if how == JoinType_Full {
+		for r, ok := range matched {
+			if !ok {
+				probeRows = append(probeRows, -1)
+				buildRows = append(buildRows, r)
+			}
+		}
+	}
+	leftRows, rightRows := probeRows, buildRows
+	if how == JoinType_Right {
+		leftRows, rightRows = buildRows, probeRows
+	}
+
+	fill := make(map[string]Column)
+	for i, k := range opts.LeftOn {
+		fill[k] = rightKeys[i]
+	}
+	drop := make(map[string]bool)
+	for _, k := range opts.RightOn {
+		drop[k] = true
+	}
+	return joinResult(left, right, leftRows, rightRows, fill, drop, opts.LeftSuffix, opts.RightSuffix)
+}
+
+// joinResult returns the Frame of the joined rows, which are -1 for null.
+// The left columns in fill are filled with the right Columns in the rows
+// only in the right Frame, and the right columns in drop are dropped.
+// The suffixes are appended to the headers in both Frames.
+func joinResult(left, right Frame, leftRows, rightRows []int, fill map[string]Column, drop map[string]bool, leftSuffix, rightSuffix string) (Frame, error) {
+	leftCols := left.Columns()
+	var rightCols []Column
+	for _, col := range right.Columns() {
+		if !drop[col.Header()] {
+			rightCols = append(rightCols, col)
+		}
+	}
+	inLeft, inRight := make(map[string]bool), make(map[string]bool)
+	for _, col := range leftCols {
+		inLeft[col.Header()] = true
+	}
+	for _, col := range rightCols {
+		inRight[col.Header()] = true
+	}
+
+	fr := New()
+	for _, col := range leftCols {
+		fd := col.Field()
+		keyCol := fill[fd.Name]
+		if keyCol == nil && inRight[fd.Name] {
+			fd.Name += leftSuffix
+		}
+		if err := fr.AddColumn(joinColumn(fd, col, leftRows, keyCol, rightRows)); err != nil {
+			return nil, err
+		}
+	}
+	for _, col := range rightCols {
+		fd := col.Field()
+		if inLeft[fd.Name] {
+			fd.Name += rightSuffix
+		}
+		if err := fr.AddColumn(joinColumn(fd, col, rightRows, nil, nil)); err != nil {
+			return nil, fmt.Errorf("%v (set the suffixes of the columns)", err)
+		}
+	}
+	return fr, nil
+}
+
+func joinKeys(fr Frame, on []string) ([]Column, error) {
+	cols := make([]Column, len(on))
+	for i, header := range on {
+		col, err := fr.Column(header)
+		if err != nil {
+			return nil, err
+		}
+		cols[i] = col
+	}
+	return cols, nil
+}
+
+// joinKey returns the hash key of the row. It returns false
+// if any of the keys is null, which does not match any row.
+func joinKey(keys []Column, fields []Field, row int) (string, bool) {
+	var sb strings.Builder
+	for i, col := range keys {
+		v := valueAt(col, row)
+		if v.IsNull() {
+			return "", false
+		}
+		k := groupKey(fields[i], v)
+		sb.WriteString(strconv.Itoa(len(k)))
+		sb.WriteString(k)
+	}
+	return sb.String(), true
+}
+
+func keyFields(keys []Column) []Field {
+	fields := make([]Field, len(keys))
+	for i, col := range keys {
+		fields[i] = col.Field()
+	}
+	return fields
+}
+
+// joinColumn returns the Column of the rows, which are -1 for null.
+// If other is not nil, the null rows are filled with its otherRows.
+func joinColumn(fd Field, col Column, rows []int, other Column, otherRows []int) Column {
+	vs := make([]Value, len(rows))
+	for i, row := range rows {
+		vs[i] = valueAt(col, row)
+		if row < 0 && other != nil {
+			vs[i] = valueAt(other, otherRows[i])
+		}
+		if vs[i].IsNull() {
+			fd.Nullable = true
+		}
+	}
+	nc := NewColumnField(fd)
+	for _, v := range vs {
+		nc.PushBack(v)
+	}
+	return nc
+}
@@ -0,0 +1,176 @@
+package dataframe
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJoin(t *testing.T) {
+	left, err := NewFromRowsTyped([]string{"id", "name", "cpu"}, [][]string{
+		{"1", "a", "0.5"},
+		{"2", "b", "1.5"},
+		{"", "null", "2.5"},
+		{"3", "c", "3.5"},
+		{"2", "b2", "4.5"},
+	}, Schema{Fields: []Field{
+		{Name: "id", Type: INT64, Nullable: true},
+		{Name: "name", Type: STRING},
+		{Name: "cpu", Type: FLOAT64},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	right, err := NewFromRowsTyped([]string{"id", "cpu", "mem"}, [][]string{
+		{"2", "10", "100"},
+		{"4", "20", "200"},
+		{"", "30", "300"},
+		{"2", "40", "400"},
+	}, Schema{Fields: []Field{
+		{Name: "id", Type: INT64, Nullable: true},
+		{Name: "cpu", Type: FLOAT64},
+		{Name: "mem", Type: INT64},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		how      JoinType
+		expected [][]string
+	}{
+		{
+			JoinType_Inner,
+			[][]string{
+				{"2", "b", "1.5", "10", "100"},
+				{"2", "b", "1.5", "40", "400"},
+				{"2", "b2", "4.5", "10", "100"},
+				{"2", "b2", "4.5", "40", "400"},
+			},
+		},
+		{
+			JoinType_Left,
+			[][]string{
+				{"1", "a", "0.5", "", ""},
+				{"2", "b", "1.5", "10", "100"},
+				{"2", "b", "1.5", "40", "400"},
+				{"", "null", "2.5", "", ""},
+				{"3", "c", "3.5", "", ""},
+				{"2", "b2", "4.5", "10", "100"},
+				{"2", "b2", "4.5", "40", "400"},
+			},
+		},
+		{
+			JoinType_Right,
+			[][]string{
+				{"2", "b", "1.5", "10", "100"},
+				{"2", "b2", "4.5", "10", "100"},
+				{"4", "", "", "20", "200"},
+				{"", "", "", "30", "300"},
+				{"2", "b", "1.5", "40", "400"},
+				{"2", "b2", "4.5", "40", "400"},
+			},
+		},
+		{
+			JoinType_Full,
+			[][]string{
+				{"1", "a", "0.5", "", ""},
+				{"2", "b", "1.5", "10", "100"},
+				{"2", "b", "1.5", "40", "400"},
+				{"", "null", "2.5", "", ""},
+				{"3", "c", "3.5", "", ""},
+				{"2", "b2", "4.5", "10", "100"},
+				{"2", "b2", "4.5", "40", "400"},
+				{"4", "", "", "20", "200"},
+				{"", "", "", "30", "300"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		fr, err := Join(left, right, []string{"id"}, tt.how)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hd, rows := fr.Rows()
+		if expected := []string{"id", "name", "cpu", "cpu_right", "mem"}; !reflect.DeepEqual(hd, expected) {
+			t.Fatalf("join %d: expected %v, got %v", tt.how, expected, hd)
+		}
+		if !reflect.DeepEqual(rows, tt.expected) {
+			t.Fatalf("join %d: expected %v, got %v", tt.how, tt.expected, rows)
+		}
+	}
+
+	// differently named keys and suffixes
+	if err := right.UpdateHeader("id", "ID"); err != nil {
+		t.Fatal(err)
+	}
+	fr, err := JoinWith(left, right, JoinType_Inner, JoinOptions{
+		LeftOn:      []string{"id"},
+		RightOn:     []string{"ID"},
+		LeftSuffix:  "_etcd3",
+		RightSuffix: "_zk",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hd, expected := fr.Headers(), []string{"id", "name", "cpu_etcd3", "cpu_zk", "mem"}; !reflect.DeepEqual(hd, expected) {
+		t.Fatalf("expected %v, got %v", expected, hd)
+	}
+	col, err := fr.Column("mem")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col.Count() != 4 || col.Field().Nullable {
+		t.Fatalf("expected 4 non-nullable rows, got %d rows of %v", col.Count(), col.Field())
+	}
+
+	if _, err := Join(left, right, []string{"id"}, JoinType_Inner); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := JoinWith(left, right, JoinType_Inner, JoinOptions{LeftOn: []string{"id"}}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestJoinMonitor(t *testing.T) {
+	monitor, _, err := NewFromCSVInfer(nil, "testdata/bench-01-etcd-1-monitor.csv", InferOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	timeseries, _, err := NewFromCSVInfer(nil, "testdata/bench-01-etcd-timeseries.csv", InferOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fr, err := Join(timeseries, monitor, []string{"unix_ts"}, JoinType_Inner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tcol, err := timeseries.Column("unix_ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	mcol, err := monitor.Column("unix_ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := make(map[string]bool)
+	for _, ts := range mcol.Rows() {
+		seen[ts] = true
+	}
+	var expected []string
+	for _, ts := range tcol.Rows() {
+		if seen[ts] {
+			expected = append(expected, ts)
+		}
+	}
+	col, err := fr.Column("unix_ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows := col.Rows(); !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("expected %v, got %v", expected, rows)
+	}
+	if fr.Count() != timeseries.Count()+monitor.Count()-1 {
+		t.Fatalf("expected %d columns, got %d", timeseries.Count()+monitor.Count()-1, fr.Count())
+	}
+}
@@ -0,0 +1,526 @@
+package dataframe
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// JSONOptions configures reading JSON.
+type JSONOptions struct {
+	// Schema parses the values into typed columns, if not nil.
+	// Otherwise, JSON numbers become INT64 or FLOAT64 columns,
+	// booleans become BOOL columns, and others become STRING columns.
+	Schema *Schema
+
+	// KeepNested is true to keep nested objects as raw JSON strings.
+	// By default, nested objects are flattened to dotted column names,
+	// such as {"cpu": {"avg": 1}} to "cpu.avg".
+	KeepNested bool
+}
+
+type jsonKind uint8
+
+const (
+	jsonNull jsonKind = iota
+	jsonBool
+	jsonNumber
+	jsonString
+	jsonObject
+	jsonArray
+)
+
+// jsonCell is a JSON value in string, before parsed into Value.
+type jsonCell struct {
+	kind jsonKind
+	text string
+}
+
+func toJSONCell(raw json.RawMessage) (jsonCell, error) {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return jsonCell{}, fmt.Errorf("empty JSON value")
+	}
+	switch raw[0] {
+	case 'n':
+		return jsonCell{kind: jsonNull}, nil
+	case 't', 'f':
+		return jsonCell{kind: jsonBool, text: string(raw)}, nil
+	case '"':
+		var s string
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return jsonCell{}, err
+		}
+		return jsonCell{kind: jsonString, text: s}, nil
+	case '{':
+		return jsonCell{kind: jsonObject, text: string(raw)}, nil
+	case '[':
+		return jsonCell{kind: jsonArray, text: string(raw)}, nil
+	default:
+		return jsonCell{kind: jsonNumber, text: string(raw)}, nil
+	}
+}
+
+// jsonMember is a key-value pair of JSON object.
+type jsonMember struct {
+	key   string
+	value json.RawMessage
+}
+
+// decodeObject decodes JSON object, keeping the order of the keys.
+func decodeObject(raw json.RawMessage) ([]jsonMember, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return nil, fmt.Errorf("expected JSON object, got %v", tok)
+	}
+	var members []jsonMember
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		members = append(members, jsonMember{key: tok.(string), value: value})
+	}
+	return members, nil
+}
+
+// decodeArray decodes JSON array into its elements.
+func decodeArray(raw json.RawMessage) ([]json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '[' {
+		return nil, fmt.Errorf("expected JSON array, got %v", tok)
+	}
+	var elems []json.RawMessage
+	for dec.More() {
+		var elem json.RawMessage
+		if err := dec.Decode(&elem); err != nil {
+			return nil, err
+		}
+		elems = append(elems, elem)
+	}
+	return elems, nil
+}
+
+// jsonTable collects JSON values into columns,
+// in the order that the keys are first seen.
+type jsonTable struct {
+	opts    JSONOptions
+	headers []string
+	index   map[string]int
+	columns [][]jsonCell
+	rows    int
+}
+
+func newJSONTable(opts JSONOptions) *jsonTable {
+	return &jsonTable{opts: opts, index: make(map[string]int)}
+}
+
+// column returns the index of the column, adding it
+// with null values for the rows so far if not exist.
+func (t *jsonTable) column(name string) int {
+	idx, ok := t.index[name]
+	if !ok {
+		idx = len(t.headers)
+		t.index[name] = idx
+		t.headers = append(t.headers, name)
+		t.columns = append(t.columns, make([]jsonCell, t.rows))
+	}
+	return idx
+}
+
+// addRecord adds a JSON object as a row.
+func (t *jsonTable) addRecord(raw json.RawMessage) error {
+	if err := t.setRecord("", raw); err != nil {
+		return err
+	}
+	t.rows++
+	for i := range t.columns { // fill in null values
+		if len(t.columns[i]) < t.rows {
+			t.columns[i] = append(t.columns[i], jsonCell{kind: jsonNull})
+		}
+	}
+	return nil
+}
+
+func (t *jsonTable) setRecord(prefix string, raw json.RawMessage) error {
+	members, err := decodeObject(raw)
+	if err != nil {
+		return err
+	}
+	for _, m := range members {
+		cell, err := toJSONCell(m.value)
+		if err != nil {
+			return err
+		}
+		if cell.kind == jsonObject && !t.opts.KeepNested {
+			if err := t.setRecord(prefix+m.key+".", m.value); err != nil {
+				return err
+			}
+			continue
+		}
+		idx := t.column(prefix + m.key)
+		if len(t.columns[idx]) > t.rows { // duplicate key
+			t.columns[idx][t.rows] = cell
+			continue
+		}
+		t.columns[idx] = append(t.columns[idx], cell)
+	}
+	return nil
+}
+
+// addColumns adds a JSON object of arrays as columns.
+func (t *jsonTable) addColumns(prefix string, raw json.RawMessage) error {
+	members, err := decodeObject(raw)
+	if err != nil {
+		return err
+	}
+	for _, m := range members {
+		cell, err := toJSONCell(m.value)
+		if err != nil {
+			return err
+		}
+		switch {
+		case cell.kind == jsonObject && !t.opts.KeepNested:
+			if err := t.addColumns(prefix+m.key+".", m.value); err != nil {
+				return err
+			}
+			continue
+		case cell.kind != jsonArray:
+			return fmt.Errorf("column %q expected JSON array, got %s", prefix+m.key, m.value)
+		}
+		elems, err := decodeArray(m.value)
+		if err != nil {
+			return err
+		}
+		idx := t.column(prefix + m.key)
+		t.columns[idx] = t.columns[idx][:0] // not padded by rows
+		for _, elem := range elems {
+			cell, err := toJSONCell(elem)
+			if err != nil {
+				return err
+			}
+			t.columns[idx] = append(t.columns[idx], cell)
+		}
+		if t.rows < len(t.columns[idx]) {
+			t.rows = len(t.columns[idx])
+		}
+	}
+	return nil
+}
+
+// inferJSONField infers the Field from JSON value kinds.
+func inferJSONField(name string, cells []jsonCell) Field {
+	fd := Field{Name: name, Type: STRING, Nullable: true}
+	var kind jsonKind
+	isInt := true
+	for _, c := range cells {
+		if c.kind == jsonNull {
+			continue
+		}
+		if kind != jsonNull && kind != c.kind {
+			return fd // mixed
+		}
+		kind = c.kind
+		if kind == jsonNumber && isInt {
+			_, err := strconv.ParseInt(c.text, 10, 64)
+			isInt = err == nil
+		}
+	}
+	switch {
+	case kind == jsonBool:
+		fd.Type = BOOL
+	case kind == jsonNumber && isInt:
+		fd.Type = INT64
+	case kind == jsonNumber:
+		fd.Type = FLOAT64
+	}
+	return fd
+}
+
+func (t *jsonTable) frame() (Frame, error) {
+	fr := New()
+	for i, name := range t.headers {
+		cells := t.columns[i]
+		for len(cells) < t.rows {
+			cells = append(cells, jsonCell{kind: jsonNull})
+		}
+
+		var fd Field
+		if t.opts.Schema != nil {
+			var ok bool
+			fd, ok = t.opts.Schema.Field(name)
+			if !ok {
+				return nil, &SchemaError{Row: -1, Column: name, Err: ErrFieldNotFound}
+			}
+		} else {
+			fd = inferJSONField(name, cells)
+		}
+
+		col := NewColumnField(fd)
+		for row, c := range cells {
+			if c.kind == jsonNull {
+				if !fd.Nullable {
+					return nil, &SchemaError{Row: row, Column: name, Value: "null", Err: ErrNotNullable}
+				}
+				col.PushBack(NewNullValue())
+				continue
+			}
+			v, err := fd.Parse(c.text)
+			if err != nil {
+				return nil, &SchemaError{Row: row, Column: name, Value: c.text, Err: err}
+			}
+			col.PushBack(v)
+		}
+		if err := fr.AddColumn(col); err != nil {
+			return nil, err
+		}
+	}
+	return fr, nil
+}
+
+// NewFromJSON creates a new Frame from JSON, either an array of objects
+// (one object per row) or an object of arrays (one array per column).
+// Column order follows the order that the keys are first seen.
+func NewFromJSON(r io.Reader, opts JSONOptions) (Frame, error) {
+	dec := json.NewDecoder(r)
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return nil, err
+	}
+	raw = bytes.TrimSpace(raw)
+
+	t := newJSONTable(opts)
+	switch {
+	case len(raw) > 0 && raw[0] == '[':
+		records, err := decodeArray(raw)
+		if err != nil {
+			return nil, err
+		}
+		for _, rec := range records {
+			if err := t.addRecord(rec); err != nil {
+				return nil, err
+			}
+		}
+	case len(raw) > 0 && raw[0] == '{':
+		if err := t.addColumns("", raw); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("expected JSON array or object, got %.20s", raw)
+	}
+	return t.frame()
+}
+
+// NewFromNDJSON creates a new Frame from newline-delimited JSON,
+// with one object per row. It decodes one row at a time, without
+// reading the whole input into memory.
+func NewFromNDJSON(r io.Reader, opts JSONOptions) (Frame, error) {
+	dec := json.NewDecoder(r)
+	t := newJSONTable(opts)
+	for {
+		var raw json.RawMessage
+		err := dec.Decode(&raw)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := t.addRecord(raw); err != nil {
+			return nil, fmt.Errorf("row %d: %w", t.rows, err)
+		}
+	}
+	return t.frame()
+}
+
+// appendJSONValue encodes the Value by the data type of the Field.
+func appendJSONValue(buf []byte, fd Field, v Value) []byte {
+	if v == nil || v.IsNull() {
+		return append(buf, "null"...)
+	}
+	switch fd.Type {
+	case INT64, UINT64, BOOL:
+		s, _ := v.String()
+		return append(buf, s...)
+	case FLOAT64:
+		fv, _ := v.Float64()
+		if math.IsNaN(fv) || math.IsInf(fv, 0) { // not valid JSON numbers
+			return strconv.AppendQuote(buf, strconv.FormatFloat(fv, 'f', -1, 64))
+		}
+		return strconv.AppendFloat(buf, fv, 'f', -1, 64)
+	case TIME:
+		tv, ok := v.Time(fd.Layout)
+		if !ok {
+			s, _ := v.String()
+			return appendJSONString(buf, s)
+		}
+		if fd.Layout == LayoutUnixSeconds {
+			return strconv.AppendInt(buf, tv.Unix(), 10)
+		}
+		return appendJSONString(buf, formatTime(fd.Layout, tv))
+	default:
+		s, _ := v.String()
+		return appendJSONString(buf, s)
+	}
+}
+
+func appendJSONString(buf []byte, s string) []byte {
+	bts, _ := json.Marshal(s)
+	return append(buf, bts...)
+}
+
+func (f *frame) WriteNDJSON(w io.Writer) error {
+	f.mu.Lock()
+	cols := make([]Column, len(f.columns))
+	copy(cols, f.columns)
+	f.mu.Unlock()
+
+	fields := make([]Field, len(cols))
+	keys := make([][]byte, len(cols))
+	var rowN int
+	for i, col := range cols {
+		fields[i] = col.Field()
+		keys[i] = appendJSONString(nil, fields[i].Name)
+		if n := col.Count(); rowN < n {
+			rowN = n
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	var buf []byte
+	for rowIdx := 0; rowIdx < rowN; rowIdx++ {
+		buf = append(buf[:0], '{')
+		for colIdx, col := range cols {
+			if colIdx > 0 {
+				buf = append(buf, ',')
+			}
+			buf = append(buf, keys[colIdx]...)
+			buf = append(buf, ':')
+			v, err := col.Value(rowIdx)
+			if err != nil {
+				v = NewNullValue()
+			}
+			buf = appendJSONValue(buf, fields[colIdx], v)
+		}
+		buf = append(buf, '}', '\n')
+		if _, err := bw.Write(buf); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// jsonField is the JSON encoding of Field.
+type jsonField struct {
+	Name     string    `json:"name"`
+	Type     DATA_TYPE `json:"type"`
+	Nullable bool      `json:"nullable"`
+	Layout   string    `json:"layout,omitempty"`
+}
+
+// MarshalJSON encodes the Frame as the Schema and the columns:
+//
+//	{"schema":{"fields":[{"name":"a","type":"INT64","nullable":true}]},"data":{"a":[1,null]}}
+func (f *frame) MarshalJSON() ([]byte, error) {
+	f.mu.Lock()
+	cols := make([]Column, len(f.columns))
+	copy(cols, f.columns)
+	f.mu.Unlock()
+
+	fields := make([]jsonField, len(cols))
+	for i, col := range cols {
+		fd := col.Field()
+		fields[i] = jsonField{Name: fd.Name, Type: fd.Type, Nullable: fd.Nullable, Layout: fd.Layout}
+	}
+	schema, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := append([]byte(`{"schema":{"fields":`), schema...)
+	buf = append(buf, `},"data":{`...)
+	for i, col := range cols {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		fd := col.Field()
+		buf = appendJSONString(buf, fd.Name)
+		buf = append(buf, ':', '[')
+		for row := 0; row < col.Count(); row++ {
+			if row > 0 {
+				buf = append(buf, ',')
+			}
+			v, err := col.Value(row)
+			if err != nil {
+				return nil, err
+			}
+			buf = appendJSONValue(buf, fd, v)
+		}
+		buf = append(buf, ']')
+	}
+	buf = append(buf, '}', '}')
+	return buf, nil
+}
+
+// UnmarshalJSON decodes the Frame encoded by MarshalJSON,
+// replacing all the Columns.
+func (f *frame) UnmarshalJSON(data []byte) error {
+	var doc struct {
+		Schema struct {
+			Fields []jsonField `json:"fields"`
+		} `json:"schema"`
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return err
+	}
+	schema := Schema{Fields: make([]Field, len(doc.Schema.Fields))}
+	for i, fd := range doc.Schema.Fields {
+		schema.Fields[i] = Field{Name: fd.Name, Type: fd.Type, Nullable: fd.Nullable, Layout: fd.Layout}
+	}
+
+	t := newJSONTable(JSONOptions{Schema: &schema, KeepNested: true})
+	if len(doc.Data) > 0 {
+		if err := t.addColumns("", doc.Data); err != nil {
+			return err
+		}
+	}
+	decoded, err := t.frame()
+	if err != nil {
+		return err
+	}
+
+	columns := make([]Column, len(schema.Fields))
+	headerTo := make(map[string]int, len(schema.Fields))
+	for i, fd := range schema.Fields {
+		col, err := decoded.Column(fd.Name)
+		if err != nil { // no data
+			col = NewColumnField(fd)
+		}
+		columns[i] = col
+		headerTo[fd.Name] = i
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.columns = columns
+	f.headerTo = headerTo
+	return nil
+}
@@ -0,0 +1,144 @@
+package dataframe
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewFromJSONRecords(t *testing.T) {
+	data := `[
+	{"unix_ts": 1458757864, "NAME": "etcd", "cpu": {"avg": 0.5, "max": 1}, "ok": true},
+	{"unix_ts": 1458757865, "NAME": "etcd", "cpu": {"avg": 6.93, "max": 7}, "tags": ["a"]},
+	{"unix_ts": 1458757866, "NAME": null, "ok": false}
+]`
+	fr, err := NewFromJSON(strings.NewReader(data), JSONOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Field{
+		{Name: "unix_ts", Type: INT64, Nullable: true},
+		{Name: "NAME", Type: STRING, Nullable: true},
+		{Name: "cpu.avg", Type: FLOAT64, Nullable: true},
+		{Name: "cpu.max", Type: INT64, Nullable: true},
+		{Name: "ok", Type: BOOL, Nullable: true},
+		{Name: "tags", Type: STRING, Nullable: true},
+	}
+	if !reflect.DeepEqual(fr.Schema().Fields, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, fr.Schema().Fields)
+	}
+	col, err := fr.Column("cpu.avg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows, mask := col.Float64sMask(); !reflect.DeepEqual(rows, []float64{0.5, 6.93, 0}) || !reflect.DeepEqual(mask, []bool{true, true, false}) {
+		t.Fatalf("unexpected rows %v %v", rows, mask)
+	}
+	col, err = fr.Column("tags")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := col.Value(1); err != nil || !v.EqualTo(NewStringValue(`["a"]`)) {
+		t.Fatalf("expected raw JSON, got %v(%v)", v, err)
+	}
+
+	fr, err = NewFromJSON(strings.NewReader(data), JSONOptions{KeepNested: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, err = fr.Column("cpu")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := col.Value(0); err != nil || !v.EqualTo(NewStringValue(`{"avg": 0.5, "max": 1}`)) {
+		t.Fatalf("expected raw JSON, got %v(%v)", v, err)
+	}
+}
+
+func TestNewFromJSONColumns(t *testing.T) {
+	schema := Schema{Fields: []Field{
+		{Name: "unix_ts", Type: TIME, Layout: LayoutUnixSeconds},
+		{Name: "latency.avg", Type: FLOAT64, Nullable: true},
+	}}
+	data := `{"unix_ts": [1458757890, 1458757891], "latency": {"avg": [4.48]}}`
+	fr, err := NewFromJSON(strings.NewReader(data), JSONOptions{Schema: &schema})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fr.Schema().Equal(schema) {
+		t.Fatalf("expected %+v, got %+v", schema, fr.Schema())
+	}
+	_, rows := fr.Rows()
+	if len(rows) != 2 || rows[1][1] != "" {
+		t.Fatalf("unexpected rows %q", rows)
+	}
+}
+
+func TestNDJSON(t *testing.T) {
+	fr, schema, err := NewFromCSVInfer(nil, "testdata/bench-01-etcd-timeseries.csv", InferOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if err := fr.WriteNDJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	line, _ := buf.ReadString('\n')
+	if line != "{\"unix_ts\":1458757890,\"avg_latency_ms\":4.484004,\"throughput\":64}\n" {
+		t.Fatalf("unexpected line %q", line)
+	}
+	buf.Reset()
+	if err := fr.WriteNDJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	fr2, err := NewFromNDJSON(&buf, JSONOptions{Schema: &schema})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h1, rows1 := fr.Rows()
+	h2, rows2 := fr2.Rows()
+	if !reflect.DeepEqual(h1, h2) || !reflect.DeepEqual(rows1, rows2) {
+		t.Fatalf("expected %q, got %q", h1, h2)
+	}
+}
+
+func TestFrameMarshalJSON(t *testing.T) {
+	schema := Schema{Fields: []Field{
+		{Name: "b", Type: FLOAT64, Nullable: true},
+		{Name: "a", Type: TIME, Layout: "2006-01-02"},
+		{Name: "c", Type: DURATION},
+		{Name: "d", Type: STRING, Nullable: true},
+	}}
+	rows := [][]string{
+		{"1.5", "2016-03-23", "1s", "x"},
+		{"", "2016-03-24", "2m0s"},
+	}
+	fr, err := NewFromRowsTyped(schema.Headers(), rows, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bts, err := json.Marshal(fr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"schema":{"fields":[{"name":"b","type":"FLOAT64","nullable":true},{"name":"a","type":"TIME","nullable":false,"layout":"2006-01-02"},{"name":"c","type":"DURATION","nullable":false},{"name":"d","type":"STRING","nullable":true}]},"data":{"b":[1.5,null],"a":["2016-03-23","2016-03-24"],"c":["1s","2m0s"],"d":["x",null]}}`
+	if string(bts) != expected {
+		t.Fatalf("expected %s, got %s", expected, bts)
+	}
+
+	fr2 := New()
+	if err := json.Unmarshal(bts, fr2); err != nil {
+		t.Fatal(err)
+	}
+	if !fr2.Schema().Equal(schema) {
+		t.Fatalf("expected %+v, got %+v", schema, fr2.Schema())
+	}
+	h1, rows1 := fr.Rows()
+	h2, rows2 := fr2.Rows()
+	if !reflect.DeepEqual(h1, h2) || !reflect.DeepEqual(rows1, rows2) {
+		t.Fatalf("expected %q %q, got %q %q", h1, rows1, h2, rows2)
+	}
+}
@@ -0,0 +1,151 @@
+package dataframe
+
+import "fmt"
+
+// DropHow defines which rows DropNulls drops.
+type DropHow int
+
+const (
+	// DropHow_Any drops the rows with any null in the columns.
+	DropHow_Any DropHow = iota
+
+	// DropHow_All drops the rows with all nulls in the columns.
+	DropHow_All
+)
+
+// DropNulls returns a new Frame without the rows with nulls in the
+// subset columns, or in all the columns if subset is empty. The rows
+// out of a shorter Column are null.
+func (f *frame) DropNulls(subset []string, how DropHow) (Frame, error) {
+	if how != DropHow_Any && how != DropHow_All {
+		return nil, fmt.Errorf("drop how %d is unknown", how)
+	}
+	cols := f.Columns()
+	if len(subset) > 0 {
+		var err error
+		if cols, err = joinKeys(f, subset); err != nil {
+			return nil, err
+		}
+	}
+	nulls := make([]int, rowCount(f.Columns()))
+	for _, col := range cols {
+		for row := range nulls {
+			if valueAt(col, row).IsNull() {
+				nulls[row]++
+			}
+		}
+	}
+	var rows []int
+	for row, n := range nulls {
+		if (how == DropHow_Any && n == 0) || (how == DropHow_All && n < len(cols)) {
+			rows = append(rows, row)
+		}
+	}
+	return f.take(rows), nil
+}
+
+// NullCounts returns the number of null rows of each Column by header.
+func (f *frame) NullCounts() map[string]int {
+	counts := make(map[string]int)
+	for _, col := range f.Columns() {
+		counts[col.Header()] = col.NullCount()
+	}
+	return counts
+}
+
+func (c *column) FillNull(v Value) (Column, error) {
+	fd := c.Field()
+	fv, err := typedValue(fd, -1, v)
+	if err != nil {
+		return nil, err
+	}
+	nc := c.Copy().(*column)
+	for row := 0; row < nc.data.Len(); row++ {
+		if nc.data.IsNull(row) {
+			nc.data.Set(row, fv)
+		}
+	}
+	return nc, nil
+}
+
+// fill returns a copy of the Column with each null row filled from the
+// closest non-null row in the direction, at most limit rows away if
+// limit is positive.
+func (c *column) fill(limit int, forward bool) (Column, error) {
+	if limit < 0 {
+		return nil, fmt.Errorf("limit %d is negative", limit)
+	}
+	nc := c.Copy().(*column)
+	n := nc.data.Len()
+	last, from := Value(nil), -1
+	for i := 0; i < n; i++ {
+		row := i
+		if !forward {
+			row = n - 1 - i
+		}
+		if !nc.data.IsNull(row) {
+			last, from = nc.data.Value(row), i
+			continue
+		}
+		if last != nil && (limit == 0 || i-from <= limit) {
+			nc.data.Set(row, last)
+		}
+	}
+	return nc, nil
+}
+
+func (c *column) FillForward(limit int) (Column, error) {
+	return c.fill(limit, true)
+}
+
+func (c *column) FillBackward(limit int) (Column, error) {
+	return c.fill(limit, false)
+}
+
+// interpolateColumn interpolates the null rows of the numeric Column
+// by the positions, or by the indexes if pos is nil.
+func (c *column) interpolateColumn(pos []float64) (Column, error) {
+	fd := c.Field()
+	switch fd.Type {
+	case INT64, UINT64, FLOAT64:
+		fd.Type = FLOAT64
+	case DURATION:
+	default:
+		return nil, fmt.Errorf("interpolation is not supported for %q of %s", fd.Name, fd.Type)
+	}
+	src := c.Field().Type
+	vs := make([]Value, c.Count())
+	for row := range vs {
+		if v := valueAt(c, row); !v.IsNull() {
+			vs[row] = v
+			if fd.Type == FLOAT64 {
+				vs[row] = Float64(valueFloat64(src, v))
+			}
+		}
+	}
+	interpolate(fd.Type, vs, pos)
+	nc := NewColumnField(fd)
+	for _, v := range vs {
+		if v == nil {
+			v = NewNullValue()
+		}
+		nc.PushBack(v)
+	}
+	return nc, nil
+}
+
+func (c *column) Interpolate() (Column, error) {
+	return c.interpolateColumn(nil)
+}
+
+func (c *column) InterpolateTime(on Column) (Column, error) {
+	times, err := sortedTimes(on, c.Count())
+	if err != nil {
+		return nil, err
+	}
+	pos := make([]float64, len(times))
+	for i, t := range times {
+		pos[i] = float64(t - times[0])
+	}
+	return c.interpolateColumn(pos)
+}
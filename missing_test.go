@@ -0,0 +1,100 @@
+package dataframe
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDropNulls(t *testing.T) {
+	fr, err := NewFromRowsTyped([]string{"host", "cpu", "mem"}, [][]string{
+		{"a", "1", "10"},
+		{"b", "", "20"},
+		{"c", "", ""},
+		{"d", "4"},
+	}, Schema{Fields: []Field{
+		{Name: "host", Type: STRING},
+		{Name: "cpu", Type: FLOAT64, Nullable: true},
+		{Name: "mem", Type: INT64, Nullable: true},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if counts, expected := fr.NullCounts(), map[string]int{"host": 0, "cpu": 2, "mem": 2}; !reflect.DeepEqual(counts, expected) {
+		t.Fatalf("expected %v, got %v", expected, counts)
+	}
+
+	tests := []struct {
+		subset   []string
+		how      DropHow
+		expected []string
+	}{
+		{nil, DropHow_Any, []string{"a"}},
+		{nil, DropHow_All, []string{"a", "b", "c", "d"}},
+		{[]string{"cpu", "mem"}, DropHow_All, []string{"a", "b", "d"}},
+		{[]string{"cpu"}, DropHow_Any, []string{"a", "d"}},
+	}
+	for i, tt := range tests {
+		got, err := fr.DropNulls(tt.subset, tt.how)
+		if err != nil {
+			t.Fatal(err)
+		}
+		col, err := got.Column("host")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rows := col.Rows(); !reflect.DeepEqual(rows, tt.expected) {
+			t.Fatalf("#%d: expected %v, got %v", i, tt.expected, rows)
+		}
+	}
+	if _, err := fr.DropNulls([]string{"disk"}, DropHow_Any); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestFillNull(t *testing.T) {
+	col := NewColumnTyped("cpu", INT64)
+	for _, v := range []Value{NewNullValue(), Int64(1), NewNullValue(), NewNullValue(), NewNullValue(), Int64(9), NewNullValue()} {
+		col.PushBack(v)
+	}
+	ts := NewColumnTyped("unix_ts", INT64)
+	for _, sec := range []int64{0, 1, 2, 5, 6, 9, 10} {
+		ts.PushBack(Int64(sec))
+	}
+
+	tests := []struct {
+		fn       func() (Column, error)
+		expected []string
+	}{
+		{func() (Column, error) { return col.FillNull(Int64(0)) }, []string{"0", "1", "0", "0", "0", "9", "0"}},
+		{func() (Column, error) { return col.FillForward(0) }, []string{"", "1", "1", "1", "1", "9", "9"}},
+		{func() (Column, error) { return col.FillForward(2) }, []string{"", "1", "1", "1", "", "9", "9"}},
+		{func() (Column, error) { return col.FillBackward(1) }, []string{"1", "1", "", "", "9", "9", ""}},
+		{col.Interpolate, []string{"", "1", "3", "5", "7", "9", ""}},
+		{func() (Column, error) { return col.InterpolateTime(ts) }, []string{"", "1", "2", "5", "6", "9", ""}},
+	}
+	for i, tt := range tests {
+		got, err := tt.fn()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rows := got.Rows(); !reflect.DeepEqual(rows, tt.expected) {
+			t.Fatalf("#%d: expected %v, got %v", i, tt.expected, rows)
+		}
+	}
+	if col.NullCount() != 5 {
+		t.Fatalf("expected the Column unchanged, got %v", col.Rows())
+	}
+
+	if _, err := col.FillNull(NewStringValue("x")); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := col.FillForward(-1); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := NewColumn("host").Interpolate(); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := col.InterpolateTime(col); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,785 @@
+package dataframe
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"math/big"
+	"time"
+)
+
+// parquetMagic starts and ends Parquet files.
+const parquetMagic = "PAR1"
+
+// schemaMetadataKey is the key of the file metadata that keeps the Schema
+// of the Frame, for the data types and layouts Parquet and Arrow do not have.
+const schemaMetadataKey = "dataframe.schema"
+
+// ParquetReadOptions configures reading Parquet.
+type ParquetReadOptions struct {
+	// Columns are the names of the columns to read, in order.
+	// Other columns are not decoded. If empty, all columns are read.
+	Columns []string
+}
+
+// ParquetWriteOptions configures writing Parquet.
+type ParquetWriteOptions struct {
+	// Compression is the compression codec of the pages.
+	Compression Compression
+
+	// RowGroupSize is the maximum number of rows in a row group.
+	// Zero or negative writes all the rows in one row group.
+	RowGroupSize int
+}
+
+func (c Compression) parquetCodec() (int32, error) {
+	switch c {
+	case Compression_None:
+		return parquetUncompressed, nil
+	case Compression_Snappy:
+		return parquetSnappy, nil
+	case Compression_Gzip:
+		return parquetGzip, nil
+	case Compression_Zstd:
+		return parquetZstd, nil
+	default:
+		return 0, fmt.Errorf("compression %v is not supported", c)
+	}
+}
+
+func parquetCompression(codec int32) (Compression, error) {
+	switch codec {
+	case parquetUncompressed:
+		return Compression_None, nil
+	case parquetSnappy:
+		return Compression_Snappy, nil
+	case parquetGzip:
+		return Compression_Gzip, nil
+	case parquetZstd:
+		return Compression_Zstd, nil
+	default:
+		return 0, fmt.Errorf("parquet: compression codec %d is not supported", codec)
+	}
+}
+
+// parquetElement returns the schema element of the Field.
+// DURATION is INT64 nanoseconds, which the Schema in
+// the file metadata restores.
+func parquetElement(fd Field, optional bool) parquetSchemaElement {
+	se := parquetSchemaElement{
+		name:       fd.Name,
+		repetition: parquetRequired,
+		converted:  parquetConvertedNone,
+	}
+	if optional {
+		se.repetition = parquetOptional
+	}
+	switch fd.Type {
+	case INT64, DURATION:
+		se.typ = parquetInt64
+	case UINT64:
+		se.typ = parquetInt64
+		se.converted = parquetConvertedUint64
+		se.logical = parquetLogicalType{kind: parquetLogicalInteger, bitWidth: 64}
+	case FLOAT64:
+		se.typ = parquetDouble
+	case BOOL:
+		se.typ = parquetBoolean
+	case TIME:
+		se.typ = parquetInt64
+		se.logical = parquetLogicalType{kind: parquetLogicalTimestamp, unit: parquetNanos, adjustedToUTC: true}
+	default:
+		se.typ = parquetByteArray
+		se.converted = parquetConvertedUTF8
+		se.logical = parquetLogicalType{kind: parquetLogicalString}
+	}
+	return se
+}
+
+var (
+	minUnixNano = time.Unix(0, math.MinInt64)
+	maxUnixNano = time.Unix(0, math.MaxInt64)
+)
+
+// appendParquetValue appends the PLAIN encoded value. It returns false
+// if the value cannot be converted to the data type of the Field.
+func appendParquetValue(buf []byte, fd Field, v Value) ([]byte, bool) {
+	switch fd.Type {
+	case INT64:
+		iv, ok := v.Int64()
+		return binary.LittleEndian.AppendUint64(buf, uint64(iv)), ok
+	case UINT64:
+		uv, ok := v.Uint64()
+		return binary.LittleEndian.AppendUint64(buf, uv), ok
+	case DURATION:
+		dv, ok := v.Duration()
+		return binary.LittleEndian.AppendUint64(buf, uint64(dv)), ok
+	case FLOAT64:
+		fv, ok := v.Float64()
+		return binary.LittleEndian.AppendUint64(buf, math.Float64bits(fv)), ok
+	case TIME:
+		tv, ok := v.Time(fd.Layout)
+		if !ok || tv.Before(minUnixNano) || tv.After(maxUnixNano) {
+			return buf, false
+		}
+		return binary.LittleEndian.AppendUint64(buf, uint64(tv.UnixNano())), true
+	default:
+		s, ok := v.String()
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(s)))
+		return append(buf, s...), ok
+	}
+}
+
+// encodeParquetPage encodes the rows [start, end) of the Column
+// in a data page of PLAIN encoding.
+func encodeParquetPage(col Column, fd Field, optional bool, start, end int) ([]byte, int, error) {
+	var defs []int32
+	if optional {
+		defs = make([]int32, 0, end-start)
+	}
+	var vals, bools []byte
+	nulls, bitN := 0, 0
+	for row := start; row < end; row++ {
+		v, err := col.Value(row)
+		if err != nil || v.IsNull() {
+			if !optional {
+				return nil, 0, &SchemaError{Row: row, Column: fd.Name, Err: ErrNotNullable}
+			}
+			defs = append(defs, 0)
+			nulls++
+			continue
+		}
+		if optional {
+			defs = append(defs, 1)
+		}
+		if fd.Type == BOOL {
+			bv, ok := v.Bool()
+			if !ok {
+				s, _ := v.String()
+				return nil, 0, &SchemaError{Row: row, Column: fd.Name, Value: s, Err: ErrTypeMismatch}
+			}
+			if bitN%8 == 0 {
+				bools = append(bools, 0)
+			}
+			if bv {
+				bools[bitN/8] |= 1 << uint(bitN%8)
+			}
+			bitN++
+			continue
+		}
+		var ok bool
+		if vals, ok = appendParquetValue(vals, fd, v); !ok {
+			s, _ := v.String()
+			return nil, 0, &SchemaError{Row: row, Column: fd.Name, Value: s, Err: ErrTypeMismatch}
+		}
+	}
+
+	var page []byte
+	if optional {
+		levels := appendParquetRLE(nil, defs, 1)
+		page = binary.LittleEndian.AppendUint32(page, uint32(len(levels)))
+		page = append(page, levels...)
+	}
+	page = append(page, bools...)
+	page = append(page, vals...)
+	return page, nulls, nil
+}
+
+// countWriter counts the bytes written.
+type countWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+func (f *frame) WriteParquet(w io.Writer, opts ParquetWriteOptions) error {
+	codec, err := opts.Compression.parquetCodec()
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	cols := make([]Column, len(f.columns))
+	copy(cols, f.columns)
+	f.mu.Unlock()
+
+	rowN := 0
+	for _, col := range cols {
+		if n := col.Count(); rowN < n {
+			rowN = n
+		}
+	}
+	meta := &parquetFileMeta{
+		version:   1,
+		numRows:   int64(rowN),
+		createdBy: "github.com/gyuho/dataframe",
+		schema:    []parquetSchemaElement{{typ: -1, name: "schema", numChildren: int32(len(cols)), converted: parquetConvertedNone}},
+	}
+	fields := make([]Field, len(cols))
+	jfields := make([]jsonField, len(cols))
+	optional := make([]bool, len(cols))
+	for i, col := range cols {
+		fd := col.Field()
+		fields[i] = fd
+		jfields[i] = jsonField{Name: fd.Name, Type: fd.Type, Nullable: fd.Nullable, Layout: fd.Layout}
+
+		// shorter columns are padded with nulls
+		optional[i] = fd.Nullable || col.Count() < rowN
+		meta.schema = append(meta.schema, parquetElement(fd, optional[i]))
+	}
+	schema, err := json.Marshal(jfields)
+	if err != nil {
+		return err
+	}
+	meta.keyValues = []parquetKeyValue{{key: schemaMetadataKey, value: string(schema)}}
+
+	cw := &countWriter{w: w}
+	if _, err := io.WriteString(cw, parquetMagic); err != nil {
+		return err
+	}
+	groupSize := opts.RowGroupSize
+	if groupSize <= 0 {
+		groupSize = rowN
+	}
+	for start := 0; start < rowN; start += groupSize {
+		end := start + groupSize
+		if end > rowN {
+			end = rowN
+		}
+		rg := parquetRowGroup{numRows: int64(end - start)}
+		for i, col := range cols {
+			page, nulls, err := encodeParquetPage(col, fields[i], optional[i], start, end)
+			if err != nil {
+				return err
+			}
+			compressed, err := opts.Compression.compress(page)
+			if err != nil {
+				return err
+			}
+			hd := (&parquetPageHeader{
+				typ:              parquetDataPage,
+				uncompressedSize: int32(len(page)),
+				compressedSize:   int32(len(compressed)),
+				numValues:        int32(end - start),
+				encoding:         parquetPlain,
+			}).encode()
+
+			offset := cw.n
+			if _, err := cw.Write(hd); err != nil {
+				return err
+			}
+			if _, err := cw.Write(compressed); err != nil {
+				return err
+			}
+			cc := parquetColumnChunk{
+				fileOffset: offset,
+				meta: parquetColumnMeta{
+					typ:               meta.schema[i+1].typ,
+					encodings:         []int32{parquetPlain, parquetRLE},
+					path:              []string{fields[i].Name},
+					codec:             codec,
+					numValues:         int64(end - start),
+					totalUncompressed: int64(len(hd) + len(page)),
+					totalCompressed:   int64(len(hd) + len(compressed)),
+					dataPageOffset:    offset,
+					nullCount:         int64(nulls),
+				},
+			}
+			rg.columns = append(rg.columns, cc)
+			rg.totalByteSize += cc.meta.totalUncompressed
+		}
+		meta.rowGroups = append(meta.rowGroups, rg)
+	}
+
+	footer := meta.encode()
+	footer = binary.LittleEndian.AppendUint32(footer, uint32(len(footer)))
+	footer = append(footer, parquetMagic...)
+	_, err = cw.Write(footer)
+	return err
+}
+
+// parquetConverter converts the i-th decoded value to Value.
+type parquetConverter func(pv *parquetValues, i int) Value
+
+// parquetField returns the Field of the leaf column, and the converter
+// from its physical type.
+func parquetField(se parquetSchemaElement) (Field, parquetConverter, error) {
+	fd := Field{Name: se.name, Nullable: se.repetition == parquetOptional}
+	lt := se.logical
+	switch se.typ {
+	case parquetBoolean:
+		fd.Type = BOOL
+		return fd, func(pv *parquetValues, i int) Value { return Bool(pv.ints[i] != 0) }, nil
+
+	case parquetInt32, parquetInt64:
+		switch {
+		case lt.kind == parquetLogicalDate || se.converted == parquetConvertedDate:
+			fd.Type, fd.Layout = TIME, "2006-01-02"
+			return fd, func(pv *parquetValues, i int) Value {
+				return GoTime(time.Unix(pv.ints[i]*86400, 0).UTC())
+			}, nil
+
+		case lt.kind == parquetLogicalTimestamp || se.converted == parquetConvertedTimestampMillis || se.converted == parquetConvertedTimestampMicros:
+			fd.Type = TIME
+			unit := parquetTimeUnit(lt.unit, se.converted == parquetConvertedTimestampMillis)
+			return fd, func(pv *parquetValues, i int) Value {
+				return GoTime(time.Unix(0, 0).UTC().Add(time.Duration(pv.ints[i]) * unit))
+			}, nil
+
+		case lt.kind == parquetLogicalTime || se.converted == parquetConvertedTimeMillis || se.converted == parquetConvertedTimeMicros:
+			// time of day
+			fd.Type = DURATION
+			unit := parquetTimeUnit(lt.unit, se.converted == parquetConvertedTimeMillis)
+			return fd, func(pv *parquetValues, i int) Value { return GoDuration(time.Duration(pv.ints[i]) * unit) }, nil
+
+		case lt.kind == parquetLogicalDecimal || se.converted == parquetConvertedDecimal:
+			fd.Type = FLOAT64
+			scale := math.Pow10(int(parquetDecimalScale(se)))
+			return fd, func(pv *parquetValues, i int) Value { return Float64(float64(pv.ints[i]) / scale) }, nil
+
+		case (lt.kind == parquetLogicalInteger && !lt.signed) ||
+			(parquetConvertedUint8 <= se.converted && se.converted <= parquetConvertedUint64):
+			fd.Type = UINT64
+			if se.typ == parquetInt32 {
+				return fd, func(pv *parquetValues, i int) Value { return Uint64(uint32(pv.ints[i])) }, nil
+			}
+			return fd, func(pv *parquetValues, i int) Value { return Uint64(pv.ints[i]) }, nil
+
+		default:
+			fd.Type = INT64
+			return fd, func(pv *parquetValues, i int) Value { return Int64(pv.ints[i]) }, nil
+		}
+
+	case parquetInt96:
+		// legacy timestamps of nanoseconds in the day and Julian day
+		fd.Type = TIME
+		return fd, func(pv *parquetValues, i int) Value {
+			b := pv.bytes[i]
+			nanos := int64(binary.LittleEndian.Uint64(b))
+			days := int64(binary.LittleEndian.Uint32(b[8:])) - 2440588
+			return GoTime(time.Unix(days*86400, nanos).UTC())
+		}, nil
+
+	case parquetFloat, parquetDouble:
+		fd.Type = FLOAT64
+		return fd, func(pv *parquetValues, i int) Value { return Float64(pv.floats[i]) }, nil
+
+	case parquetByteArray, parquetFixedLenByteArray:
+		switch {
+		case lt.kind == parquetLogicalDecimal || se.converted == parquetConvertedDecimal:
+			fd.Type = FLOAT64
+			scale := new(big.Float).SetFloat64(math.Pow10(int(parquetDecimalScale(se))))
+			return fd, func(pv *parquetValues, i int) Value {
+				fv, _ := new(big.Float).Quo(new(big.Float).SetInt(bigEndianInt(pv.bytes[i])), scale).Float64()
+				return Float64(fv)
+			}, nil
+
+		case lt.kind == parquetLogicalUUID:
+			fd.Type = STRING
+			return fd, func(pv *parquetValues, i int) Value {
+				b := pv.bytes[i]
+				if len(b) != 16 {
+					return String(b)
+				}
+				return String(fmt.Sprintf("%x-%x-%x-%x-%x", b[:4], b[4:6], b[6:8], b[8:10], b[10:]))
+			}, nil
+
+		default:
+			fd.Type = STRING
+			return fd, func(pv *parquetValues, i int) Value { return String(pv.bytes[i]) }, nil
+		}
+
+	default:
+		return fd, nil, fmt.Errorf("parquet: column %q has unknown physical type %d", se.name, se.typ)
+	}
+}
+
+func parquetTimeUnit(unit int16, millis bool) time.Duration {
+	switch {
+	case unit == parquetMillis || (unit == 0 && millis):
+		return time.Millisecond
+	case unit == parquetNanos:
+		return time.Nanosecond
+	default:
+		return time.Microsecond
+	}
+}
+
+func parquetDecimalScale(se parquetSchemaElement) int32 {
+	if se.logical.kind == parquetLogicalDecimal {
+		return se.logical.scale
+	}
+	return se.scale
+}
+
+// bigEndianInt decodes the big-endian two's complement integer.
+func bigEndianInt(b []byte) *big.Int {
+	v := new(big.Int).SetBytes(b)
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		v.Sub(v, new(big.Int).Lsh(big.NewInt(1), uint(len(b))*8))
+	}
+	return v
+}
+
+// readParquetFileMeta reads the footer of the Parquet file.
+func readParquetFileMeta(r io.ReaderAt, size int64) (*parquetFileMeta, error) {
+	if size < int64(2*len(parquetMagic)+4) {
+		return nil, fmt.Errorf("parquet: file is too small (%d bytes)", size)
+	}
+	tail := make([]byte, 8)
+	if _, err := r.ReadAt(tail, size-8); err != nil {
+		return nil, err
+	}
+	if string(tail[4:]) != parquetMagic {
+		return nil, fmt.Errorf("parquet: invalid magic number %q", tail[4:])
+	}
+	n := int64(binary.LittleEndian.Uint32(tail))
+	if n > size-int64(len(parquetMagic))-8 {
+		return nil, fmt.Errorf("parquet: invalid footer length %d", n)
+	}
+	footer := make([]byte, n)
+	if _, err := r.ReadAt(footer, size-8-n); err != nil {
+		return nil, err
+	}
+	return decodeParquetFileMeta(footer)
+}
+
+// parquetChunkReader decodes the pages of a column chunk.
+type parquetChunkReader struct {
+	se   parquetSchemaElement
+	comp Compression
+	dict *parquetValues
+	vals parquetValues
+
+	// defs are the definition levels, if the column is optional.
+	defs []int32
+}
+
+func (cr *parquetChunkReader) readChunk(r io.ReaderAt, size int64, cc parquetColumnChunk) error {
+	if cc.filePath != "" {
+		return fmt.Errorf("parquet: column chunks in external files are not supported")
+	}
+	cm := cc.meta
+	comp, err := parquetCompression(cm.codec)
+	if err != nil {
+		return err
+	}
+	cr.comp = comp
+	cr.dict = nil
+
+	start := cm.dataPageOffset
+	if cm.dictionaryPageOffset > 0 && cm.dictionaryPageOffset < start {
+		start = cm.dictionaryPageOffset
+	}
+	if start < 0 || cm.totalCompressed < 0 || start+cm.totalCompressed > size {
+		return fmt.Errorf("parquet: column chunk [%d, %d) out of file size %d", start, start+cm.totalCompressed, size)
+	}
+	buf := make([]byte, cm.totalCompressed)
+	if _, err := r.ReadAt(buf, start); err != nil {
+		return err
+	}
+
+	var read int64
+	for read < cm.numValues && len(buf) > 0 {
+		hd, n, err := decodeParquetPageHeader(buf)
+		if err != nil {
+			return err
+		}
+		buf = buf[n:]
+		if int(hd.compressedSize) > len(buf) {
+			return errParquetCorrupt
+		}
+		body := buf[:hd.compressedSize]
+		buf = buf[hd.compressedSize:]
+		if hd.typ != parquetDictionaryPage && int64(hd.numValues) > cm.numValues-read {
+			return errParquetCorrupt
+		}
+
+		switch hd.typ {
+		case parquetDictionaryPage:
+			err = cr.readDictionaryPage(hd, body)
+		case parquetDataPage:
+			err = cr.readDataPage(hd, body)
+			read += int64(hd.numValues)
+		case parquetDataPageV2:
+			err = cr.readDataPageV2(hd, body)
+			read += int64(hd.numValues)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if read != cm.numValues {
+		return fmt.Errorf("parquet: column %q has %d values (expected %d)", cr.se.name, read, cm.numValues)
+	}
+	return nil
+}
+
+func (cr *parquetChunkReader) readDictionaryPage(hd *parquetPageHeader, body []byte) error {
+	data, err := cr.comp.decompress(body, int(hd.uncompressedSize))
+	if err != nil {
+		return err
+	}
+	cr.dict = &parquetValues{}
+	_, err = decodeParquetPlain(cr.dict, data, cr.se.typ, cr.se.typeLength, int(hd.numValues))
+	return err
+}
+
+// readDefs reads the definition levels, and returns the number of non-null values.
+func (cr *parquetChunkReader) readDefs(levels []byte, n int) (int, error) {
+	defs, err := decodeParquetRLE(levels, 1, n)
+	if err != nil {
+		return 0, err
+	}
+	nonNull := 0
+	for _, d := range defs {
+		if d == 1 {
+			nonNull++
+		}
+	}
+	cr.defs = append(cr.defs, defs...)
+	return nonNull, nil
+}
+
+func (cr *parquetChunkReader) readDataPage(hd *parquetPageHeader, body []byte) error {
+	data, err := cr.comp.decompress(body, int(hd.uncompressedSize))
+	if err != nil {
+		return err
+	}
+	n := int(hd.numValues)
+	if cr.se.repetition == parquetOptional {
+		if len(data) < 4 {
+			return errParquetCorrupt
+		}
+		size := int(binary.LittleEndian.Uint32(data))
+		if size < 0 || 4+size > len(data) {
+			return errParquetCorrupt
+		}
+		if n, err = cr.readDefs(data[4:4+size], n); err != nil {
+			return err
+		}
+		data = data[4+size:]
+	}
+	return cr.readValues(data, hd.encoding, n)
+}
+
+func (cr *parquetChunkReader) readDataPageV2(hd *parquetPageHeader, body []byte) error {
+	levelsN := int(hd.repLevelsLength) + int(hd.defLevelsLength)
+	if hd.repLevelsLength != 0 || levelsN < 0 || levelsN > len(body) {
+		return errParquetCorrupt
+	}
+	n := int(hd.numValues)
+	if cr.se.repetition == parquetOptional {
+		var err error
+		if n, err = cr.readDefs(body[:levelsN], n); err != nil {
+			return err
+		}
+	}
+	data := body[levelsN:]
+	if hd.compressed {
+		var err error
+		if data, err = cr.comp.decompress(data, int(hd.uncompressedSize)-levelsN); err != nil {
+			return err
+		}
+	}
+	return cr.readValues(data, hd.encoding, n)
+}
+
+func (cr *parquetChunkReader) readValues(data []byte, encoding int32, n int) error {
+	pv := &cr.vals
+	if n < 0 {
+		return errParquetCorrupt
+	}
+	switch encoding {
+	case parquetPlain:
+		_, err := decodeParquetPlain(pv, data, cr.se.typ, cr.se.typeLength, n)
+		return err
+
+	case parquetPlainDictionary, parquetRLEDictionary:
+		if cr.dict == nil {
+			return fmt.Errorf("parquet: column %q has no dictionary page", cr.se.name)
+		}
+		if n == 0 {
+			return nil
+		}
+		if len(data) < 1 {
+			return errParquetCorrupt
+		}
+		idx, err := decodeParquetRLE(data[1:], int(data[0]), n)
+		if err != nil {
+			return err
+		}
+		return pv.appendIndexed(cr.dict, idx)
+
+	case parquetRLE:
+		if cr.se.typ != parquetBoolean || len(data) < 4 {
+			return errParquetCorrupt
+		}
+		vals, err := decodeParquetRLE(data[4:], 1, n)
+		if err != nil {
+			return err
+		}
+		for _, v := range vals {
+			pv.ints = append(pv.ints, int64(v))
+		}
+		return nil
+
+	case parquetDeltaBinaryPacked:
+		vals, _, err := decodeParquetDeltaBinaryPacked(data)
+		if err != nil {
+			return err
+		}
+		if len(vals) < n {
+			return errParquetCorrupt
+		}
+		for _, v := range vals[:n] {
+			if cr.se.typ == parquetInt32 {
+				v = int64(int32(v))
+			}
+			pv.ints = append(pv.ints, v)
+		}
+		return nil
+
+	case parquetDeltaLengthByteArray:
+		vals, _, err := decodeParquetDeltaLengthByteArray(data, n)
+		if err != nil {
+			return err
+		}
+		pv.bytes = append(pv.bytes, vals...)
+		return nil
+
+	case parquetDeltaByteArray:
+		vals, err := decodeParquetDeltaByteArray(data, n)
+		if err != nil {
+			return err
+		}
+		pv.bytes = append(pv.bytes, vals...)
+		return nil
+
+	default:
+		return fmt.Errorf("parquet: column %q has unsupported encoding %d", cr.se.name, encoding)
+	}
+}
+
+// NewFromParquet creates a new Frame from the Parquet file in io.ReaderAt
+// of the size, such as *os.File. Parquet types are mapped to the data types:
+//
+//	BOOLEAN                                   BOOL
+//	INT32, INT64                              INT64
+//	INT32, INT64 (unsigned INTEGER)           UINT64
+//	FLOAT, DOUBLE                             FLOAT64
+//	BYTE_ARRAY, FIXED_LEN_BYTE_ARRAY          STRING
+//	TIMESTAMP, DATE, INT96                    TIME
+//	TIME                                      DURATION
+//	DECIMAL                                   FLOAT64
+//
+// Optional columns are nullable. Nested columns are not supported.
+// Files written by WriteParquet restore the DURATION data types and
+// the time layouts of the Schema.
+func NewFromParquet(r io.ReaderAt, size int64, opts ParquetReadOptions) (Frame, error) {
+	meta, err := readParquetFileMeta(r, size)
+	if err != nil {
+		return nil, err
+	}
+	if len(meta.schema) < 1 {
+		return nil, fmt.Errorf("parquet: empty schema")
+	}
+	leaves := meta.schema[1:]
+	if int(meta.schema[0].numChildren) != len(leaves) {
+		return nil, fmt.Errorf("parquet: nested columns are not supported")
+	}
+
+	var stored Schema
+	for _, kv := range meta.keyValues {
+		if kv.key != schemaMetadataKey {
+			continue
+		}
+		var jfields []jsonField
+		if err := json.Unmarshal([]byte(kv.value), &jfields); err == nil {
+			for _, jf := range jfields {
+				stored.Fields = append(stored.Fields, Field{Name: jf.Name, Type: jf.Type, Nullable: jf.Nullable, Layout: jf.Layout})
+			}
+		}
+	}
+
+	idx := make([]int, 0, len(leaves))
+	if len(opts.Columns) == 0 {
+		for i := range leaves {
+			idx = append(idx, i)
+		}
+	} else {
+		for _, name := range opts.Columns {
+			found := false
+			for i, se := range leaves {
+				if se.name == name {
+					idx, found = append(idx, i), true
+					break
+				}
+			}
+			if !found {
+				return nil, &SchemaError{Row: -1, Column: name, Err: ErrFieldNotFound}
+			}
+		}
+	}
+
+	fr := New()
+	for _, i := range idx {
+		se := leaves[i]
+		if se.typ < 0 || se.numChildren > 0 || se.repetition == parquetRepeated {
+			return nil, fmt.Errorf("parquet: nested column %q is not supported", se.name)
+		}
+		fd, conv, err := parquetField(se)
+		if err != nil {
+			return nil, err
+		}
+		if sf, ok := stored.Field(se.name); ok {
+			switch {
+			case sf.Type == DURATION && fd.Type == INT64:
+				fd.Type = DURATION
+				conv = func(pv *parquetValues, i int) Value { return GoDuration(pv.ints[i]) }
+			case sf.Type == TIME && fd.Type == TIME:
+				fd.Layout = sf.Layout
+			}
+		}
+
+		col := NewColumnField(fd)
+		for _, rg := range meta.rowGroups {
+			if len(rg.columns) != len(leaves) {
+				return nil, fmt.Errorf("parquet: row group has %d columns (expected %d)", len(rg.columns), len(leaves))
+			}
+			cr := &parquetChunkReader{se: se}
+			if err := cr.readChunk(r, size, rg.columns[i]); err != nil {
+				return nil, err
+			}
+			if !cr.vals.matches(se.typ) {
+				return nil, errParquetCorrupt
+			}
+			if cr.defs == nil && cr.vals.len() != int(rg.numRows) || cr.defs != nil && len(cr.defs) != int(rg.numRows) {
+				return nil, fmt.Errorf("parquet: column %q has %d rows (expected %d)", se.name, cr.vals.len(), rg.numRows)
+			}
+			vi := 0
+			for row := 0; row < int(rg.numRows); row++ {
+				if cr.defs != nil && cr.defs[row] == 0 {
+					col.PushBack(NewNullValue())
+					continue
+				}
+				if vi >= cr.vals.len() {
+					return nil, errParquetCorrupt
+				}
+				col.PushBack(conv(&cr.vals, vi))
+				vi++
+			}
+		}
+		if err := fr.AddColumn(col); err != nil {
+			return nil, err
+		}
+	}
+	return fr, nil
+}
@@ -0,0 +1,431 @@
+package dataframe
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+var errParquetCorrupt = errors.New("parquet: corrupt page")
+
+// parquetValues holds the decoded values of a physical type.
+type parquetValues struct {
+	ints   []int64   // BOOLEAN, INT32, INT64
+	floats []float64 // FLOAT, DOUBLE
+	bytes  [][]byte  // INT96, BYTE_ARRAY, FIXED_LEN_BYTE_ARRAY
+}
+
+func (pv *parquetValues) len() int {
+	return len(pv.ints) + len(pv.floats) + len(pv.bytes)
+}
+
+// matches returns true if the values are decoded in the storage of
+// the physical type, as expected by the converters of parquetField.
+func (pv *parquetValues) matches(typ int32) bool {
+	switch typ {
+	case parquetBoolean, parquetInt32, parquetInt64:
+		return len(pv.floats) == 0 && len(pv.bytes) == 0
+	case parquetFloat, parquetDouble:
+		return len(pv.ints) == 0 && len(pv.bytes) == 0
+	case parquetInt96:
+		for _, b := range pv.bytes {
+			if len(b) != 12 {
+				return false
+			}
+		}
+		return len(pv.ints) == 0 && len(pv.floats) == 0
+	default:
+		return len(pv.ints) == 0 && len(pv.floats) == 0
+	}
+}
+
+// appendIndexed appends the values of dict in the order of idx.
+func (pv *parquetValues) appendIndexed(dict *parquetValues, idx []int32) error {
+	n := dict.len()
+	for _, i := range idx {
+		if i < 0 || int(i) >= n {
+			return fmt.Errorf("parquet: dictionary index %d out of range [0, %d)", i, n)
+		}
+	}
+	switch {
+	case dict.ints != nil:
+		for _, i := range idx {
+			pv.ints = append(pv.ints, dict.ints[i])
+		}
+	case dict.floats != nil:
+		for _, i := range idx {
+			pv.floats = append(pv.floats, dict.floats[i])
+		}
+	default:
+		for _, i := range idx {
+			pv.bytes = append(pv.bytes, dict.bytes[i])
+		}
+	}
+	return nil
+}
+
+// decodeParquetPlain decodes n PLAIN encoded values, and returns
+// the number of bytes read.
+func decodeParquetPlain(pv *parquetValues, src []byte, typ, typeLength int32, n int) (int, error) {
+	switch typ {
+	case parquetBoolean:
+		if len(src)*8 < n {
+			return 0, errParquetCorrupt
+		}
+		for i := 0; i < n; i++ {
+			pv.ints = append(pv.ints, int64(src[i/8]>>(uint(i)%8)&1))
+		}
+		return (n + 7) / 8, nil
+	case parquetInt32:
+		if len(src) < 4*n {
+			return 0, errParquetCorrupt
+		}
+		for i := 0; i < n; i++ {
+			pv.ints = append(pv.ints, int64(int32(binary.LittleEndian.Uint32(src[4*i:]))))
+		}
+		return 4 * n, nil
+	case parquetInt64:
+		if len(src) < 8*n {
+			return 0, errParquetCorrupt
+		}
+		for i := 0; i < n; i++ {
+			pv.ints = append(pv.ints, int64(binary.LittleEndian.Uint64(src[8*i:])))
+		}
+		return 8 * n, nil
+	case parquetFloat:
+		if len(src) < 4*n {
+			return 0, errParquetCorrupt
+		}
+		for i := 0; i < n; i++ {
+			pv.floats = append(pv.floats, float64(math.Float32frombits(binary.LittleEndian.Uint32(src[4*i:]))))
+		}
+		return 4 * n, nil
+	case parquetDouble:
+		if len(src) < 8*n {
+			return 0, errParquetCorrupt
+		}
+		for i := 0; i < n; i++ {
+			pv.floats = append(pv.floats, math.Float64frombits(binary.LittleEndian.Uint64(src[8*i:])))
+		}
+		return 8 * n, nil
+	case parquetInt96, parquetFixedLenByteArray:
+		size := int(typeLength)
+		if typ == parquetInt96 {
+			size = 12
+		}
+		if size <= 0 || len(src) < size*n {
+			return 0, errParquetCorrupt
+		}
+		for i := 0; i < n; i++ {
+			pv.bytes = append(pv.bytes, src[size*i:size*(i+1)])
+		}
+		return size * n, nil
+	case parquetByteArray:
+		pos := 0
+		for i := 0; i < n; i++ {
+			if pos+4 > len(src) {
+				return 0, errParquetCorrupt
+			}
+			size := int(binary.LittleEndian.Uint32(src[pos:]))
+			pos += 4
+			if size < 0 || size > len(src)-pos {
+				return 0, errParquetCorrupt
+			}
+			pv.bytes = append(pv.bytes, src[pos:pos+size])
+			pos += size
+		}
+		return pos, nil
+	default:
+		return 0, fmt.Errorf("parquet: unknown physical type %d", typ)
+	}
+}
+
+// unpackBits appends n values of bitWidth bits, packed from the lowest bit.
+func unpackBits(dst []int32, src []byte, bitWidth, n int) []int32 {
+	var acc uint64
+	nbits := 0
+	pos := 0
+	mask := uint64(1)<<uint(bitWidth) - 1
+	for i := 0; i < n; i++ {
+		for nbits < bitWidth {
+			var b byte
+			if pos < len(src) {
+				b = src[pos]
+			}
+			pos++
+			acc |= uint64(b) << uint(nbits)
+			nbits += 8
+		}
+		dst = append(dst, int32(acc&mask))
+		acc >>= uint(bitWidth)
+		nbits -= bitWidth
+	}
+	return dst
+}
+
+// decodeParquetRLE decodes n values of the RLE/bit-packing hybrid encoding.
+func decodeParquetRLE(src []byte, bitWidth, n int) ([]int32, error) {
+	if bitWidth < 0 || bitWidth > 32 {
+		return nil, fmt.Errorf("parquet: invalid bit width %d", bitWidth)
+	}
+	if n < 0 {
+		return nil, errParquetCorrupt
+	}
+	// n is from the file, so that the capacity is bounded by the data
+	dst := make([]int32, 0, min(n, 8*len(src)))
+	byteWidth := (bitWidth + 7) / 8
+	for len(dst) < n {
+		hd, k := binary.Uvarint(src)
+		if k <= 0 {
+			return nil, errParquetCorrupt
+		}
+		src = src[k:]
+		if hd&1 == 1 {
+			// bit-packed groups of 8 values
+			count := int(hd>>1) * 8
+			size := int(hd>>1) * bitWidth
+			if size > len(src) {
+				return nil, errParquetCorrupt
+			}
+			if count > n-len(dst) {
+				count = n - len(dst)
+			}
+			dst = unpackBits(dst, src[:size], bitWidth, count)
+			src = src[size:]
+			continue
+		}
+		count := int(hd >> 1)
+		if len(src) < byteWidth {
+			return nil, errParquetCorrupt
+		}
+		var v int32
+		for i := byteWidth - 1; i >= 0; i-- {
+			v = v<<8 | int32(src[i])
+		}
+		src = src[byteWidth:]
+		if count > n-len(dst) {
+			count = n - len(dst)
+		}
+		for i := 0; i < count; i++ {
+			dst = append(dst, v)
+		}
+	}
+	return dst, nil
+}
+
+// appendParquetRLE encodes the values in the RLE/bit-packing hybrid encoding.
+// Runs of 8 or more repeated values are run-length encoded,
+// and the others are bit-packed in groups of 8.
+func appendParquetRLE(dst []byte, vals []int32, bitWidth int) []byte {
+	byteWidth := (bitWidth + 7) / 8
+	runLength := func(i int) int {
+		j := i + 1
+		for j < len(vals) && vals[j] == vals[i] {
+			j++
+		}
+		return j - i
+	}
+	for i := 0; i < len(vals); {
+		if r := runLength(i); r >= 8 {
+			dst = binary.AppendUvarint(dst, uint64(r)<<1)
+			for b := 0; b < byteWidth; b++ {
+				dst = append(dst, byte(vals[i]>>(8*uint(b))))
+			}
+			i += r
+			continue
+		}
+
+		start := i
+		for i < len(vals) {
+			i += 8
+			if i >= len(vals) {
+				i = len(vals)
+				break
+			}
+			if runLength(i) >= 8 {
+				break
+			}
+		}
+		groups := (i - start + 7) / 8
+		dst = binary.AppendUvarint(dst, uint64(groups)<<1|1)
+		var acc uint64
+		nbits := 0
+		for j := 0; j < groups*8; j++ {
+			var v int32
+			if start+j < i {
+				v = vals[start+j]
+			}
+			acc |= uint64(uint32(v)) << uint(nbits)
+			nbits += bitWidth
+			for nbits >= 8 {
+				dst = append(dst, byte(acc))
+				acc >>= 8
+				nbits -= 8
+			}
+		}
+	}
+	return dst
+}
+
+// parquetMaxDeltaBlockSize is the largest DELTA_BINARY_PACKED block size
+// that is decoded. The writers use 128, and the block size cannot be bound
+// by the page size, since a block of equal deltas takes only a few bytes.
+const parquetMaxDeltaBlockSize = 1 << 16
+
+// decodeParquetDeltaBinaryPacked decodes DELTA_BINARY_PACKED integers,
+// and returns the number of bytes read.
+func decodeParquetDeltaBinaryPacked(src []byte) ([]int64, int, error) {
+	pos := 0
+	uvarint := func() (uint64, error) {
+		v, k := binary.Uvarint(src[pos:])
+		if k <= 0 {
+			return 0, errParquetCorrupt
+		}
+		pos += k
+		return v, nil
+	}
+	varint := func() (int64, error) {
+		v, k := binary.Varint(src[pos:])
+		if k <= 0 {
+			return 0, errParquetCorrupt
+		}
+		pos += k
+		return v, nil
+	}
+
+	blockSize, err := uvarint()
+	if err != nil {
+		return nil, 0, err
+	}
+	miniblocks, err := uvarint()
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := uvarint()
+	if err != nil {
+		return nil, 0, err
+	}
+	first, err := varint()
+	if err != nil {
+		return nil, 0, err
+	}
+	// the block size is a multiple of 128, and each miniblock has
+	// a multiple of 8 values and a bit width byte
+	if blockSize == 0 || blockSize%128 != 0 || blockSize > parquetMaxDeltaBlockSize ||
+		miniblocks == 0 || miniblocks > blockSize/8 || miniblocks > uint64(len(src)) ||
+		blockSize%miniblocks != 0 || total > uint64(len(src))*64 {
+		return nil, 0, errParquetCorrupt
+	}
+	perMiniblock := int(blockSize / miniblocks)
+	if perMiniblock%8 != 0 {
+		return nil, 0, errParquetCorrupt
+	}
+
+	vals := make([]int64, 0, total)
+	if total > 0 {
+		vals = append(vals, first)
+	}
+	last := first
+	deltas := make([]int64, perMiniblock)
+	for uint64(len(vals)) < total {
+		minDelta, err := varint()
+		if err != nil {
+			return nil, 0, err
+		}
+		if pos+int(miniblocks) > len(src) {
+			return nil, 0, errParquetCorrupt
+		}
+		widths := src[pos : pos+int(miniblocks)]
+		pos += int(miniblocks)
+		for _, w := range widths {
+			if uint64(len(vals)) >= total {
+				break
+			}
+			if w > 64 {
+				return nil, 0, errParquetCorrupt
+			}
+			size := perMiniblock * int(w) / 8
+			if pos+size > len(src) {
+				return nil, 0, errParquetCorrupt
+			}
+			unpackBits64(deltas, src[pos:pos+size], int(w))
+			pos += size
+			for _, d := range deltas {
+				if uint64(len(vals)) >= total {
+					break
+				}
+				// wrap around on overflow, as the encoders do
+				last = int64(uint64(last) + uint64(minDelta) + uint64(d))
+				vals = append(vals, last)
+			}
+		}
+	}
+	return vals, pos, nil
+}
+
+// unpackBits64 unpacks len(dst) values of up to 64 bits.
+func unpackBits64(dst []int64, src []byte, bitWidth int) {
+	bitPos := 0
+	for i := range dst {
+		var v uint64
+		for b := 0; b < bitWidth; b++ {
+			p := bitPos + b
+			if src[p/8]>>(uint(p)%8)&1 == 1 {
+				v |= 1 << uint(b)
+			}
+		}
+		dst[i] = int64(v)
+		bitPos += bitWidth
+	}
+}
+
+// decodeParquetDeltaLengthByteArray decodes n DELTA_LENGTH_BYTE_ARRAY values.
+func decodeParquetDeltaLengthByteArray(src []byte, n int) ([][]byte, int, error) {
+	lengths, pos, err := decodeParquetDeltaBinaryPacked(src)
+	if err != nil {
+		return nil, 0, err
+	}
+	if n < 0 || len(lengths) < n {
+		return nil, 0, errParquetCorrupt
+	}
+	vals := make([][]byte, n)
+	for i := range vals {
+		size := lengths[i]
+		if size < 0 || size > int64(len(src)-pos) {
+			return nil, 0, errParquetCorrupt
+		}
+		vals[i] = src[pos : pos+int(size)]
+		pos += int(size)
+	}
+	return vals, pos, nil
+}
+
+// decodeParquetDeltaByteArray decodes n DELTA_BYTE_ARRAY values,
+// which are the lengths of the prefixes shared with the previous values
+// and the suffixes.
+func decodeParquetDeltaByteArray(src []byte, n int) ([][]byte, error) {
+	prefixes, pos, err := decodeParquetDeltaBinaryPacked(src)
+	if err != nil {
+		return nil, err
+	}
+	suffixes, _, err := decodeParquetDeltaLengthByteArray(src[pos:], n)
+	if err != nil {
+		return nil, err
+	}
+	if len(prefixes) < n {
+		return nil, errParquetCorrupt
+	}
+	vals := make([][]byte, n)
+	var prev []byte
+	for i := range vals {
+		p := prefixes[i]
+		if p < 0 || p > int64(len(prev)) {
+			return nil, errParquetCorrupt
+		}
+		v := make([]byte, 0, int(p)+len(suffixes[i]))
+		v = append(append(v, prev[:p]...), suffixes[i]...)
+		vals[i], prev = v, v
+	}
+	return vals, nil
+}
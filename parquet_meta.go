@@ -0,0 +1,471 @@
+package dataframe
+
+import "fmt"
+
+// Parquet metadata, as defined in parquet.thrift of the Parquet format.
+// Only the fields that this package uses are decoded.
+
+// parquet physical types
+const (
+	parquetBoolean           = 0
+	parquetInt32             = 1
+	parquetInt64             = 2
+	parquetInt96             = 3
+	parquetFloat             = 4
+	parquetDouble            = 5
+	parquetByteArray         = 6
+	parquetFixedLenByteArray = 7
+)
+
+// parquet repetition types
+const (
+	parquetRequired = 0
+	parquetOptional = 1
+	parquetRepeated = 2
+)
+
+// parquet converted types, which are the legacy logical types
+const (
+	parquetConvertedNone            = -1
+	parquetConvertedUTF8            = 0
+	parquetConvertedEnum            = 4
+	parquetConvertedDecimal         = 5
+	parquetConvertedDate            = 6
+	parquetConvertedTimeMillis      = 7
+	parquetConvertedTimeMicros      = 8
+	parquetConvertedTimestampMillis = 9
+	parquetConvertedTimestampMicros = 10
+	parquetConvertedUint8           = 11
+	parquetConvertedUint16          = 12
+	parquetConvertedUint32          = 13
+	parquetConvertedUint64          = 14
+	parquetConvertedInt8            = 15
+	parquetConvertedInt16           = 16
+	parquetConvertedInt32           = 17
+	parquetConvertedInt64           = 18
+	parquetConvertedJSON            = 19
+)
+
+// parquet logical types, by the field id in the LogicalType union
+const (
+	parquetLogicalNone      = 0
+	parquetLogicalString    = 1
+	parquetLogicalEnum      = 4
+	parquetLogicalDecimal   = 5
+	parquetLogicalDate      = 6
+	parquetLogicalTime      = 7
+	parquetLogicalTimestamp = 8
+	parquetLogicalInteger   = 10
+	parquetLogicalJSON      = 12
+	parquetLogicalUUID      = 14
+)
+
+// parquet time units, by the field id in the TimeUnit union
+const (
+	parquetMillis = 1
+	parquetMicros = 2
+	parquetNanos  = 3
+)
+
+// parquet encodings
+const (
+	parquetPlain                = 0
+	parquetPlainDictionary      = 2
+	parquetRLE                  = 3
+	parquetBitPacked            = 4
+	parquetDeltaBinaryPacked    = 5
+	parquetDeltaLengthByteArray = 6
+	parquetDeltaByteArray       = 7
+	parquetRLEDictionary        = 8
+)
+
+// parquet compression codecs
+const (
+	parquetUncompressed = 0
+	parquetSnappy       = 1
+	parquetGzip         = 2
+	parquetZstd         = 6
+)
+
+// parquet page types
+const (
+	parquetDataPage       = 0
+	parquetDictionaryPage = 2
+	parquetDataPageV2     = 3
+)
+
+type parquetLogicalType struct {
+	kind int16
+
+	// unit and adjustedToUTC are for TIME and TIMESTAMP.
+	unit          int16
+	adjustedToUTC bool
+
+	// bitWidth and signed are for INTEGER.
+	bitWidth int8
+	signed   bool
+
+	// scale and precision are for DECIMAL.
+	scale, precision int32
+}
+
+type parquetSchemaElement struct {
+	typ         int32 // -1 for groups
+	typeLength  int32
+	repetition  int32
+	name        string
+	numChildren int32
+	converted   int32
+	scale       int32
+	precision   int32
+	logical     parquetLogicalType
+}
+
+type parquetColumnMeta struct {
+	typ                  int32
+	encodings            []int32
+	path                 []string
+	codec                int32
+	numValues            int64
+	totalUncompressed    int64
+	totalCompressed      int64
+	dataPageOffset       int64
+	dictionaryPageOffset int64
+	nullCount            int64
+}
+
+type parquetColumnChunk struct {
+	filePath   string
+	fileOffset int64
+	meta       parquetColumnMeta
+}
+
+type parquetRowGroup struct {
+	columns       []parquetColumnChunk
+	totalByteSize int64
+	numRows       int64
+}
+
+type parquetKeyValue struct {
+	key, value string
+}
+
+type parquetFileMeta struct {
+	version   int32
+	schema    []parquetSchemaElement
+	numRows   int64
+	rowGroups []parquetRowGroup
+	keyValues []parquetKeyValue
+	createdBy string
+}
+
+type parquetPageHeader struct {
+	typ              int32
+	uncompressedSize int32
+	compressedSize   int32
+
+	// numValues and encoding are for data and dictionary pages.
+	numValues int32
+	encoding  int32
+
+	// numNulls, defLevelsLength, and compressed are for DATA_PAGE_V2.
+	numNulls        int32
+	defLevelsLength int32
+	repLevelsLength int32
+	compressed      bool
+}
+
+func (m *parquetFileMeta) encode() []byte {
+	w := &thriftWriter{}
+	w.beginStruct(0)
+	w.i32(1, m.version)
+	w.list(2, thriftStructure, len(m.schema))
+	for _, se := range m.schema {
+		w.beginElement()
+		if se.typ >= 0 {
+			w.i32(1, se.typ)
+		}
+		if se.typ == parquetFixedLenByteArray {
+			w.i32(2, se.typeLength)
+		}
+		if se.typ >= 0 {
+			w.i32(3, se.repetition)
+		}
+		w.binary(4, se.name)
+		if se.numChildren > 0 {
+			w.i32(5, se.numChildren)
+		}
+		if se.converted != parquetConvertedNone {
+			w.i32(6, se.converted)
+		}
+		if se.logical.kind != parquetLogicalNone {
+			w.beginStruct(10)
+			encodeParquetLogicalType(w, se.logical)
+			w.endStruct()
+		}
+		w.endStruct()
+	}
+	w.i64(3, m.numRows)
+	w.list(4, thriftStructure, len(m.rowGroups))
+	for _, rg := range m.rowGroups {
+		w.beginElement()
+		w.list(1, thriftStructure, len(rg.columns))
+		for _, cc := range rg.columns {
+			w.beginElement()
+			w.i64(2, cc.fileOffset)
+			w.beginStruct(3)
+			cm := cc.meta
+			w.i32(1, cm.typ)
+			w.list(2, thriftI32, len(cm.encodings))
+			for _, enc := range cm.encodings {
+				w.elemI32(enc)
+			}
+			w.list(3, thriftBinary, len(cm.path))
+			for _, p := range cm.path {
+				w.elemBinary(p)
+			}
+			w.i32(4, cm.codec)
+			w.i64(5, cm.numValues)
+			w.i64(6, cm.totalUncompressed)
+			w.i64(7, cm.totalCompressed)
+			w.i64(9, cm.dataPageOffset)
+			w.beginStruct(12) // statistics
+			w.i64(3, cm.nullCount)
+			w.endStruct()
+			w.endStruct()
+			w.endStruct()
+		}
+		w.i64(2, rg.totalByteSize)
+		w.i64(3, rg.numRows)
+		w.endStruct()
+	}
+	if len(m.keyValues) > 0 {
+		w.list(5, thriftStructure, len(m.keyValues))
+		for _, kv := range m.keyValues {
+			w.beginElement()
+			w.binary(1, kv.key)
+			w.binary(2, kv.value)
+			w.endStruct()
+		}
+	}
+	w.binary(6, m.createdBy)
+	w.endStruct()
+	return w.buf
+}
+
+func encodeParquetLogicalType(w *thriftWriter, lt parquetLogicalType) {
+	w.beginStruct(lt.kind)
+	switch lt.kind {
+	case parquetLogicalTime, parquetLogicalTimestamp:
+		w.bool(1, lt.adjustedToUTC)
+		w.beginStruct(2)
+		w.beginStruct(lt.unit)
+		w.endStruct()
+		w.endStruct()
+	case parquetLogicalInteger:
+		w.i8(1, lt.bitWidth)
+		w.bool(2, lt.signed)
+	case parquetLogicalDecimal:
+		w.i32(1, lt.scale)
+		w.i32(2, lt.precision)
+	}
+	w.endStruct()
+}
+
+func (h *parquetPageHeader) encode() []byte {
+	w := &thriftWriter{}
+	w.beginStruct(0)
+	w.i32(1, h.typ)
+	w.i32(2, h.uncompressedSize)
+	w.i32(3, h.compressedSize)
+	switch h.typ {
+	case parquetDataPage:
+		w.beginStruct(5)
+		w.i32(1, h.numValues)
+		w.i32(2, h.encoding)
+		w.i32(3, parquetRLE) // definition levels
+		w.i32(4, parquetRLE) // repetition levels
+		w.endStruct()
+	case parquetDictionaryPage:
+		w.beginStruct(7)
+		w.i32(1, h.numValues)
+		w.i32(2, h.encoding)
+		w.endStruct()
+	}
+	w.endStruct()
+	return w.buf
+}
+
+func toInt32(v int64, ok bool) int32 {
+	if !ok {
+		return 0
+	}
+	return int32(v)
+}
+
+func decodeParquetFileMeta(data []byte) (*parquetFileMeta, error) {
+	s, _, err := readThriftStruct(data)
+	if err != nil {
+		return nil, err
+	}
+	m := &parquetFileMeta{
+		version:   toInt32(s.int(1)),
+		createdBy: s.string(6),
+	}
+	m.numRows, _ = s.int(3)
+	for _, v := range s.list(2) {
+		es, ok := v.(thriftStruct)
+		if !ok {
+			return nil, errThriftCorrupt
+		}
+		se := parquetSchemaElement{
+			typ:         -1,
+			typeLength:  toInt32(es.int(2)),
+			repetition:  toInt32(es.int(3)),
+			name:        es.string(4),
+			numChildren: toInt32(es.int(5)),
+			converted:   parquetConvertedNone,
+			scale:       toInt32(es.int(7)),
+			precision:   toInt32(es.int(8)),
+		}
+		if tp, ok := es.int(1); ok {
+			se.typ = int32(tp)
+		}
+		if ct, ok := es.int(6); ok {
+			se.converted = int32(ct)
+		}
+		if lt := es.structure(10); lt != nil {
+			se.logical = decodeParquetLogicalType(lt)
+		}
+		m.schema = append(m.schema, se)
+	}
+	for _, v := range s.list(4) {
+		rs, ok := v.(thriftStruct)
+		if !ok {
+			return nil, errThriftCorrupt
+		}
+		rg := parquetRowGroup{}
+		rg.totalByteSize, _ = rs.int(2)
+		rg.numRows, _ = rs.int(3)
+		for _, cv := range rs.list(1) {
+			cs, ok := cv.(thriftStruct)
+			if !ok {
+				return nil, errThriftCorrupt
+			}
+			cc := parquetColumnChunk{filePath: cs.string(1)}
+			cc.fileOffset, _ = cs.int(2)
+			ms := cs.structure(3)
+			if ms == nil {
+				return nil, fmt.Errorf("parquet: column chunk without metadata")
+			}
+			cm := &cc.meta
+			cm.typ = toInt32(ms.int(1))
+			for _, e := range ms.list(2) {
+				if ev, ok := e.(int64); ok {
+					cm.encodings = append(cm.encodings, int32(ev))
+				}
+			}
+			for _, p := range ms.list(3) {
+				if pv, ok := p.([]byte); ok {
+					cm.path = append(cm.path, string(pv))
+				}
+			}
+			cm.codec = toInt32(ms.int(4))
+			cm.numValues, _ = ms.int(5)
+			cm.totalUncompressed, _ = ms.int(6)
+			cm.totalCompressed, _ = ms.int(7)
+			cm.dataPageOffset, _ = ms.int(9)
+			cm.dictionaryPageOffset, _ = ms.int(11)
+			if st := ms.structure(12); st != nil {
+				cm.nullCount, _ = st.int(3)
+			}
+			rg.columns = append(rg.columns, cc)
+		}
+		m.rowGroups = append(m.rowGroups, rg)
+	}
+	for _, v := range s.list(5) {
+		ks, ok := v.(thriftStruct)
+		if !ok {
+			return nil, errThriftCorrupt
+		}
+		m.keyValues = append(m.keyValues, parquetKeyValue{key: ks.string(1), value: ks.string(2)})
+	}
+	return m, nil
+}
+
+func decodeParquetLogicalType(s thriftStruct) parquetLogicalType {
+	var lt parquetLogicalType
+	for id, v := range s {
+		fs, ok := v.(thriftStruct)
+		if !ok {
+			continue
+		}
+		lt.kind = id
+		switch id {
+		case parquetLogicalTime, parquetLogicalTimestamp:
+			lt.adjustedToUTC, _ = fs.bool(1)
+			for unit := range fs.structure(2) {
+				lt.unit = unit
+			}
+		case parquetLogicalInteger:
+			bw, _ := fs.int(1)
+			lt.bitWidth = int8(bw)
+			lt.signed, _ = fs.bool(2)
+		case parquetLogicalDecimal:
+			lt.scale = toInt32(fs.int(1))
+			lt.precision = toInt32(fs.int(2))
+		}
+	}
+	return lt
+}
+
+// decodeParquetPageHeader decodes the page header,
+// and returns the number of bytes read.
+func decodeParquetPageHeader(data []byte) (*parquetPageHeader, int, error) {
+	s, n, err := readThriftStruct(data)
+	if err != nil {
+		return nil, 0, err
+	}
+	h := &parquetPageHeader{
+		typ:              toInt32(s.int(1)),
+		uncompressedSize: toInt32(s.int(2)),
+		compressedSize:   toInt32(s.int(3)),
+		compressed:       true,
+	}
+	switch h.typ {
+	case parquetDataPage:
+		ds := s.structure(5)
+		if ds == nil {
+			return nil, 0, fmt.Errorf("parquet: data page without header")
+		}
+		h.numValues = toInt32(ds.int(1))
+		h.encoding = toInt32(ds.int(2))
+	case parquetDictionaryPage:
+		ds := s.structure(7)
+		if ds == nil {
+			return nil, 0, fmt.Errorf("parquet: dictionary page without header")
+		}
+		h.numValues = toInt32(ds.int(1))
+		h.encoding = toInt32(ds.int(2))
+	case parquetDataPageV2:
+		ds := s.structure(8)
+		if ds == nil {
+			return nil, 0, fmt.Errorf("parquet: data page v2 without header")
+		}
+		h.numValues = toInt32(ds.int(1))
+		h.numNulls = toInt32(ds.int(2))
+		h.encoding = toInt32(ds.int(4))
+		h.defLevelsLength = toInt32(ds.int(5))
+		h.repLevelsLength = toInt32(ds.int(6))
+		if c, ok := ds.bool(7); ok {
+			h.compressed = c
+		}
+	}
+	if h.compressedSize < 0 || h.uncompressedSize < 0 {
+		return nil, 0, errThriftCorrupt
+	}
+	if h.numValues < 0 || h.numNulls < 0 || h.defLevelsLength < 0 || h.repLevelsLength < 0 {
+		return nil, 0, errParquetCorrupt
+	}
+	return h, n, nil
+}
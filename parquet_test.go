@@ -0,0 +1,233 @@
+package dataframe
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// newTypedTestFrame returns the Frame of 100 rows in all the data
+// types, with the null rows, and its Schema.
+func newTypedTestFrame(t *testing.T) (Frame, Schema) {
+	schema := Schema{Fields: []Field{
+		{Name: "unix_ts", Type: TIME, Layout: LayoutUnixSeconds},
+		{Name: "count", Type: INT64, Nullable: true},
+		{Name: "bytes", Type: UINT64, Nullable: true},
+		{Name: "avg_latency_ms", Type: FLOAT64, Nullable: true},
+		{Name: "ok", Type: BOOL, Nullable: true},
+		{Name: "took", Type: DURATION, Nullable: true},
+		{Name: "STATE", Type: STRING},
+	}}
+	var rows [][]string
+	for i := 0; i < 100; i++ {
+		row := []string{
+			fmt.Sprint(1458757864 + i),
+			fmt.Sprint(i - 50),
+			fmt.Sprint(uint64(i) << 56),
+			fmt.Sprint(float64(i) / 3),
+			fmt.Sprint(i%3 == 0),
+			fmt.Sprint(time.Duration(i) * time.Millisecond),
+			fmt.Sprintf("state-%d", i%7),
+		}
+		if i%9 == 4 {
+			for j := 1; j < 6; j++ {
+				row[j] = ""
+			}
+		}
+		rows = append(rows, row)
+	}
+	fr, err := NewFromRowsTyped(schema.Headers(), rows, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fr, schema
+}
+
+func TestParquet(t *testing.T) {
+	fr, schema := newTypedTestFrame(t)
+	_, expected := fr.Rows()
+
+	for _, opts := range []ParquetWriteOptions{
+		{},
+		{Compression: Compression_Snappy},
+		{Compression: Compression_Gzip, RowGroupSize: 30},
+		{Compression: Compression_Zstd, RowGroupSize: 100},
+	} {
+		var buf bytes.Buffer
+		if err := fr.WriteParquet(&buf, opts); err != nil {
+			t.Fatal(err)
+		}
+		got, err := NewFromParquet(bytes.NewReader(buf.Bytes()), int64(buf.Len()), ParquetReadOptions{})
+		if err != nil {
+			t.Fatalf("%v: %v", opts.Compression, err)
+		}
+		if !got.Schema().Equal(schema) {
+			t.Fatalf("%v: expected %v, got %v", opts.Compression, schema, got.Schema())
+		}
+		if _, grows := got.Rows(); !reflect.DeepEqual(grows, expected) {
+			t.Fatalf("%v: expected %v, got %v", opts.Compression, expected, grows)
+		}
+
+		// only decode the projected columns
+		got, err = NewFromParquet(bytes.NewReader(buf.Bytes()), int64(buf.Len()), ParquetReadOptions{Columns: []string{"avg_latency_ms", "unix_ts"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hd := got.Headers(); !reflect.DeepEqual(hd, []string{"avg_latency_ms", "unix_ts"}) {
+			t.Fatalf("expected projected headers, got %v", hd)
+		}
+		col, err := got.Column("avg_latency_ms")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if col.Count() != 100 || col.NullCount() != 11 {
+			t.Fatalf("expected 100 rows with 11 nulls, got %d rows with %d nulls", col.Count(), col.NullCount())
+		}
+	}
+
+	var serr *SchemaError
+	var buf bytes.Buffer
+	if err := fr.WriteParquet(&buf, ParquetWriteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	_, err := NewFromParquet(bytes.NewReader(buf.Bytes()), int64(buf.Len()), ParquetReadOptions{Columns: []string{"unknown"}})
+	if !errors.As(err, &serr) || !errors.Is(err, ErrFieldNotFound) {
+		t.Fatalf("expected ErrFieldNotFound, got %v", err)
+	}
+	if _, err := NewFromParquet(bytes.NewReader([]byte("PAR1")), 4, ParquetReadOptions{}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestNewFromParquetEncodings(t *testing.T) {
+	// written by another implementation, with DATA_PAGE_V2 pages in Snappy,
+	// dictionary and delta encodings, and DATE and TIMESTAMP logical types
+	f, err := os.Open("testdata/records-snappy-v2.parquet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	st, err := f.Stat()
+	if err != nil {
+		t.Fatal(err)
+	}
+	fr, err := NewFromParquet(f, st.Size(), ParquetReadOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Field{
+		{Name: "id", Type: INT64},
+		{Name: "i32", Type: INT64},
+		{Name: "u32", Type: UINT64},
+		{Name: "f32", Type: FLOAT64},
+		{Name: "b", Type: BOOL},
+		{Name: "s", Type: STRING},
+		{Name: "sd", Type: STRING},
+		{Name: "so", Type: STRING, Nullable: true},
+		{Name: "io", Type: INT64, Nullable: true},
+		{Name: "ts", Type: TIME},
+		{Name: "tsn", Type: TIME},
+		{Name: "d", Type: TIME, Layout: "2006-01-02"},
+		{Name: "raw", Type: STRING},
+	}
+	if sc := fr.Schema(); !sc.Equal(Schema{Fields: expected}) {
+		t.Fatalf("expected %v, got %v", expected, sc.Fields)
+	}
+
+	row := 97
+	for header, v := range map[string]Value{
+		"id":  Int64(97),
+		"i32": Int64(191),
+		"u32": Uint64(4000000097),
+		"f32": Float64(24.25),
+		"b":   Bool(false),
+		"s":   String("cat-2"),
+		"sd":  String("prefix-097"),
+		"so":  String("opt-97"),
+		"io":  Int64(7),
+		"ts":  GoTime(time.Date(2023, 5, 1, 0, 1, 37, 0, time.UTC)),
+		"tsn": GoTime(time.Date(2023, 5, 1, 0, 0, 0, 123456789, time.UTC)),
+		"d":   GoTime(time.Date(2022, 4, 15, 0, 0, 0, 0, time.UTC)),
+	} {
+		col, err := fr.Column(header)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := col.Value(row)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !got.EqualTo(v) {
+			t.Fatalf("%s: expected %v, got %v", header, v, got)
+		}
+	}
+	col, err := fr.Column("so")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col.Count() != 100 || col.NullCount() != 25 {
+		t.Fatalf("expected 100 rows with 25 nulls, got %d rows with %d nulls", col.Count(), col.NullCount())
+	}
+}
+
+func TestNewFromParquetCorrupt(t *testing.T) {
+	hd := &parquetPageHeader{typ: parquetDataPage, numValues: -1, encoding: parquetPlain}
+	if _, _, err := decodeParquetPageHeader(hd.encode()); !errors.Is(err, errParquetCorrupt) {
+		t.Fatalf("expected %v, got %v", errParquetCorrupt, err)
+	}
+
+	// DELTA_BINARY_PACKED headers of block size, miniblocks, total and first
+	for _, hdr := range [][]uint64{
+		{1 << 34, 4, 2, 0},
+		{128, 1 << 63, 2, 0},
+		{128, 3, 2, 0},
+		{100, 4, 2, 0},
+	} {
+		var src []byte
+		for _, u := range hdr {
+			src = binary.AppendUvarint(src, u)
+		}
+		src = append(src, make([]byte, 8)...)
+		if _, _, err := decodeParquetDeltaBinaryPacked(src); !errors.Is(err, errParquetCorrupt) {
+			t.Fatalf("header %v: expected %v, got %v", hdr, errParquetCorrupt, err)
+		}
+	}
+
+	data, err := os.ReadFile("testdata/records-snappy-v2.parquet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the corrupt files must return errors, not panic
+	buf := make([]byte, len(data))
+	for i := range data {
+		for _, b := range []byte{0x00, 0xff, data[i] ^ 0x80} {
+			copy(buf, data)
+			buf[i] = b
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						t.Fatalf("byte %d of 0x%02x: panic %v", i, b, r)
+					}
+				}()
+				NewFromParquet(bytes.NewReader(buf), int64(len(buf)), ParquetReadOptions{})
+			}()
+		}
+	}
+}
+
+func FuzzNewFromParquet(f *testing.F) {
+	data, err := os.ReadFile("testdata/records-snappy-v2.parquet")
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(data)
+	f.Fuzz(func(t *testing.T, data []byte) {
+		NewFromParquet(bytes.NewReader(data), int64(len(data)), ParquetReadOptions{})
+	})
+}
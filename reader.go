@@ -0,0 +1,199 @@
+package dataframe
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ReadOptions configures reading CSV.
+type ReadOptions struct {
+	// Header is used as header strings if not empty, and the data
+	// starts from the first row. If empty, the first row is used
+	// as header strings.
+	Header []string
+
+	// Schema parses the cells into typed columns, if not nil.
+	Schema *Schema
+
+	// Infer infers the Schema from the data, if not nil and
+	// Schema is nil. BatchReader infers it from the first batch,
+	// and reuses it for the following batches.
+	Infer *InferOptions
+
+	// Delimiter is the field delimiter. Zero uses comma.
+	// Use '\t' for TSV.
+	Delimiter rune
+
+	// Comment, if not zero, is the character that starts
+	// a comment line to be ignored.
+	Comment rune
+
+	// LazyQuotes is true to allow quotes in unquoted fields,
+	// and non-doubled quotes in quoted fields.
+	LazyQuotes bool
+
+	// TrimLeadingSpace is true to ignore leading white space in fields.
+	TrimLeadingSpace bool
+
+	// SkipLines is the number of lines to skip before the header
+	// or the first row.
+	SkipLines int
+}
+
+func newCSVReader(r io.Reader, opts ReadOptions) (*csv.Reader, error) {
+	if opts.SkipLines > 0 {
+		br := bufio.NewReader(r)
+		for i := 0; i < opts.SkipLines; i++ {
+			if _, err := br.ReadString('\n'); err != nil {
+				if err == io.EOF {
+					break
+				}
+				return nil, err
+			}
+		}
+		r = br
+	}
+
+	rd := csv.NewReader(r)
+	if opts.Delimiter != 0 {
+		rd.Comma = opts.Delimiter
+	}
+	rd.Comment = opts.Comment
+	rd.LazyQuotes = opts.LazyQuotes
+	rd.TrimLeadingSpace = opts.TrimLeadingSpace
+
+	// FieldsPerRecord is the number of expected fields per record.
+	// If FieldsPerRecord is positive, Read requires each record to
+	// have the given number of fields. If FieldsPerRecord is 0, Read sets it to
+	// the number of fields in the first record, so that future records must
+	// have the same field count. If FieldsPerRecord is negative, no check is
+	// made and records may have a variable number of fields.
+	rd.FieldsPerRecord = -1
+
+	return rd, nil
+}
+
+// newFromRowsOptions creates Frame from rows without header strings.
+func newFromRowsOptions(header []string, rows [][]string, opts ReadOptions) (Frame, error) {
+	switch {
+	case opts.Schema != nil:
+		return NewFromRowsTyped(header, rows, *opts.Schema)
+	case opts.Infer != nil:
+		schema, err := InferSchema(header, rows, *opts.Infer)
+		if err != nil {
+			return nil, err
+		}
+		return NewFromRowsTyped(header, rows, schema)
+	default:
+		if len(rows) == 0 { // NewFromRows needs at least one row
+			return NewFromRows(nil, [][]string{header})
+		}
+		return NewFromRows(header, rows)
+	}
+}
+
+// NewFromReader creates a new Frame from CSV data in io.Reader.
+// Use BatchReader to read large data in chunks.
+func NewFromReader(r io.Reader, opts ReadOptions) (Frame, error) {
+	rd, err := newCSVReader(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := rd.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	header := opts.Header
+	if len(header) == 0 {
+		if len(rows) < 1 {
+			return nil, fmt.Errorf("empty row %q", rows)
+		}
+		header, rows = rows[0], rows[1:]
+	}
+	return newFromRowsOptions(header, rows, opts)
+}
+
+// BatchReader reads CSV data from io.Reader in Frames of fixed number
+// of rows, so that only one batch is kept in memory at a time.
+type BatchReader struct {
+	rd     *csv.Reader
+	size   int
+	opts   ReadOptions
+	header []string
+
+	// rows is the number of data rows read so far.
+	rows int
+}
+
+// NewBatchReader returns a BatchReader that reads up to 'rows' rows per Frame.
+// If the options do not have the header, it reads the first row as header strings.
+func NewBatchReader(r io.Reader, rows int, opts ReadOptions) (*BatchReader, error) {
+	if rows < 1 {
+		return nil, fmt.Errorf("batch size must be positive (got %d)", rows)
+	}
+	rd, err := newCSVReader(r, opts)
+	if err != nil {
+		return nil, err
+	}
+	br := &BatchReader{
+		rd:     rd,
+		size:   rows,
+		opts:   opts,
+		header: opts.Header,
+	}
+	if len(br.header) == 0 {
+		header, err := br.rd.Read()
+		if err != nil {
+			if err == io.EOF {
+				return nil, fmt.Errorf("empty row %q", header)
+			}
+			return nil, err
+		}
+		br.header = header
+	}
+	return br, nil
+}
+
+// Header returns the header strings.
+func (br *BatchReader) Header() []string {
+	return br.header
+}
+
+// Next returns the next batch of rows as Frame.
+// It returns io.EOF when there are no more rows.
+// Row numbers in *SchemaError count from the first data row of the input.
+func (br *BatchReader) Next() (Frame, error) {
+	rows := make([][]string, 0, br.size)
+	for len(rows) < br.size {
+		row, err := br.rd.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	if len(rows) == 0 {
+		return nil, io.EOF
+	}
+
+	fr, err := newFromRowsOptions(br.header, rows, br.opts)
+	if err != nil {
+		var serr *SchemaError
+		if errors.As(err, &serr) && serr.Row >= 0 {
+			serr.Row += br.rows
+		}
+		return nil, err
+	}
+	if br.opts.Schema == nil && br.opts.Infer != nil {
+		// reuse the schema of the first batch
+		schema := fr.Schema()
+		br.opts.Schema = &schema
+	}
+	br.rows += len(rows)
+	return fr, nil
+}
@@ -0,0 +1,96 @@
+package dataframe
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewFromReader(t *testing.T) {
+	bts, err := ioutil.ReadFile("testdata/bench-01-etcd-timeseries.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	if _, err := gw.Write(bts); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	gr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fr, err := NewFromReader(gr, ReadOptions{Infer: &InferOptions{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hs := fr.Headers(); !reflect.DeepEqual(hs, []string{"unix_ts", "avg_latency_ms", "throughput"}) {
+		t.Fatalf("unexpected headers %q", hs)
+	}
+	col, err := fr.Column("avg_latency_ms")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col.DataType() != FLOAT64 || col.Count() != 229 {
+		t.Fatalf("expected %q with 229 rows, got %q with %d rows", FLOAT64, col.DataType(), col.Count())
+	}
+}
+
+func TestBatchReader(t *testing.T) {
+	f, err := openToRead("testdata/bench-01-etcd-1-monitor.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	br, err := NewBatchReader(f, 100, ReadOptions{Infer: &InferOptions{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var counts []int
+	for {
+		fr, err := br.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		col, err := fr.Column("unix_ts")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if col.DataType() != TIME {
+			t.Fatalf("expected %q, got %q", TIME, col.DataType())
+		}
+		counts = append(counts, col.Count())
+	}
+	if !reflect.DeepEqual(counts, []int{100, 100, 100, 62}) {
+		t.Fatalf("unexpected batch sizes %v", counts)
+	}
+}
+
+func TestBatchReaderSchemaError(t *testing.T) {
+	schema := Schema{Fields: []Field{{Name: "a", Type: INT64}}}
+	br, err := NewBatchReader(strings.NewReader("a\n1\n2\n3\nx\n"), 2, ReadOptions{Schema: &schema})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := br.Next(); err != nil {
+		t.Fatal(err)
+	}
+	_, err = br.Next()
+	var serr *SchemaError
+	if !errors.As(err, &serr) || serr.Row != 3 || serr.Value != "x" {
+		t.Fatalf("expected error at row 3, got %v", err)
+	}
+}
@@ -0,0 +1,224 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// ResampleFill defines how Resample fills the buckets without rows.
+type ResampleFill int
+
+const (
+	// ResampleFill_Null leaves the empty buckets null.
+	ResampleFill_Null ResampleFill = iota
+
+	// ResampleFill_Forward fills the empty buckets
+	// with the value of the previous bucket.
+	ResampleFill_Forward
+
+	// ResampleFill_Linear fills the empty buckets with the values
+	// linearly interpolated between the surrounding buckets, in FLOAT64
+	// for the integer columns. The empty buckets at the ends stay null.
+	ResampleFill_Linear
+)
+
+// maxResampleBuckets is the maximum number of the buckets of Resample,
+// so that a time far from the others does not exhaust the memory.
+const maxResampleBuckets = 1 << 22
+
+// floorDiv returns a/b rounded toward negative infinity.
+func floorDiv(a, b int64) int64 {
+	q := a / b
+	if a%b != 0 && (a < 0) != (b < 0) {
+		q--
+	}
+	return q
+}
+
+// Resample buckets the rows into the intervals of the times in the
+// Column on, of TIME or INT64 Unix seconds, and returns a new Frame with
+// one row for each bucket from the first time to the last time, in order.
+// The buckets are aligned to the Unix epoch, and the time column has the
+// start of each bucket. Each numeric column is aggregated by agg into the
+// column of the same header, and the other columns are dropped. The counts
+// of the empty buckets are 0. The rows with null times are skipped.
+func (f *frame) Resample(on string, interval time.Duration, agg AggFunc, fill ResampleFill) (Frame, error) {
+	if interval <= 0 {
+		return nil, fmt.Errorf("interval %v must be positive", interval)
+	}
+	if agg == AggFunc_Quantile || agg == AggFunc_Sketch || agg == AggFunc_SketchQuantile {
+		return nil, fmt.Errorf("%v is not supported for resampling (use GroupBy)", agg)
+	}
+	if fill < ResampleFill_Null || fill > ResampleFill_Linear {
+		return nil, fmt.Errorf("resample fill %d is unknown", fill)
+	}
+	tc, err := f.Column(on)
+	if err != nil {
+		return nil, err
+	}
+	tfd := tc.Field()
+	if tfd.Type != TIME && tfd.Type != INT64 {
+		return nil, fmt.Errorf("%q of %s is not TIME or INT64 Unix seconds", on, tfd.Type)
+	}
+	if tfd.Type == INT64 && interval%time.Second != 0 {
+		return nil, fmt.Errorf("interval %v of Unix seconds %q must be in seconds", interval, on)
+	}
+
+	// the time of each row in Unix nanoseconds
+	n := rowCount(f.Columns())
+	times, valid := make([]int64, n), make([]bool, n)
+	loc := time.UTC
+	first, last := int64(math.MaxInt64), int64(math.MinInt64)
+	for row := range times {
+		v := valueAt(tc, row)
+		if v.IsNull() {
+			continue
+		}
+		if tfd.Type == TIME {
+			t, ok := v.Time(tfd.Layout)
+			if !ok || t.Before(minUnixNano) || t.After(maxUnixNano) {
+				continue
+			}
+			if first == math.MaxInt64 {
+				loc = t.Location()
+			}
+			times[row] = t.UnixNano()
+		} else {
+			sec, ok := v.Int64()
+			if !ok || sec < minUnixNano.Unix() || sec > maxUnixNano.Unix() {
+				continue
+			}
+			times[row] = sec * int64(time.Second)
+		}
+		valid[row] = true
+		first, last = min(first, times[row]), max(last, times[row])
+	}
+
+	iv := int64(interval)
+	var buckets [][]int
+	start := int64(0)
+	if first <= last {
+		start = floorDiv(first, iv) * iv
+		// the span may overflow int64, but not uint64
+		n := uint64(last-start)/uint64(iv) + 1
+		if n > maxResampleBuckets {
+			return nil, fmt.Errorf("%q spans %d buckets of %v, more than %d", on, n, interval, maxResampleBuckets)
+		}
+		buckets = make([][]int, n)
+	}
+	for row, t := range times {
+		if valid[row] {
+			b := uint64(t-start) / uint64(iv)
+			buckets[b] = append(buckets[b], row)
+		}
+	}
+
+	fr := New()
+	bc := NewColumnField(Field{Name: tfd.Name, Type: tfd.Type, Layout: tfd.Layout})
+	for b := range buckets {
+		t := start + int64(b)*iv
+		if tfd.Type == TIME {
+			bc.PushBack(GoTime(time.Unix(0, t).In(loc)))
+		} else {
+			bc.PushBack(Int64(t / int64(time.Second)))
+		}
+	}
+	if err := fr.AddColumn(bc); err != nil {
+		return nil, err
+	}
+
+	for _, col := range f.Columns() {
+		fd := col.Field()
+		if fd.Name == on {
+			continue
+		}
+		switch fd.Type {
+		case INT64, UINT64, FLOAT64, DURATION:
+		default:
+			continue
+		}
+		a := Agg{Column: fd.Name, Func: agg, Name: fd.Name}
+		out, err := a.field(fd)
+		if err != nil {
+			return nil, err
+		}
+		vs := make([]Value, len(buckets))
+		var nonNull []Value
+		for b, rows := range buckets {
+			if len(rows) == 0 {
+				// the count of an empty bucket is 0, not null
+				if agg == AggFunc_Count || agg == AggFunc_CountDistinct {
+					vs[b] = a.aggregate(fd, nil)
+				}
+				continue
+			}
+			nonNull = nonNull[:0]
+			for _, row := range rows {
+				if v := valueAt(col, row); !v.IsNull() {
+					nonNull = append(nonNull, v)
+				}
+			}
+			vs[b] = a.aggregate(fd, nonNull)
+		}
+		switch fill {
+		case ResampleFill_Forward:
+			for b := 1; b < len(vs); b++ {
+				if vs[b] == nil {
+					vs[b] = vs[b-1]
+				}
+			}
+		case ResampleFill_Linear:
+			if out.Type == INT64 || out.Type == UINT64 {
+				for b, v := range vs {
+					if v != nil && !v.IsNull() {
+						vs[b] = Float64(valueFloat64(out.Type, v))
+					}
+				}
+				out.Type = FLOAT64
+			}
+			interpolate(out.Type, vs, nil)
+		}
+
+		rc := NewColumnField(out)
+		for _, v := range vs {
+			if v == nil {
+				v = NewNullValue()
+			}
+			rc.PushBack(v)
+		}
+		if err := fr.AddColumn(rc); err != nil {
+			return nil, err
+		}
+	}
+	return fr, nil
+}
+
+// interpolate fills the nil Values of FLOAT64 or DURATION linearly between
+// the closest non-nil Values, by their positions, or by the indexes if pos
+// is nil. The nil Values before the first and after the last non-nil
+// Values are left nil.
+func interpolate(tp DATA_TYPE, vs []Value, pos []float64) {
+	prev := -1
+	for i, v := range vs {
+		if v == nil {
+			continue
+		}
+		if prev >= 0 && i-prev > 1 && !vs[prev].IsNull() && !v.IsNull() {
+			from, to := valueFloat64(tp, vs[prev]), valueFloat64(tp, v)
+			for j := prev + 1; j < i; j++ {
+				w := float64(j-prev) / float64(i-prev)
+				if pos != nil && pos[i] != pos[prev] {
+					w = (pos[j] - pos[prev]) / (pos[i] - pos[prev])
+				}
+				x := from + (to-from)*w
+				if tp == DURATION {
+					vs[j] = GoDuration(time.Duration(math.Round(x)))
+				} else {
+					vs[j] = Float64(x)
+				}
+			}
+		}
+		prev = i
+	}
+}
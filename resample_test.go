@@ -0,0 +1,131 @@
+package dataframe
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestResample(t *testing.T) {
+	schema := Schema{Fields: []Field{
+		{Name: "unix_ts", Type: INT64},
+		{Name: "node", Type: STRING},
+		{Name: "cpu", Type: FLOAT64, Nullable: true},
+		{Name: "fd", Type: INT64},
+	}}
+	fr, err := NewFromRowsTyped(schema.Headers(), [][]string{
+		{"101", "a", "1", "10"},
+		{"100", "b", "3", "20"},
+		{"102", "a", "", "30"},
+		{"107", "b", "8", "40"},
+		{"103", "a", "5", "50"},
+	}, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		agg      AggFunc
+		fill     ResampleFill
+		expected [][]string
+	}{
+		{
+			AggFunc_Mean, ResampleFill_Null,
+			[][]string{{"100", "2", "15"}, {"102", "5", "40"}, {"104", "", ""}, {"106", "8", "40"}},
+		},
+		{
+			AggFunc_Sum, ResampleFill_Forward,
+			[][]string{{"100", "4", "30"}, {"102", "5", "80"}, {"104", "5", "80"}, {"106", "8", "40"}},
+		},
+		{
+			AggFunc_Sum, ResampleFill_Linear,
+			[][]string{{"100", "4", "30"}, {"102", "5", "80"}, {"104", "6.5", "60"}, {"106", "8", "40"}},
+		},
+		{
+			AggFunc_Count, ResampleFill_Null,
+			[][]string{{"100", "2", "2"}, {"102", "1", "2"}, {"104", "0", "0"}, {"106", "1", "1"}},
+		},
+	}
+	for i, tt := range tests {
+		got, err := fr.Resample("unix_ts", 2*time.Second, tt.agg, tt.fill)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hd, rows := got.Rows()
+		if expected := []string{"unix_ts", "cpu", "fd"}; !reflect.DeepEqual(hd, expected) {
+			t.Fatalf("#%d: expected %v, got %v", i, expected, hd)
+		}
+		if !reflect.DeepEqual(rows, tt.expected) {
+			t.Fatalf("#%d: expected %v, got %v", i, tt.expected, rows)
+		}
+		if err := got.Schema().Validate(got); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+	}
+
+	if _, err := fr.Resample("unix_ts", time.Millisecond, AggFunc_Mean, ResampleFill_Null); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := fr.Resample("node", time.Second, AggFunc_Mean, ResampleFill_Null); err == nil {
+		t.Fatal("expected error")
+	}
+
+	// a time far from the others has too many buckets
+	if err := fr.AppendRow(map[string]interface{}{"unix_ts": 1458757864, "node": "c", "fd": 60}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fr.Resample("unix_ts", time.Second, AggFunc_Mean, ResampleFill_Null); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestResampleMonitor(t *testing.T) {
+	fr, _, err := NewFromCSVInfer(nil, "testdata/bench-01-etcd-1-monitor.csv", InferOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts, err := fr.Column("unix_ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	times, ok := ts.Times(LayoutUnixSeconds)
+	if !ok {
+		t.Fatal("expected times")
+	}
+	span := times[len(times)-1].Sub(times[0].Truncate(10 * time.Second))
+
+	down, err := fr.Resample("unix_ts", 10*time.Second, AggFunc_Max, ResampleFill_Null)
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, err := down.Column("CpuUsageFloat64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := int(span/(10*time.Second)) + 1; col.Count() != n {
+		t.Fatalf("expected %d buckets, got %d", n, col.Count())
+	}
+	if _, err := down.Column("NAME"); err == nil {
+		t.Fatal("expected the STRING columns to be dropped")
+	}
+
+	up, err := fr.Resample("unix_ts", 500*time.Millisecond, AggFunc_Mean, ResampleFill_Linear)
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, err = up.Column("VmRSSBytes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col.DataType() != FLOAT64 || col.NullCount() != 0 {
+		t.Fatalf("expected FLOAT64 without nulls, got %s with %d nulls", col.DataType(), col.NullCount())
+	}
+	bc, err := up.Column("unix_ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bt, ok := bc.Times(LayoutUnixSeconds)
+	if !ok || bt[1].Sub(bt[0]) != 500*time.Millisecond || !bt[0].Equal(times[0]) {
+		t.Fatalf("unexpected buckets %v", bt[:2])
+	}
+}
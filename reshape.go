@@ -0,0 +1,214 @@
+package dataframe
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Melt returns a new Frame in the long format, unpivoting the valueVars
+// columns into rows of two columns: varName for the header and valueName
+// for the Value. The idVars columns are repeated for each of the valueVars,
+// whose rows are in order. If valueVars is empty, they are the columns
+// other than idVars. If empty, varName is "variable" and valueName is
+// "value". The value column is of the data type of the valueVars, or
+// STRING if they are of different data types.
+func (f *frame) Melt(idVars, valueVars []string, varName, valueName string) (Frame, error) {
+	if varName == "" {
+		varName = "variable"
+	}
+	if valueName == "" {
+		valueName = "value"
+	}
+	ids, err := joinKeys(f, idVars)
+	if err != nil {
+		return nil, err
+	}
+	if len(valueVars) == 0 {
+		isID := make(map[string]bool, len(idVars))
+		for _, h := range idVars {
+			isID[h] = true
+		}
+		for _, h := range f.Headers() {
+			if !isID[h] {
+				valueVars = append(valueVars, h)
+			}
+		}
+	}
+	vals, err := joinKeys(f, valueVars)
+	if err != nil {
+		return nil, err
+	}
+	if len(vals) == 0 {
+		return nil, fmt.Errorf("no columns to melt")
+	}
+
+	n := rowCount(f.Columns())
+	rows := make([]int, 0, n*len(vals))
+	for range vals {
+		for row := 0; row < n; row++ {
+			rows = append(rows, row)
+		}
+	}
+	fr := New()
+	for _, col := range ids {
+		if err := fr.AddColumn(takeColumn(col, rows)); err != nil {
+			return nil, err
+		}
+	}
+
+	vfd := vals[0].Field()
+	vfd.Name = valueName
+	for _, col := range vals[1:] {
+		if fd := col.Field(); fd.Type != vfd.Type || fd.Layout != vfd.Layout {
+			vfd.Type, vfd.Layout = STRING, ""
+		}
+	}
+	vs := make([]Value, 0, len(rows))
+	for _, col := range vals {
+		if col.Field().Nullable {
+			vfd.Nullable = true
+		}
+		for row := 0; row < n; row++ {
+			v := valueAt(col, row)
+			if vfd.Type == STRING && !v.IsNull() {
+				s, _ := v.String()
+				v = NewStringValue(s)
+			}
+			if v.IsNull() {
+				vfd.Nullable = true
+			}
+			vs = append(vs, v)
+		}
+	}
+	varCol := NewColumnField(Field{Name: varName, Type: STRING})
+	valCol := NewColumnField(vfd)
+	for i, v := range vs {
+		varCol.PushBack(NewStringValue(valueVars[i/n]))
+		valCol.PushBack(v)
+	}
+	for _, col := range []Column{varCol, valCol} {
+		if err := fr.AddColumn(col); err != nil {
+			return nil, err
+		}
+	}
+	return fr, nil
+}
+
+// PivotOptions configures Pivot.
+type PivotOptions struct {
+	// Header is the header template of the pivoted columns, where
+	// "{values}" is replaced with the header of the values column, and
+	// "{column}" with the value of the columns column. If empty, it is
+	// "{values}_{column}", such as "avg_latency_ms_etcd3".
+	Header string
+
+	// Fill is the Value of the cells without any row. If nil, it is null.
+	Fill Value
+}
+
+// Pivot returns a new Frame in the wide format, with one row for each
+// value of the index column, and one column for each value of the columns
+// column and each of the values columns, in the order of first seen. Each
+// cell aggregates the values of the rows with the index and the column.
+// The rows with null in the columns column are skipped.
+func (f *frame) Pivot(index, columns string, values []string, agg AggFunc, opts PivotOptions) (Frame, error) {
+	if opts.Header == "" {
+		opts.Header = "{values}_{column}"
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("no values to pivot")
+	}
+	g, err := f.GroupBy(index)
+	if err != nil {
+		return nil, err
+	}
+	groups := g.(*grouped).groups
+	colCol, err := f.Column(columns)
+	if err != nil {
+		return nil, err
+	}
+	vals, err := joinKeys(f, values)
+	if err != nil {
+		return nil, err
+	}
+
+	// the column index of each row, in the order of first seen
+	colFd := colCol.Field()
+	colIdx := make(map[string]int)
+	var colNames []string
+	rowCol := make([]int, rowCount(f.Columns()))
+	for row := range rowCol {
+		rowCol[row] = -1
+		v := valueAt(colCol, row)
+		if v.IsNull() {
+			continue
+		}
+		k := groupKey(colFd, v)
+		i, ok := colIdx[k]
+		if !ok {
+			i = len(colNames)
+			colIdx[k] = i
+			s, _ := v.String()
+			colNames = append(colNames, s)
+		}
+		rowCol[row] = i
+	}
+
+	// the rows of each cell of the group and the column
+	cells := make([][][]int, len(groups))
+	for gi, rows := range groups {
+		cells[gi] = make([][]int, len(colNames))
+		for _, row := range rows {
+			if c := rowCol[row]; c >= 0 {
+				cells[gi][c] = append(cells[gi][c], row)
+			}
+		}
+	}
+
+	fr := New()
+	idxCol := g.(*grouped).keys[0]
+	kc := NewColumnField(idxCol.Field())
+	for _, rows := range groups {
+		kc.PushBack(valueAt(idxCol, rows[0]))
+	}
+	if err := fr.AddColumn(kc); err != nil {
+		return nil, err
+	}
+	for c, name := range colNames {
+		for vi, col := range vals {
+			a := Agg{Column: values[vi], Func: agg}
+			fd := col.Field()
+			out, err := a.field(fd)
+			if err != nil {
+				return nil, err
+			}
+			out.Name = strings.NewReplacer("{values}", values[vi], "{column}", name).Replace(opts.Header)
+			fill := NewNullValue()
+			if opts.Fill != nil && !opts.Fill.IsNull() {
+				if fill, err = typedValue(out, -1, opts.Fill); err != nil {
+					return nil, err
+				}
+			}
+			pc := NewColumnField(out)
+			var vs []Value
+			for gi := range groups {
+				rows := cells[gi][c]
+				if len(rows) == 0 {
+					pc.PushBack(fill)
+					continue
+				}
+				vs = vs[:0]
+				for _, row := range rows {
+					if v := valueAt(col, row); !v.IsNull() {
+						vs = append(vs, v)
+					}
+				}
+				pc.PushBack(a.aggregate(fd, vs))
+			}
+			if err := fr.AddColumn(pc); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return fr, nil
+}
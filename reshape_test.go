@@ -0,0 +1,121 @@
+package dataframe
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPivotMelt(t *testing.T) {
+	schema := Schema{Fields: []Field{
+		{Name: "second", Type: INT64},
+		{Name: "system", Type: STRING, Nullable: true},
+		{Name: "avg_latency_ms", Type: FLOAT64},
+		{Name: "throughput", Type: INT64},
+	}}
+	long, err := NewFromRowsTyped(schema.Headers(), [][]string{
+		{"0", "etcd3", "4.5", "64"},
+		{"0", "zk", "4.7", "186"},
+		{"1", "etcd3", "4.2", "232"},
+		{"1", "etcd3", "4.4", "240"},
+		{"2", "zk", "4.1", "242"},
+	}, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the rows without the system are skipped
+	if err := long.AppendRow(map[string]interface{}{"second": 1, "avg_latency_ms": 9.9, "throughput": 1}); err != nil {
+		t.Fatal(err)
+	}
+
+	wide, err := long.Pivot("second", "system", []string{"avg_latency_ms", "throughput"}, AggFunc_Max, PivotOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedHeaders := []string{"second", "avg_latency_ms_etcd3", "throughput_etcd3", "avg_latency_ms_zk", "throughput_zk"}
+	expected := [][]string{
+		{"0", "4.5", "64", "4.7", "186"},
+		{"1", "4.4", "240", "", ""},
+		{"2", "", "", "4.1", "242"},
+	}
+	hd, rows := wide.Rows()
+	if !reflect.DeepEqual(hd, expectedHeaders) {
+		t.Fatalf("expected %v, got %v", expectedHeaders, hd)
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("expected %v, got %v", expected, rows)
+	}
+
+	counts, err := long.Pivot("second", "system", []string{"throughput"}, AggFunc_Count, PivotOptions{
+		Header: "{column}:{values}",
+		Fill:   Int64(0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = [][]string{{"0", "1", "1"}, {"1", "2", "0"}, {"2", "0", "1"}}
+	hd, rows = counts.Rows()
+	if expectedHeaders := []string{"second", "etcd3:throughput", "zk:throughput"}; !reflect.DeepEqual(hd, expectedHeaders) {
+		t.Fatalf("expected %v, got %v", expectedHeaders, hd)
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("expected %v, got %v", expected, rows)
+	}
+	if _, err := long.Pivot("second", "system", []string{"system"}, AggFunc_Mean, PivotOptions{}); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := long.Pivot("second", "system", []string{"throughput"}, AggFunc_Sum, PivotOptions{Fill: NewStringValue("none")}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	melted, err := wide.Melt([]string{"second"}, []string{"avg_latency_ms_etcd3", "avg_latency_ms_zk"}, "system", "avg_latency_ms")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = [][]string{
+		{"0", "avg_latency_ms_etcd3", "4.5"},
+		{"1", "avg_latency_ms_etcd3", "4.4"},
+		{"2", "avg_latency_ms_etcd3", ""},
+		{"0", "avg_latency_ms_zk", "4.7"},
+		{"1", "avg_latency_ms_zk", ""},
+		{"2", "avg_latency_ms_zk", "4.1"},
+	}
+	if _, rows := melted.Rows(); !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("expected %v, got %v", expected, rows)
+	}
+	if fd, _ := melted.Schema().Field("avg_latency_ms"); fd.Type != FLOAT64 || !fd.Nullable {
+		t.Fatalf("expected nullable FLOAT64, got %v", fd)
+	}
+}
+
+func TestMeltAggregated(t *testing.T) {
+	fr, _, err := NewFromCSVInfer(nil, "testdata/bench-01-all-aggregated.csv", InferOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	long, err := fr.Melt([]string{"second"}, nil, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hd, expected := long.Headers(), []string{"second", "variable", "value"}; !reflect.DeepEqual(hd, expected) {
+		t.Fatalf("expected %v, got %v", expected, hd)
+	}
+	n := rowCount(fr.Columns())
+	col, err := long.Column("value")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col.Count() != n*(fr.Count()-1) {
+		t.Fatalf("expected %d rows, got %d", n*(fr.Count()-1), col.Count())
+	}
+	if col.DataType() != STRING {
+		t.Fatalf("expected STRING for the mixed data types, got %s", col.DataType())
+	}
+
+	r, err := long.Row(5*n + 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if vs := r.Values(); !vs[0].EqualTo(Int64(1)) || !vs[1].EqualTo(NewStringValue("avg_latency_ms_etcd3")) || !vs[2].EqualTo(NewStringValue("4.298409")) {
+		t.Fatalf("unexpected row %v", vs)
+	}
+}
@@ -0,0 +1,411 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Rolling aggregates the values in the window of each row of a Column,
+// into a new nullable FLOAT64 Column of the same number of rows. Null
+// values are skipped, and the rows with fewer values in the window than
+// the minimum periods are null.
+type Rolling interface {
+	// Center returns the Rolling with the windows centered at the rows.
+	// By default, the windows trail the rows.
+	Center() Rolling
+
+	Sum() (Column, error)
+	Mean() (Column, error)
+	Min() (Column, error)
+	Max() (Column, error)
+
+	// Std returns the sample standard deviations.
+	Std() (Column, error)
+
+	Median() (Column, error)
+
+	// Quantile returns the q-quantiles, linearly interpolated
+	// between the closest values.
+	Quantile(q float64) (Column, error)
+}
+
+type rolling struct {
+	header string
+	vals   []float64
+	valid  []bool
+
+	// window is the number of rows in the window, if times is nil.
+	window int
+
+	// times are the times of the rows in Unix nanoseconds,
+	// and span is the duration of the window.
+	times []int64
+	span  int64
+
+	minPeriods int
+	center     bool
+}
+
+// newRolling returns the rolling of the numeric values of the Column.
+func newRolling(c Column, minPeriods int) (*rolling, error) {
+	fd := c.Field()
+	switch fd.Type {
+	case INT64, UINT64, FLOAT64, DURATION:
+	default:
+		return nil, fmt.Errorf("%q of %s is not numeric", fd.Name, fd.Type)
+	}
+	if minPeriods < 0 {
+		return nil, fmt.Errorf("min periods %d is negative", minPeriods)
+	}
+	n := c.Count()
+	r := &rolling{
+		header:     fd.Name,
+		vals:       make([]float64, n),
+		valid:      make([]bool, n),
+		minPeriods: minPeriods,
+	}
+	for row := range r.vals {
+		if v := valueAt(c, row); !v.IsNull() {
+			r.vals[row] = valueFloat64(fd.Type, v)
+			r.valid[row] = !math.IsNaN(r.vals[row])
+		}
+	}
+	return r, nil
+}
+
+func (c *column) Rolling(window, minPeriods int) (Rolling, error) {
+	if window < 1 {
+		return nil, fmt.Errorf("window %d must be positive", window)
+	}
+	r, err := newRolling(c, minPeriods)
+	if err != nil {
+		return nil, err
+	}
+	if r.minPeriods == 0 {
+		r.minPeriods = window
+	}
+	if r.minPeriods > window {
+		return nil, fmt.Errorf("min periods %d is larger than window %d", r.minPeriods, window)
+	}
+	r.window = window
+	return r, nil
+}
+
+func (c *column) RollingTime(on Column, window time.Duration, minPeriods int) (Rolling, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("window %v must be positive", window)
+	}
+	r, err := newRolling(c, minPeriods)
+	if err != nil {
+		return nil, err
+	}
+	if r.minPeriods == 0 {
+		r.minPeriods = 1
+	}
+	if r.times, err = sortedTimes(on, len(r.vals)); err != nil {
+		return nil, err
+	}
+	r.span = int64(window)
+	return r, nil
+}
+
+func (r *rolling) Center() Rolling {
+	nr := *r
+	nr.center = true
+	return &nr
+}
+
+// sortedTimes returns the n times of the Column of TIME or INT64 Unix
+// seconds in Unix nanoseconds, which must be non-null and in ascending order.
+func sortedTimes(on Column, n int) ([]int64, error) {
+	fd := on.Field()
+	if fd.Type != TIME && fd.Type != INT64 {
+		return nil, fmt.Errorf("%q of %s is not TIME or INT64 Unix seconds", fd.Name, fd.Type)
+	}
+	if c := on.Count(); c != n {
+		return nil, fmt.Errorf("%q has %d rows, expected %d", fd.Name, c, n)
+	}
+	times := make([]int64, n)
+	for row := range times {
+		v := valueAt(on, row)
+		ok := false
+		if fd.Type == TIME {
+			var t time.Time
+			if t, ok = v.Time(fd.Layout); ok {
+				times[row] = t.UnixNano()
+			}
+		} else {
+			var sec int64
+			sec, ok = v.Int64()
+			times[row] = sec * int64(time.Second)
+		}
+		if !ok {
+			return nil, fmt.Errorf("%q has no time in row %d", fd.Name, row)
+		}
+		if row > 0 && times[row] < times[row-1] {
+			return nil, fmt.Errorf("%q is not sorted in row %d", fd.Name, row)
+		}
+	}
+	return times, nil
+}
+
+// bounds returns the rows [lo, hi) of the window of each row. Both are
+// non-decreasing, so that the rows enter and leave the window once.
+// The time windows trail in (t-span, t], or center in (t-span/2, t+span/2].
+func (r *rolling) bounds() (lo, hi []int) {
+	n := len(r.vals)
+	lo, hi = make([]int, n), make([]int, n)
+	if r.times == nil {
+		before, after := r.window-1, 0
+		if r.center {
+			before, after = r.window/2, (r.window-1)/2
+		}
+		for i := range lo {
+			lo[i], hi[i] = max(i-before, 0), min(i+after+1, n)
+		}
+		return lo, hi
+	}
+
+	before, after := r.span, int64(0)
+	if r.center {
+		before, after = r.span/2, r.span/2
+	}
+	l, h := 0, 0
+	for i, t := range r.times {
+		for l < n && r.times[l] <= t-before {
+			l++
+		}
+		for h < n && r.times[h] <= t+after {
+			h++
+		}
+		lo[i], hi[i] = l, h
+	}
+	return lo, hi
+}
+
+// windowAgg aggregates the values in a window, as the rows
+// enter and leave the window in order.
+type windowAgg interface {
+	add(row int, x float64)
+	remove(row int, x float64)
+
+	// value returns the aggregate of the n values in the window,
+	// which is not empty, or false if it is undefined.
+	value(n int) (float64, bool)
+}
+
+// aggregate slides the window over the rows.
+func (r *rolling) aggregate(name string, agg windowAgg) Column {
+	lo, hi := r.bounds()
+	col := NewColumnField(Field{Name: fmt.Sprintf("%s_rolling_%s", r.header, name), Type: FLOAT64, Nullable: true})
+	l, h, n := 0, 0, 0
+	for i := range r.vals {
+		for ; h < hi[i]; h++ {
+			if r.valid[h] {
+				agg.add(h, r.vals[h])
+				n++
+			}
+		}
+		for ; l < lo[i]; l++ {
+			if r.valid[l] {
+				agg.remove(l, r.vals[l])
+				n--
+			}
+		}
+		if n == 0 || n < r.minPeriods {
+			col.PushBack(NewNullValue())
+			continue
+		}
+		if v, ok := agg.value(n); ok {
+			col.PushBack(Float64(v))
+		} else {
+			col.PushBack(NewNullValue())
+		}
+	}
+	return col
+}
+
+// sumAgg keeps the running sum, to aggregate in O(n).
+type sumAgg struct {
+	n    int
+	sum  float64
+	mean bool
+
+	// posInf and negInf count the infinite values, which are not
+	// in sum, so that they do not poison sum after leaving the window
+	posInf, negInf int
+}
+
+func (a *sumAgg) add(_ int, x float64) {
+	a.n++
+	switch {
+	case math.IsInf(x, 1):
+		a.posInf++
+	case math.IsInf(x, -1):
+		a.negInf++
+	default:
+		a.sum += x
+	}
+}
+
+func (a *sumAgg) remove(_ int, x float64) {
+	a.n--
+	switch {
+	case math.IsInf(x, 1):
+		a.posInf--
+	case math.IsInf(x, -1):
+		a.negInf--
+	default:
+		a.sum -= x
+	}
+	if a.n == 0 {
+		// reset the rounding errors
+		a.sum = 0
+	}
+}
+
+func (a *sumAgg) value(n int) (float64, bool) {
+	sum := a.sum
+	switch {
+	case a.posInf > 0 && a.negInf > 0:
+		sum = math.NaN()
+	case a.posInf > 0:
+		sum = math.Inf(1)
+	case a.negInf > 0:
+		sum = math.Inf(-1)
+	}
+	if a.mean {
+		return sum / float64(n), true
+	}
+	return sum, true
+}
+
+// extremeAgg keeps the rows in a monotonic deque, whose
+// front is the minimum or the maximum of the window.
+type extremeAgg struct {
+	rows []int
+	vals []float64
+	max  bool
+}
+
+func (a *extremeAgg) add(row int, x float64) {
+	for len(a.rows) > 0 {
+		back := a.vals[a.rows[len(a.rows)-1]]
+		if (a.max && back > x) || (!a.max && back < x) {
+			break
+		}
+		a.rows = a.rows[:len(a.rows)-1]
+	}
+	a.rows = append(a.rows, row)
+}
+
+func (a *extremeAgg) remove(row int, _ float64) {
+	if len(a.rows) > 0 && a.rows[0] == row {
+		a.rows = a.rows[1:]
+	}
+}
+
+func (a *extremeAgg) value(n int) (float64, bool) {
+	return a.vals[a.rows[0]], true
+}
+
+// stdAgg keeps the running mean and the sum of the squared
+// differences in Welford's algorithm.
+type stdAgg struct {
+	n        int
+	mean, m2 float64
+
+	// inf counts the infinite values, which are not in mean and m2
+	inf int
+}
+
+func (a *stdAgg) add(_ int, x float64) {
+	if math.IsInf(x, 0) {
+		a.inf++
+		return
+	}
+	a.n++
+	d := x - a.mean
+	a.mean += d / float64(a.n)
+	a.m2 += d * (x - a.mean)
+}
+
+func (a *stdAgg) remove(_ int, x float64) {
+	if math.IsInf(x, 0) {
+		a.inf--
+		return
+	}
+	a.n--
+	if a.n == 0 {
+		a.mean, a.m2 = 0, 0
+		return
+	}
+	d := x - a.mean
+	a.mean -= d / float64(a.n)
+	a.m2 -= d * (x - a.mean)
+}
+
+func (a *stdAgg) value(n int) (float64, bool) {
+	if n < 2 {
+		return 0, false
+	}
+	if a.inf > 0 {
+		return math.NaN(), true
+	}
+	return math.Sqrt(max(a.m2, 0) / float64(n-1)), true
+}
+
+// quantileAgg keeps the values of the window sorted.
+type quantileAgg struct {
+	sorted []float64
+	q      float64
+}
+
+func (a *quantileAgg) add(_ int, x float64) {
+	i := sort.SearchFloat64s(a.sorted, x)
+	a.sorted = append(a.sorted, 0)
+	copy(a.sorted[i+1:], a.sorted[i:])
+	a.sorted[i] = x
+}
+
+func (a *quantileAgg) remove(_ int, x float64) {
+	i := sort.SearchFloat64s(a.sorted, x)
+	a.sorted = append(a.sorted[:i], a.sorted[i+1:]...)
+}
+
+func (a *quantileAgg) value(n int) (float64, bool) {
+	return sortedQuantile(a.sorted, a.q), true
+}
+
+func (r *rolling) Sum() (Column, error) {
+	return r.aggregate("sum", &sumAgg{}), nil
+}
+
+func (r *rolling) Mean() (Column, error) {
+	return r.aggregate("mean", &sumAgg{mean: true}), nil
+}
+
+func (r *rolling) Min() (Column, error) {
+	return r.aggregate("min", &extremeAgg{vals: r.vals}), nil
+}
+
+func (r *rolling) Max() (Column, error) {
+	return r.aggregate("max", &extremeAgg{vals: r.vals, max: true}), nil
+}
+
+func (r *rolling) Std() (Column, error) {
+	return r.aggregate("std", &stdAgg{}), nil
+}
+
+func (r *rolling) Median() (Column, error) {
+	return r.aggregate("median", &quantileAgg{q: 0.5}), nil
+}
+
+func (r *rolling) Quantile(q float64) (Column, error) {
+	if !(q >= 0 && q <= 1) {
+		return nil, fmt.Errorf("quantile %v is out of range [0, 1]", q)
+	}
+	return r.aggregate(fmt.Sprintf("q%g", q), &quantileAgg{q: q}), nil
+}
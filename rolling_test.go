@@ -0,0 +1,188 @@
+package dataframe
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRolling(t *testing.T) {
+	col := NewColumnTyped("latency", FLOAT64)
+	for _, v := range []Value{Float64(1), Float64(3), NewNullValue(), Float64(2), Float64(8), Float64(4)} {
+		col.PushBack(v)
+	}
+
+	tests := []struct {
+		center     bool
+		window     int
+		minPeriods int
+		agg        func(r Rolling) (Column, error)
+		expected   []string
+	}{
+		{false, 3, 0, Rolling.Sum, []string{"", "", "", "", "", "14"}},
+		{false, 3, 1, Rolling.Sum, []string{"1", "4", "4", "5", "10", "14"}},
+		{false, 3, 2, Rolling.Mean, []string{"", "2", "2", "2.5", "5", "4.666666666666667"}},
+		{false, 3, 1, Rolling.Min, []string{"1", "1", "1", "2", "2", "2"}},
+		{false, 3, 1, Rolling.Max, []string{"1", "3", "3", "3", "8", "8"}},
+		{true, 3, 1, Rolling.Max, []string{"3", "3", "3", "8", "8", "8"}},
+		{true, 4, 1, Rolling.Min, []string{"1", "1", "1", "2", "2", "2"}},
+		{false, 2, 1, Rolling.Std, []string{"", "1.4142135623730951", "", "", "4.242640687119285", "2.8284271247461903"}},
+		{false, 3, 1, Rolling.Median, []string{"1", "2", "2", "2.5", "5", "4"}},
+		{false, 3, 1, func(r Rolling) (Column, error) { return r.Quantile(0.25) }, []string{"1", "1.5", "1.5", "2.25", "3.5", "3"}},
+	}
+	for i, tt := range tests {
+		r, err := col.Rolling(tt.window, tt.minPeriods)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tt.center {
+			r = r.Center()
+		}
+		got, err := tt.agg(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rows := got.Rows(); !reflect.DeepEqual(rows, tt.expected) {
+			t.Fatalf("#%d: expected %v, got %v", i, tt.expected, rows)
+		}
+	}
+
+	if _, err := col.Rolling(0, 0); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := col.Rolling(2, 3); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := NewColumn("host").Rolling(2, 1); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestRollingInf(t *testing.T) {
+	col := NewColumnTyped("latency", FLOAT64)
+	for _, fv := range []float64{1, math.Inf(1), 1, 1, 1, 1} {
+		col.PushBack(Float64(fv))
+	}
+	r, err := col.Rolling(2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		agg      func(r Rolling) (Column, error)
+		expected []string
+	}{
+		{Rolling.Sum, []string{"", "+Inf", "+Inf", "2", "2", "2"}},
+		{Rolling.Mean, []string{"", "+Inf", "+Inf", "1", "1", "1"}},
+		{Rolling.Std, []string{"", "NaN", "NaN", "0", "0", "0"}},
+	} {
+		got, err := tt.agg(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rows := got.Rows(); !reflect.DeepEqual(rows, tt.expected) {
+			t.Fatalf("expected %v, got %v", tt.expected, rows)
+		}
+	}
+}
+
+func TestRollingNaive(t *testing.T) {
+	const n, window = 500, 17
+	col := NewColumnTyped("v", FLOAT64)
+	rd := rand.New(rand.NewSource(1))
+	for i := 0; i < n; i++ {
+		if rd.Intn(10) == 0 {
+			col.PushBack(NewNullValue())
+		} else {
+			col.PushBack(Float64(float64(rd.Intn(100))))
+		}
+	}
+	vs, mask := col.Float64sMask()
+	r, err := col.Rolling(window, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aggs := []struct {
+		agg func() (Column, error)
+		fn  AggFunc
+	}{
+		{r.Sum, AggFunc_Sum},
+		{r.Mean, AggFunc_Mean},
+		{r.Min, AggFunc_Min},
+		{r.Max, AggFunc_Max},
+		{r.Std, AggFunc_Std},
+		{r.Median, AggFunc_Median},
+	}
+	fd := col.Field()
+	for _, a := range aggs {
+		got, err := a.agg()
+		if err != nil {
+			t.Fatal(err)
+		}
+		fs, gotMask := got.Float64sMask()
+		for i := 0; i < n; i++ {
+			var win []Value
+			for j := max(i-window+1, 0); j <= i; j++ {
+				if mask[j] {
+					win = append(win, Float64(vs[j]))
+				}
+			}
+			expected := Agg{Func: a.fn}.aggregate(fd, win)
+			if expected.IsNull() {
+				if gotMask[i] {
+					t.Fatalf("%v row %d: expected null, got %v", a.fn, i, fs[i])
+				}
+				continue
+			}
+			ev, _ := expected.Float64()
+			if !gotMask[i] || math.Abs(ev-fs[i]) > 1e-9 {
+				t.Fatalf("%v row %d: expected %v, got %v", a.fn, i, ev, fs[i])
+			}
+		}
+	}
+}
+
+func TestRollingTime(t *testing.T) {
+	fr, _, err := NewFromCSVInfer(nil, "testdata/bench-01-etcd-timeseries.csv", InferOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ts, err := fr.Column("unix_ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	latency, err := fr.Column("avg_latency_ms")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := latency.RollingTime(ts, 10*time.Second, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := r.Quantile(0.99)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Header() != "avg_latency_ms_rolling_q0.99" || got.Count() != latency.Count() || got.NullCount() != 0 {
+		t.Fatalf("unexpected %q of %d rows with %d nulls", got.Header(), got.Count(), got.NullCount())
+	}
+
+	// the rows are one second apart, so that the time windows
+	// are the same as the windows of 10 rows
+	rr, err := latency.Rolling(10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := rr.Quantile(0.99)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Rows(), expected.Rows()) {
+		t.Fatalf("expected %v, got %v", expected.Rows(), got.Rows())
+	}
+
+	if _, err := latency.RollingTime(latency, time.Second, 0); err == nil {
+		t.Fatal("expected error")
+	}
+}
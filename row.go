@@ -0,0 +1,249 @@
+package dataframe
+
+import (
+	"fmt"
+	"iter"
+)
+
+// Row is a row of a Frame, to read its Values by header or position.
+// It reads a snapshot of the Columns, which does not change with
+// the writes to the Frame.
+type Row struct {
+	index int
+	set   *rowSet
+}
+
+// rowSet is the snapshot of the Columns of a Frame,
+// which is read by Rows without the locks of the Columns.
+type rowSet struct {
+	headers  []string
+	headerTo map[string]int
+	data     []columnData
+
+	// n is the number of rows of the longest Column.
+	n int
+}
+
+// snapshot returns the rowSet of the Columns.
+func (f *frame) snapshot() *rowSet {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	rs := &rowSet{
+		headers:  make([]string, len(f.columns)),
+		headerTo: make(map[string]int, len(f.headerTo)),
+		data:     make([]columnData, len(f.columns)),
+	}
+	for k, v := range f.headerTo {
+		rs.headers[v] = k
+		rs.headerTo[k] = v
+	}
+	for i, col := range f.columns {
+		if c, ok := col.(*column); ok {
+			c.mu.Lock()
+			rs.data[i] = c.data.Snapshot()
+			c.mu.Unlock()
+		} else {
+			d := newColumnData(col.DataType())
+			for row, n := 0, col.Count(); row < n; row++ {
+				d.Append(valueAt(col, row))
+			}
+			rs.data[i] = d
+		}
+		if n := rs.data[i].Len(); rs.n < n {
+			rs.n = n
+		}
+	}
+	return rs
+}
+
+// Index returns the index of the row in the Frame.
+func (r Row) Index() int {
+	return r.index
+}
+
+// Len returns the number of Values, one for each Column.
+func (r Row) Len() int {
+	return len(r.set.data)
+}
+
+// Headers returns the headers of the Values in order.
+func (r Row) Headers() []string {
+	headers := make([]string, len(r.set.headers))
+	copy(headers, r.set.headers)
+	return headers
+}
+
+// Value returns the Value of the Column in the row. It returns
+// null if the Column is shorter than the row.
+func (r Row) Value(header string) (Value, error) {
+	i, ok := r.set.headerTo[header]
+	if !ok {
+		return nil, fmt.Errorf("%q does not exist", header)
+	}
+	return r.value(i), nil
+}
+
+// ValueAt returns the Value of the Column at the position.
+func (r Row) ValueAt(i int) (Value, error) {
+	if i < 0 || i >= len(r.set.data) {
+		return nil, fmt.Errorf("column %d is out of range [0, %d)", i, len(r.set.data))
+	}
+	return r.value(i), nil
+}
+
+// Values returns the Values in the order of Headers.
+func (r Row) Values() []Value {
+	vs := make([]Value, len(r.set.data))
+	for i := range vs {
+		vs[i] = r.value(i)
+	}
+	return vs
+}
+
+func (r Row) value(i int) Value {
+	d := r.set.data[i]
+	if r.index >= d.Len() {
+		return NewNullValue()
+	}
+	return d.Value(r.index)
+}
+
+// Row returns the row of the index.
+func (f *frame) Row(i int) (Row, error) {
+	rs := f.snapshot()
+	if i < 0 || i >= rs.n {
+		return Row{}, fmt.Errorf("row %d is out of range [0, %d)", i, rs.n)
+	}
+	return Row{index: i, set: rs}, nil
+}
+
+// IterRows returns the iterator of the rows with their indexes,
+// as of the call to the iterator.
+func (f *frame) IterRows() iter.Seq2[int, Row] {
+	return func(yield func(int, Row) bool) {
+		rs := f.snapshot()
+		for i := 0; i < rs.n; i++ {
+			if !yield(i, Row{index: i, set: rs}) {
+				return
+			}
+		}
+	}
+}
+
+// typedValue converts v to the Value of the Field in the row,
+// checking the data type and the nullability. v is a Value,
+// or a Go value as in PushBackTyped.
+func typedValue(fd Field, row int, v interface{}) (Value, error) {
+	tv, ok := v.(Value)
+	if !ok {
+		return goTypedValue(fd, row, v)
+	}
+	if tv == nil || tv.IsNull() {
+		if !fd.Nullable {
+			return nil, &SchemaError{Row: row, Column: fd.Name, Err: ErrNotNullable}
+		}
+		return NewNullValue(), nil
+	}
+	if !isFieldType(fd, tv) {
+		s, _ := tv.String()
+		return nil, &SchemaError{
+			Row:    row,
+			Column: fd.Name,
+			Value:  s,
+			Err:    fmt.Errorf("%w (expected %q)", ErrTypeMismatch, fd.Type),
+		}
+	}
+	return tv, nil
+}
+
+// isFieldType returns true if the Value converts to the data type
+// of the Field. TIME Values must parse in the layout of the Field,
+// and STRING Values must be strings.
+func isFieldType(fd Field, v Value) bool {
+	var ok bool
+	switch fd.Type {
+	case INT64:
+		_, ok = v.Int64()
+	case UINT64:
+		_, ok = v.Uint64()
+	case FLOAT64:
+		_, ok = v.Float64()
+	case BOOL:
+		_, ok = v.Bool()
+	case DURATION:
+		_, ok = v.Duration()
+	case TIME:
+		_, ok = v.Time(fd.Layout)
+	default:
+		_, ok = v.(String)
+	}
+	return ok
+}
+
+// appendRow appends the row of the Values by header, or of the Values
+// in the order of the Columns if named is nil, after the Columns are
+// padded with nulls to the same length. The Values are checked before
+// any of them is appended.
+func (f *frame) appendRow(named map[string]interface{}, values []Value) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	n := rowCount(f.columns)
+	if named != nil {
+		for header := range named {
+			if _, ok := f.headerTo[header]; !ok {
+				return &SchemaError{Row: n, Column: header, Err: ErrFieldNotFound}
+			}
+		}
+	} else if len(values) != len(f.columns) {
+		return fmt.Errorf("row %d has %d values, expected %d", n, len(values), len(f.columns))
+	}
+	headers := make([]string, len(f.columns))
+	for k, v := range f.headerTo {
+		headers[v] = k
+	}
+
+	vs := make([]Value, len(f.columns))
+	for i, col := range f.columns {
+		fd := col.Field()
+		if !fd.Nullable && col.Count() < n {
+			return &SchemaError{Row: col.Count(), Column: headers[i], Err: ErrNotNullable}
+		}
+		var v interface{}
+		if named != nil {
+			v = named[headers[i]]
+		} else if values[i] != nil {
+			v = values[i]
+		}
+		fd.Name = headers[i]
+		var err error
+		if vs[i], err = typedValue(fd, n, v); err != nil {
+			return err
+		}
+	}
+	for i, col := range f.columns {
+		if err := col.Appends(NewNullValue(), n); err != nil {
+			return err
+		}
+		col.PushBack(vs[i])
+	}
+	return nil
+}
+
+// AppendRow appends the row of the Values by header, which are Values
+// or Go values as in PushBackTyped. The headers that are not in the row
+// are null. It returns *SchemaError if a Value does not match its Column,
+// or the header does not exist.
+func (f *frame) AppendRow(row map[string]interface{}) error {
+	if row == nil {
+		row = map[string]interface{}{}
+	}
+	return f.appendRow(row, nil)
+}
+
+// AppendRowValues appends the row of the Values in the order of the
+// Columns. It returns *SchemaError if a Value does not match its Column.
+func (f *frame) AppendRowValues(row []Value) error {
+	return f.appendRow(nil, row)
+}
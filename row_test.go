@@ -0,0 +1,153 @@
+package dataframe
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRow(t *testing.T) {
+	schema := Schema{Fields: []Field{
+		{Name: "host", Type: STRING},
+		{Name: "cpu", Type: FLOAT64, Nullable: true},
+		{Name: "took", Type: DURATION},
+	}}
+	fr, err := NewFromRowsTyped(schema.Headers(), [][]string{
+		{"a", "0.5", "1s"},
+		{"b", "", "2s"},
+	}, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := fr.Row(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := r.Value("host"); err != nil || !v.EqualTo(NewStringValue("b")) {
+		t.Fatalf("expected b, got %v (%v)", v, err)
+	}
+	if v, err := r.ValueAt(1); err != nil || !v.IsNull() {
+		t.Fatalf("expected null, got %v (%v)", v, err)
+	}
+	if _, err := r.Value("mem"); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := r.ValueAt(3); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := fr.Row(2); err == nil {
+		t.Fatal("expected error")
+	}
+
+	if err := fr.AppendRow(map[string]interface{}{"host": "c", "took": 3 * time.Second}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fr.AppendRowValues([]Value{NewStringValue("d"), Float64(1.5), GoDuration(4 * time.Second)}); err != nil {
+		t.Fatal(err)
+	}
+
+	var serr *SchemaError
+	if err := fr.AppendRow(map[string]interface{}{"host": "e", "took": "5s"}); !errors.As(err, &serr) || !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("expected ErrTypeMismatch, got %v", err)
+	}
+	if err := fr.AppendRow(map[string]interface{}{"host": "e"}); !errors.Is(err, ErrNotNullable) {
+		t.Fatalf("expected ErrNotNullable, got %v", err)
+	}
+	if err := fr.AppendRow(map[string]interface{}{"mem": 1}); !errors.Is(err, ErrFieldNotFound) {
+		t.Fatalf("expected ErrFieldNotFound, got %v", err)
+	}
+	if err := fr.AppendRowValues([]Value{NewStringValue("e"), NewStringValue("x"), GoDuration(0)}); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("expected ErrTypeMismatch, got %v", err)
+	}
+	if err := fr.AppendRowValues([]Value{NewStringValue("e")}); err == nil {
+		t.Fatal("expected error")
+	}
+
+	var hosts []string
+	var took time.Duration
+	for i, r := range fr.IterRows() {
+		if r.Index() != i {
+			t.Fatalf("expected index %d, got %d", i, r.Index())
+		}
+		if i == 0 {
+			// the iteration does not see the appended rows
+			if err := fr.AppendRowValues([]Value{NewStringValue("e"), nil, GoDuration(0)}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		vs := r.Values()
+		s, _ := vs[0].String()
+		hosts = append(hosts, s)
+		d, _ := vs[2].Duration()
+		took += d
+	}
+	if expected := []string{"a", "b", "c", "d"}; !reflect.DeepEqual(hosts, expected) {
+		t.Fatalf("expected %v, got %v", expected, hosts)
+	}
+	if took != 10*time.Second {
+		t.Fatalf("expected 10s, got %v", took)
+	}
+	for _, col := range fr.Columns() {
+		if col.Count() != 5 {
+			t.Fatalf("%q: expected 5 rows, got %d", col.Header(), col.Count())
+		}
+	}
+}
+
+func TestAppendRowTypeMismatch(t *testing.T) {
+	schema := Schema{Fields: []Field{
+		{Name: "t", Type: TIME, Layout: time.RFC3339},
+		{Name: "s", Type: STRING, Nullable: true},
+	}}
+	fr, err := NewFromRowsTyped(schema.Headers(), [][]string{{"2016-03-23T18:30:00Z", "a"}}, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := GoTime(time.Unix(1458757864, 0).UTC())
+	for i, vs := range [][]Value{
+		{NewStringValue("garbage"), nil},
+		{Int64(5), nil},
+		{now, Int64(5)},
+		{now, now},
+	} {
+		var serr *SchemaError
+		if err := fr.AppendRowValues(vs); !errors.As(err, &serr) || !errors.Is(err, ErrTypeMismatch) {
+			t.Fatalf("#%d: expected ErrTypeMismatch, got %v", i, err)
+		}
+	}
+	var serr *SchemaError
+	if err := fr.AppendRow(map[string]interface{}{"t": Int64(5)}); !errors.As(err, &serr) || serr.Column != "t" || !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("expected ErrTypeMismatch of \"t\", got %v", err)
+	}
+	if err := fr.AppendRowValues([]Value{NewStringValue("2016-03-23T18:31:00Z"), nil}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fr.AppendRow(map[string]interface{}{"t": now, "s": NewStringValue("b")}); err != nil {
+		t.Fatal(err)
+	}
+	if n := fr.Columns()[0].Count(); n != 3 {
+		t.Fatalf("expected 3 rows, got %d", n)
+	}
+}
+
+func TestAppendRowPadding(t *testing.T) {
+	fr := New()
+	a, b := NewColumnTyped("a", INT64), NewColumnTyped("b", INT64)
+	a.PushBack(Int64(1))
+	a.PushBack(Int64(2))
+	b.PushBack(Int64(3))
+	for _, col := range []Column{a, b} {
+		if err := fr.AddColumn(col); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := fr.AppendRow(map[string]interface{}{"a": 4, "b": 5}); err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]string{{"1", "3"}, {"2", ""}, {"4", "5"}}
+	if _, rows := fr.Rows(); !reflect.DeepEqual(rows, expected) {
+		t.Fatalf("expected %v, got %v", expected, rows)
+	}
+}
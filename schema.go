@@ -0,0 +1,343 @@
+package dataframe
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// LayoutUnixSeconds is the time layout for integer seconds
+// since the Unix epoch, such as 'unix_ts' columns.
+const LayoutUnixSeconds = "unix"
+
+// DefaultTimeLayouts are tried in order when inferring TIME columns.
+var DefaultTimeLayouts = []string{
+	TimeDefaultLayout,
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+	"2006-01-02",
+	LayoutUnixSeconds,
+}
+
+// unix seconds are inferred only in this range [2000-01-01, 2100-01-01),
+// so that other integer columns are not mistaken for TIME.
+const (
+	minInferUnixSeconds = 946684800
+	maxInferUnixSeconds = 4102444800
+)
+
+// Field describes a column of Frame.
+type Field struct {
+	// Name is the header of the column.
+	Name string
+
+	// Type is the data type of the column.
+	Type DATA_TYPE
+
+	// Nullable is true if the column can have null Values.
+	Nullable bool
+
+	// Layout is the time layout of TIME column.
+	// Empty Layout uses TimeDefaultLayout.
+	Layout string
+}
+
+var (
+	// ErrTypeMismatch is returned when a value or a column
+	// does not match the data type of its Field.
+	ErrTypeMismatch = errors.New("data type mismatch")
+
+	// ErrNotNullable is returned when a null is found
+	// in a column that is not nullable.
+	ErrNotNullable = errors.New("null in non-nullable field")
+
+	// ErrFieldNotFound is returned when a column
+	// does not exist in the Schema.
+	ErrFieldNotFound = errors.New("field does not exist in schema")
+)
+
+// SchemaError describes a value that does not match the Schema.
+type SchemaError struct {
+	// Row is the index of the offending data row.
+	// It is -1 if the error is not specific to a row.
+	Row int
+
+	// Column is the header of the offending column.
+	Column string
+
+	// Value is the offending value in string.
+	Value string
+
+	// Err is the reason, such as ErrTypeMismatch or a parsing error.
+	Err error
+}
+
+func (e *SchemaError) Error() string {
+	if e.Row < 0 {
+		return fmt.Sprintf("column %q: %v", e.Column, e.Err)
+	}
+	return fmt.Sprintf("row %d column %q value %q: %v", e.Row, e.Column, e.Value, e.Err)
+}
+
+func (e *SchemaError) Unwrap() error {
+	return e.Err
+}
+
+// Validate returns *SchemaError if the Column does not match the Field.
+func (f Field) Validate(col Column) error {
+	if tp := col.DataType(); tp != f.Type {
+		return &SchemaError{Row: -1, Column: f.Name, Err: fmt.Errorf("%w (expected %q, got %q)", ErrTypeMismatch, f.Type, tp)}
+	}
+	if f.Nullable || col.NullCount() == 0 {
+		return nil
+	}
+	for i := 0; i < col.Count(); i++ {
+		if v, err := col.Value(i); err == nil && v.IsNull() {
+			return &SchemaError{Row: i, Column: f.Name, Err: ErrNotNullable}
+		}
+	}
+	return nil
+}
+
+// Parse converts a string to the Value of the field's data type.
+// Empty strings are null, except for STRING fields.
+func (f Field) Parse(s string) (Value, error) {
+	if s == "" && f.Type != STRING {
+		return NewNullValue(), nil
+	}
+	switch f.Type {
+	case STRING:
+		return String(s), nil
+	case INT64:
+		iv, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return Int64(iv), nil
+	case UINT64:
+		uv, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return Uint64(uv), nil
+	case FLOAT64:
+		fv, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, err
+		}
+		return Float64(fv), nil
+	case BOOL:
+		bv, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, err
+		}
+		return Bool(bv), nil
+	case DURATION:
+		dv, err := time.ParseDuration(s)
+		if err != nil {
+			return nil, err
+		}
+		return GoDuration(dv), nil
+	case TIME:
+		tv, err := parseTime(f.Layout, s)
+		if err != nil {
+			return nil, err
+		}
+		return GoTime(tv), nil
+	default:
+		return nil, fmt.Errorf("data type %q is not supported yet", f.Type)
+	}
+}
+
+func parseTime(layout, s string) (time.Time, error) {
+	switch layout {
+	case "":
+		return time.Parse(TimeDefaultLayout, s)
+	case LayoutUnixSeconds:
+		sec, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(sec, 0).UTC(), nil
+	default:
+		return time.Parse(layout, s)
+	}
+}
+
+// Schema describes the columns of Frame in order.
+type Schema struct {
+	Fields []Field
+}
+
+// Headers returns the field names in order.
+func (s Schema) Headers() []string {
+	hs := make([]string, len(s.Fields))
+	for i, f := range s.Fields {
+		hs[i] = f.Name
+	}
+	return hs
+}
+
+// Equal returns true if both Schemas have the same Fields in the same order.
+func (s Schema) Equal(o Schema) bool {
+	if len(s.Fields) != len(o.Fields) {
+		return false
+	}
+	for i := range s.Fields {
+		if s.Fields[i] != o.Fields[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Validate returns *SchemaError if the Frame does not have the Fields
+// of the Schema, or has columns that are not in the Schema.
+func (s Schema) Validate(fr Frame) error {
+	for _, f := range s.Fields {
+		col, err := fr.Column(f.Name)
+		if err != nil {
+			return &SchemaError{Row: -1, Column: f.Name, Err: err}
+		}
+		if err := f.Validate(col); err != nil {
+			return err
+		}
+	}
+	for _, hd := range fr.Headers() {
+		if _, ok := s.Field(hd); !ok {
+			return &SchemaError{Row: -1, Column: hd, Err: ErrFieldNotFound}
+		}
+	}
+	return nil
+}
+
+// Field returns the Field by its name.
+func (s Schema) Field(name string) (Field, bool) {
+	for _, f := range s.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// Set overwrites the Field of the same name.
+// It returns error if the name does not exist.
+func (s *Schema) Set(f Field) error {
+	for i := range s.Fields {
+		if s.Fields[i].Name == f.Name {
+			s.Fields[i] = f
+			return nil
+		}
+	}
+	return fmt.Errorf("%q does not exist", f.Name)
+}
+
+// InferOptions configures schema inference.
+type InferOptions struct {
+	// SampleRows is the number of data rows to sample from the top.
+	// Zero or negative scans all the rows.
+	SampleRows int
+
+	// TimeLayouts are tried in order to infer TIME columns.
+	// If empty, DefaultTimeLayouts is used.
+	TimeLayouts []string
+}
+
+// InferSchema infers the Schema from rows. Each column becomes TIME
+// if all of its sampled cells parse with one of the time layouts,
+// then BOOL, INT64, FLOAT64, or otherwise STRING. Empty cells are
+// ignored, and columns with no cells to sample are STRING.
+// Inferred Fields are nullable, since rows out of the sample may be empty.
+// Pass 'nil' header if first row is used as header strings.
+// Pass 'non-nil' header if the data starts from the first row, without header strings.
+func InferSchema(header []string, rows [][]string, opts InferOptions) (Schema, error) {
+	if len(header) == 0 {
+		if len(rows) < 1 {
+			return Schema{}, fmt.Errorf("empty row %q", rows)
+		}
+		header, rows = rows[0], rows[1:]
+	}
+	if opts.SampleRows > 0 && opts.SampleRows < len(rows) {
+		rows = rows[:opts.SampleRows]
+	}
+	layouts := opts.TimeLayouts
+	if len(layouts) == 0 {
+		layouts = DefaultTimeLayouts
+	}
+
+	sc := Schema{Fields: make([]Field, len(header))}
+	for i, name := range header {
+		cells := make([]string, 0, len(rows))
+		for _, row := range rows {
+			if i < len(row) {
+				cells = append(cells, row[i])
+			}
+		}
+		sc.Fields[i] = inferField(name, cells, layouts)
+	}
+	return sc, nil
+}
+
+func inferField(name string, cells []string, layouts []string) Field {
+	isBool, isInt, isFloat := true, true, true
+	isTime := make([]bool, len(layouts))
+	for i := range isTime {
+		isTime[i] = true
+	}
+
+	n := 0
+	for _, s := range cells {
+		if s == "" {
+			continue
+		}
+		n++
+		if isBool {
+			// as parsed by Field.Parse, other than the integers 1 and 0
+			_, err := strconv.ParseBool(s)
+			isBool = err == nil && s != "1" && s != "0"
+		}
+		if isInt {
+			_, err := strconv.ParseInt(s, 10, 64)
+			isInt = err == nil
+		}
+		if isFloat {
+			_, err := strconv.ParseFloat(s, 64)
+			isFloat = err == nil
+		}
+		for i, layout := range layouts {
+			if isTime[i] {
+				isTime[i] = inferTime(layout, s)
+			}
+		}
+	}
+	if n == 0 {
+		return Field{Name: name, Type: STRING, Nullable: true}
+	}
+
+	for i, layout := range layouts {
+		if isTime[i] {
+			return Field{Name: name, Type: TIME, Nullable: true, Layout: layout}
+		}
+	}
+	switch {
+	case isBool:
+		return Field{Name: name, Type: BOOL, Nullable: true}
+	case isInt:
+		return Field{Name: name, Type: INT64, Nullable: true}
+	case isFloat:
+		return Field{Name: name, Type: FLOAT64, Nullable: true}
+	default:
+		return Field{Name: name, Type: STRING, Nullable: true}
+	}
+}
+
+func inferTime(layout, s string) bool {
+	if layout == LayoutUnixSeconds {
+		sec, err := strconv.ParseInt(s, 10, 64)
+		return err == nil && minInferUnixSeconds <= sec && sec < maxInferUnixSeconds
+	}
+	_, err := parseTime(layout, s)
+	return err == nil
+}
@@ -0,0 +1,186 @@
+package dataframe
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestInferSchema(t *testing.T) {
+	fr, schema, err := NewFromCSVInfer(nil, "testdata/bench-01-etcd-1-monitor.csv", InferOptions{SampleRows: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Field{
+		{Name: "unix_ts", Type: TIME, Nullable: true, Layout: LayoutUnixSeconds},
+		{Name: "NAME", Type: STRING, Nullable: true},
+		{Name: "STATE", Type: STRING, Nullable: true},
+		{Name: "PID", Type: INT64, Nullable: true},
+		{Name: "PPID", Type: INT64, Nullable: true},
+		{Name: "CPU", Type: STRING, Nullable: true},
+		{Name: "VM_RSS", Type: STRING, Nullable: true},
+		{Name: "VM_SIZE", Type: STRING, Nullable: true},
+		{Name: "FD", Type: INT64, Nullable: true},
+		{Name: "THREADS", Type: INT64, Nullable: true},
+		{Name: "CpuUsageFloat64", Type: FLOAT64, Nullable: true},
+		{Name: "VmRSSBytes", Type: INT64, Nullable: true},
+		{Name: "VmSizeBytes", Type: INT64, Nullable: true},
+	}
+	if !reflect.DeepEqual(schema.Fields, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, schema.Fields)
+	}
+	if !reflect.DeepEqual(fr.Headers(), schema.Headers()) {
+		t.Fatalf("expected %q, got %q", schema.Headers(), fr.Headers())
+	}
+
+	col, err := fr.Column("unix_ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col.DataType() != TIME {
+		t.Fatalf("expected %q, got %q", TIME, col.DataType())
+	}
+	if v, ok := col.Front(); !ok || !v.EqualTo(NewTimeValue(time.Unix(1458757864, 0))) {
+		t.Fatalf("expected %v, got %v", time.Unix(1458757864, 0), v)
+	}
+	col, err = fr.Column("CpuUsageFloat64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rows, ok := col.Float64s(); !ok || rows[1] != 6.93 {
+		t.Fatalf("expected 6.93, got %v(%v)", rows, ok)
+	}
+
+	// override and reuse for the next file
+	if err := schema.Set(Field{Name: "VmRSSBytes", Type: FLOAT64}); err != nil {
+		t.Fatal(err)
+	}
+	fr2, err := NewFromCSVTyped(nil, "testdata/bench-01-etcd-2-monitor.csv", schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, err = fr2.Column("VmRSSBytes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col.DataType() != FLOAT64 {
+		t.Fatalf("expected %q, got %q", FLOAT64, col.DataType())
+	}
+}
+
+func TestInferSchemaTypes(t *testing.T) {
+	rows := [][]string{
+		{"a", "b", "c", "d", "e", "f", "g"},
+		{"1", "1.5", "true", "2016-03-23", "", "x", "True"},
+		{"2", "", "FALSE", "2016-03-24", "", "1", "tRuE"},
+		{"", "3", "false", "2016-03-25", "", "2", "false"},
+	}
+	schema, err := InferSchema(nil, rows, InferOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []Field{
+		{Name: "a", Type: INT64, Nullable: true},
+		{Name: "b", Type: FLOAT64, Nullable: true},
+		{Name: "c", Type: BOOL, Nullable: true},
+		{Name: "d", Type: TIME, Nullable: true, Layout: "2006-01-02"},
+		{Name: "e", Type: STRING, Nullable: true},
+		{Name: "f", Type: STRING, Nullable: true},
+		{Name: "g", Type: STRING, Nullable: true},
+	}
+	if !reflect.DeepEqual(schema.Fields, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, schema.Fields)
+	}
+
+	fr, err := NewFromRowsTyped(nil, rows, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, err := fr.Column("a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := col.NullCount(); n != 1 {
+		t.Fatalf("null count expected 1, got %d", n)
+	}
+
+	if _, err := NewFromRowsTyped(nil, [][]string{{"a"}, {"x"}}, schema); err == nil {
+		t.Fatal("expected error")
+	}
+	_, err = NewFromRowsTyped(nil, [][]string{{"a"}, {"1"}, {"2", "3"}}, schema)
+	var se *SchemaError
+	if !errors.As(err, &se) || se.Row != 1 || !errors.Is(err, ErrFieldNotFound) {
+		t.Fatalf("expected *SchemaError of row 1, got %v", err)
+	}
+}
+
+func TestSchemaValidate(t *testing.T) {
+	schema := Schema{Fields: []Field{
+		{Name: "unix_ts", Type: TIME, Layout: LayoutUnixSeconds},
+		{Name: "avg_latency_ms", Type: FLOAT64, Nullable: true},
+		{Name: "throughput", Type: INT64},
+	}}
+	rows := [][]string{
+		{"unix_ts", "avg_latency_ms", "throughput"},
+		{"1458757890", "4.484004", "64"},
+		{"1458757891", "", "232"},
+		{"1458757892", "4.1", "x"},
+	}
+	_, err := NewFromRowsTyped(nil, rows, schema)
+	serr, ok := err.(*SchemaError)
+	if !ok {
+		t.Fatalf("expected *SchemaError, got %v", err)
+	}
+	if serr.Row != 2 || serr.Column != "throughput" || serr.Value != "x" {
+		t.Fatalf("unexpected error %+v", serr)
+	}
+
+	rows[3] = []string{"1458757892", "4.1"}
+	_, err = NewFromRowsTyped(nil, rows, schema)
+	if !errors.Is(err, ErrNotNullable) {
+		t.Fatalf("expected %v, got %v", ErrNotNullable, err)
+	}
+
+	rows = rows[:3]
+	fr, err := NewFromRowsTyped(nil, rows, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fr.Schema().Equal(schema) {
+		t.Fatalf("expected %+v, got %+v", schema, fr.Schema())
+	}
+	if err := schema.Validate(fr); err != nil {
+		t.Fatal(err)
+	}
+
+	col, err := fr.Column("throughput")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := col.PushBackTyped(nil); !errors.Is(err, ErrNotNullable) {
+		t.Fatalf("expected %v, got %v", ErrNotNullable, err)
+	}
+	if _, err := col.PushBackTyped(1.5); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("expected %v, got %v", ErrTypeMismatch, err)
+	}
+
+	typed := NewTyped(schema)
+	lc := NewColumnTyped("avg_latency_ms", INT64)
+	if err := typed.AddColumn(lc); !errors.Is(err, ErrTypeMismatch) {
+		t.Fatalf("expected %v, got %v", ErrTypeMismatch, err)
+	}
+	tc := NewColumnTyped("throughput", INT64)
+	tc.PushBack(NewInt64Value(1))
+	tc.PushBack(NewNullValue())
+	err = typed.AddColumn(tc)
+	if serr, ok := err.(*SchemaError); !ok || serr.Row != 1 || !errors.Is(err, ErrNotNullable) {
+		t.Fatalf("expected %v at row 1, got %v", ErrNotNullable, err)
+	}
+	if err := typed.AddColumn(NewColumn("NAME")); !errors.Is(err, ErrFieldNotFound) {
+		t.Fatalf("expected %v, got %v", ErrFieldNotFound, err)
+	}
+	if err := typed.AddColumn(NewColumnTyped("avg_latency_ms", FLOAT64)); err != nil {
+		t.Fatal(err)
+	}
+}
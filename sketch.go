@@ -0,0 +1,329 @@
+package dataframe
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// DefaultSketchAccuracy is the relative accuracy of the Sketches
+// of the aggregations by default.
+const DefaultSketchAccuracy = 0.01
+
+// sketchVersion is the version of the binary encoding of Sketch.
+const sketchVersion = 1
+
+// Sketch is a mergeable quantile sketch (DDSketch), which estimates the
+// quantiles of the values within the relative accuracy, such as 1% of the
+// p99 latency, in the memory of the logarithm of the range of the values.
+// The Sketches of the chunks of the values, or of the nodes, are merged
+// into the Sketch of all the values. The zero Sketch is empty, and takes
+// the relative accuracy of the first Sketch merged or decoded into it,
+// or DefaultSketchAccuracy when a value is added first.
+type Sketch struct {
+	accuracy float64
+	logGamma float64
+
+	// pos and neg are the counts of the positive values and of the
+	// magnitudes of the negative values by the index of the buckets,
+	// which is the ceiling of the logarithm of the value in gamma.
+	pos  map[int]uint64
+	neg  map[int]uint64
+	zero uint64
+
+	count    uint64
+	min, max float64
+}
+
+// NewSketch returns a new empty Sketch of the relative accuracy in (0, 1).
+func NewSketch(relativeAccuracy float64) (*Sketch, error) {
+	if !(relativeAccuracy > 0 && relativeAccuracy < 1) {
+		return nil, fmt.Errorf("relative accuracy %v is out of range (0, 1)", relativeAccuracy)
+	}
+	s := &Sketch{}
+	s.init(relativeAccuracy)
+	return s, nil
+}
+
+func (s *Sketch) init(relativeAccuracy float64) {
+	gamma := (1 + relativeAccuracy) / (1 - relativeAccuracy)
+	*s = Sketch{
+		accuracy: relativeAccuracy,
+		logGamma: math.Log(gamma),
+		pos:      make(map[int]uint64),
+		neg:      make(map[int]uint64),
+		min:      math.Inf(1),
+		max:      math.Inf(-1),
+	}
+}
+
+// RelativeAccuracy returns the relative accuracy of the Sketch.
+func (s *Sketch) RelativeAccuracy() float64 {
+	return s.accuracy
+}
+
+// Count returns the number of the values in the Sketch.
+func (s *Sketch) Count() uint64 {
+	return s.count
+}
+
+// Add adds the value to the Sketch. NaN and infinite values are skipped.
+func (s *Sketch) Add(v float64) {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return
+	}
+	if s.pos == nil {
+		s.init(DefaultSketchAccuracy)
+	}
+	switch {
+	case v > 0:
+		s.pos[s.index(v)]++
+	case v < 0:
+		s.neg[s.index(-v)]++
+	default:
+		s.zero++
+	}
+	s.count++
+	s.min = math.Min(s.min, v)
+	s.max = math.Max(s.max, v)
+}
+
+func (s *Sketch) index(v float64) int {
+	return int(math.Ceil(math.Log(v) / s.logGamma))
+}
+
+// value returns the value of the bucket, whose relative distance
+// to any value in the bucket is within the accuracy.
+func (s *Sketch) value(index int) float64 {
+	return 2 * math.Exp(float64(index)*s.logGamma) / (1 + math.Exp(s.logGamma))
+}
+
+// Quantile returns the estimated q-quantile of the values, for q in [0, 1].
+// The 0 and 1 quantiles are the exact minimum and maximum.
+func (s *Sketch) Quantile(q float64) (float64, error) {
+	if !(q >= 0 && q <= 1) {
+		return 0, fmt.Errorf("quantile %v is out of range [0, 1]", q)
+	}
+	if s.count == 0 {
+		return 0, fmt.Errorf("sketch is empty")
+	}
+	switch q {
+	case 0:
+		return s.min, nil
+	case 1:
+		return s.max, nil
+	}
+
+	// the value of the rank, in the order of the negative buckets
+	// of the largest magnitude first, zero and the positive buckets
+	rank := uint64(q * float64(s.count-1))
+	var seen uint64
+	fv := s.max
+	found := false
+	for _, i := range sortedIndexes(s.neg, true) {
+		if seen += s.neg[i]; seen > rank {
+			fv, found = -s.value(i), true
+			break
+		}
+	}
+	if !found {
+		if seen += s.zero; seen > rank {
+			fv, found = 0, true
+		}
+	}
+	if !found {
+		for _, i := range sortedIndexes(s.pos, false) {
+			if seen += s.pos[i]; seen > rank {
+				fv = s.value(i)
+				break
+			}
+		}
+	}
+	return math.Max(s.min, math.Min(s.max, fv)), nil
+}
+
+func sortedIndexes(buckets map[int]uint64, desc bool) []int {
+	idx := make([]int, 0, len(buckets))
+	for i := range buckets {
+		idx = append(idx, i)
+	}
+	if desc {
+		sort.Sort(sort.Reverse(sort.IntSlice(idx)))
+	} else {
+		sort.Ints(idx)
+	}
+	return idx
+}
+
+// Merge adds the values of the other Sketch, which must have
+// the same relative accuracy unless the Sketch is the zero Sketch.
+func (s *Sketch) Merge(o *Sketch) error {
+	if o.count == 0 {
+		return nil
+	}
+	if s.pos == nil {
+		s.init(o.accuracy)
+	}
+	if s.accuracy != o.accuracy {
+		return fmt.Errorf("relative accuracy %v does not match %v", o.accuracy, s.accuracy)
+	}
+	for i, c := range o.pos {
+		s.pos[i] += c
+	}
+	for i, c := range o.neg {
+		s.neg[i] += c
+	}
+	s.zero += o.zero
+	s.count += o.count
+	s.min = math.Min(s.min, o.min)
+	s.max = math.Max(s.max, o.max)
+	return nil
+}
+
+// MarshalBinary encodes the Sketch, to be merged with
+// the Sketches of the other nodes.
+func (s *Sketch) MarshalBinary() ([]byte, error) {
+	if s.pos == nil {
+		s = &Sketch{}
+		s.init(DefaultSketchAccuracy)
+	}
+	b := []byte{sketchVersion}
+	b = binary.LittleEndian.AppendUint64(b, math.Float64bits(s.accuracy))
+	b = binary.LittleEndian.AppendUint64(b, math.Float64bits(s.min))
+	b = binary.LittleEndian.AppendUint64(b, math.Float64bits(s.max))
+	b = binary.AppendUvarint(b, s.zero)
+	for _, buckets := range []map[int]uint64{s.neg, s.pos} {
+		b = binary.AppendUvarint(b, uint64(len(buckets)))
+		for _, i := range sortedIndexes(buckets, false) {
+			b = binary.AppendVarint(b, int64(i))
+			b = binary.AppendUvarint(b, buckets[i])
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalBinary decodes the Sketch from MarshalBinary.
+func (s *Sketch) UnmarshalBinary(data []byte) error {
+	if len(data) < 25 || data[0] != sketchVersion {
+		return fmt.Errorf("sketch encoding is invalid")
+	}
+	accuracy := math.Float64frombits(binary.LittleEndian.Uint64(data[1:]))
+	if !(accuracy > 0 && accuracy < 1) {
+		return fmt.Errorf("relative accuracy %v is out of range (0, 1)", accuracy)
+	}
+	var ns Sketch
+	ns.init(accuracy)
+	ns.min = math.Float64frombits(binary.LittleEndian.Uint64(data[9:]))
+	ns.max = math.Float64frombits(binary.LittleEndian.Uint64(data[17:]))
+	data = data[25:]
+
+	uvarint := func() (uint64, error) {
+		u, n := binary.Uvarint(data)
+		if n <= 0 {
+			return 0, fmt.Errorf("sketch encoding is truncated")
+		}
+		data = data[n:]
+		return u, nil
+	}
+	var err error
+	if ns.zero, err = uvarint(); err != nil {
+		return err
+	}
+	ns.count = ns.zero
+	for _, buckets := range []map[int]uint64{ns.neg, ns.pos} {
+		n, err := uvarint()
+		if err != nil {
+			return err
+		}
+		for ; n > 0; n-- {
+			i, m := binary.Varint(data)
+			if m <= 0 {
+				return fmt.Errorf("sketch encoding is truncated")
+			}
+			data = data[m:]
+			c, err := uvarint()
+			if err != nil {
+				return err
+			}
+			buckets[int(i)] += c
+			ns.count += c
+		}
+	}
+	if len(data) > 0 {
+		return fmt.Errorf("sketch encoding has %d trailing bytes", len(data))
+	}
+	if ns.count > 0 && !(ns.min <= ns.max) {
+		return fmt.Errorf("sketch range [%v, %v] is invalid", ns.min, ns.max)
+	}
+	*s = ns
+	return nil
+}
+
+// MarshalText encodes the Sketch in base64, to be stored in a STRING Column.
+func (s *Sketch) MarshalText() ([]byte, error) {
+	b, err := s.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	text := make([]byte, base64.StdEncoding.EncodedLen(len(b)))
+	base64.StdEncoding.Encode(text, b)
+	return text, nil
+}
+
+// UnmarshalText decodes the Sketch from MarshalText.
+func (s *Sketch) UnmarshalText(text []byte) error {
+	b := make([]byte, base64.StdEncoding.DecodedLen(len(text)))
+	n, err := base64.StdEncoding.Decode(b, text)
+	if err != nil {
+		return err
+	}
+	return s.UnmarshalBinary(b[:n])
+}
+
+// aggSketch returns the Sketch of the non-null values of the Field,
+// or the merged Sketch of the STRING values from MarshalText.
+func aggSketch(fd Field, vs []Value, relativeAccuracy float64) (*Sketch, error) {
+	if fd.Type != STRING {
+		s, err := NewSketch(relativeAccuracy)
+		if err != nil {
+			return nil, err
+		}
+		for _, v := range vs {
+			s.Add(valueFloat64(fd.Type, v))
+		}
+		return s, nil
+	}
+	s := &Sketch{}
+	for _, v := range vs {
+		text, _ := v.String()
+		var vsk Sketch
+		if err := vsk.UnmarshalText([]byte(text)); err != nil {
+			return nil, err
+		}
+		if err := s.Merge(&vsk); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (c *column) Sketch(relativeAccuracy float64) (*Sketch, error) {
+	fd := c.Field()
+	switch fd.Type {
+	case INT64, UINT64, FLOAT64, DURATION:
+	default:
+		return nil, fmt.Errorf("%q of %s is not numeric", fd.Name, fd.Type)
+	}
+	s, err := NewSketch(relativeAccuracy)
+	if err != nil {
+		return nil, err
+	}
+	for row, n := 0, c.Count(); row < n; row++ {
+		if v := valueAt(c, row); !v.IsNull() {
+			s.Add(valueFloat64(fd.Type, v))
+		}
+	}
+	return s, nil
+}
@@ -0,0 +1,157 @@
+package dataframe
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestSketch(t *testing.T) {
+	rd := rand.New(rand.NewSource(1))
+	fs := make([]float64, 20000)
+	chunks := make([]*Sketch, 4)
+	for i := range chunks {
+		chunks[i], _ = NewSketch(0.01)
+	}
+	for i := range fs {
+		fs[i] = rd.ExpFloat64() * 1e6
+		if i%100 == 0 {
+			fs[i] = -fs[i]
+		}
+		chunks[i%len(chunks)].Add(fs[i])
+	}
+	sorted := append([]float64(nil), fs...)
+	sort.Float64s(sorted)
+
+	// merge the chunks through the encoding of each node
+	var s Sketch
+	for _, c := range chunks {
+		text, err := c.MarshalText()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var dec Sketch
+		if err := dec.UnmarshalText(text); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Merge(&dec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if s.Count() != uint64(len(fs)) || s.RelativeAccuracy() != 0.01 {
+		t.Fatalf("expected %d values of 0.01, got %d of %v", len(fs), s.Count(), s.RelativeAccuracy())
+	}
+	for _, q := range []float64{0, 0.001, 0.01, 0.25, 0.5, 0.9, 0.99, 0.999, 1} {
+		fv, err := s.Quantile(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		exact := sorted[int(q*float64(len(sorted)-1))]
+		if math.Abs(fv-exact) > 0.01*math.Abs(exact) {
+			t.Fatalf("q%v: expected %v within 1%%, got %v", q, exact, fv)
+		}
+	}
+
+	other, _ := NewSketch(0.05)
+	other.Add(1)
+	if err := s.Merge(other); err == nil {
+		t.Fatal("expected error")
+	}
+	b, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := new(Sketch).UnmarshalBinary(b[:len(b)-1]); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := new(Sketch).Quantile(0.5); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := NewSketch(0); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestSketchGroupBy(t *testing.T) {
+	fr, err := NewFromRowsTyped([]string{"node", "took"}, [][]string{
+		{"a", "10ms"},
+		{"b", "20ms"},
+		{"a", "30ms"},
+		{"b", ""},
+		{"b", "40ms"},
+		{"a", "100ms"},
+	}, Schema{Fields: []Field{
+		{Name: "node", Type: STRING},
+		{Name: "took", Type: DURATION, Nullable: true},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := fr.GroupBy("node")
+	if err != nil {
+		t.Fatal(err)
+	}
+	perNode, err := g.Agg(
+		Agg{Column: "took", Func: AggFunc_Sketch},
+		Agg{Column: "took", Func: AggFunc_SketchQuantile, Quantile: 1},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, err := perNode.Column("took_q1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := col.Value(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d, _ := v.Duration(); d != 100*time.Millisecond {
+		t.Fatalf("expected 100ms, got %v", d)
+	}
+
+	// combine the p50s of the nodes from their Sketches
+	sketches, err := perNode.Column("took_sketch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := New()
+	if err := all.AddColumn(sketches); err != nil {
+		t.Fatal(err)
+	}
+	cluster := NewColumn("cluster")
+	cluster.Appends(NewStringValue("x"), sketches.Count())
+	if err := all.AddColumn(cluster); err != nil {
+		t.Fatal(err)
+	}
+	g, err = all.GroupBy("cluster")
+	if err != nil {
+		t.Fatal(err)
+	}
+	merged, err := g.Agg(Agg{Column: "took_sketch", Func: AggFunc_SketchQuantile, Quantile: 0.5, Name: "p50"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	col, err = merged.Column("p50")
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, _ = col.Value(0)
+	if fv, _ := v.Float64(); math.Abs(fv-float64(30*time.Millisecond)) > 0.01*float64(30*time.Millisecond) {
+		t.Fatalf("expected 30ms within 1%%, got %v", fv)
+	}
+
+	if _, err := g.Agg(Agg{Column: "took_sketch", Func: AggFunc_Sketch, Accuracy: 2}); err == nil {
+		t.Fatal("expected error")
+	}
+	took, _ := fr.Column("took")
+	s, err := took.Sketch(0.01)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Count() != 5 {
+		t.Fatalf("expected 5 values, got %d", s.Count())
+	}
+}
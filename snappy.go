@@ -0,0 +1,187 @@
+package dataframe
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// snappy implements the Snappy block format, which Parquet uses
+// for SNAPPY compressed pages (without the framing format).
+
+var errSnappyCorrupt = errors.New("snappy: corrupt input")
+
+const (
+	snappyTagLiteral = 0x00
+	snappyTagCopy1   = 0x01
+	snappyTagCopy2   = 0x02
+	snappyTagCopy4   = 0x03
+
+	// snappyBlockSize is the size of the blocks that are compressed
+	// independently, so that offsets fit in two bytes.
+	snappyBlockSize = 1 << 16
+
+	snappyTableBits = 14
+)
+
+// snappyDecode decodes the Snappy block in src, whose decoded size is size.
+// The size in the block is not trusted for the allocation.
+func snappyDecode(src []byte, size int) ([]byte, error) {
+	n, k := binary.Uvarint(src)
+	if k <= 0 || n > 1<<32-1 || n != uint64(size) {
+		return nil, errSnappyCorrupt
+	}
+	src = src[k:]
+	dst := make([]byte, 0, n)
+	for len(src) > 0 {
+		tag := src[0]
+		var length, offset int
+		switch tag & 0x03 {
+		case snappyTagLiteral:
+			x := int(tag >> 2)
+			switch {
+			case x < 60:
+				src = src[1:]
+			case x <= 63:
+				nb := x - 59
+				if len(src) < 1+nb {
+					return nil, errSnappyCorrupt
+				}
+				x = 0
+				for i := nb; i > 0; i-- {
+					x = x<<8 | int(src[i])
+				}
+				src = src[1+nb:]
+			}
+			length = x + 1
+			if length > len(src) || len(dst)+length > int(n) {
+				return nil, errSnappyCorrupt
+			}
+			dst = append(dst, src[:length]...)
+			src = src[length:]
+			continue
+
+		case snappyTagCopy1:
+			if len(src) < 2 {
+				return nil, errSnappyCorrupt
+			}
+			length = 4 + int(tag>>2)&0x07
+			offset = int(tag&0xe0)<<3 | int(src[1])
+			src = src[2:]
+
+		case snappyTagCopy2:
+			if len(src) < 3 {
+				return nil, errSnappyCorrupt
+			}
+			length = 1 + int(tag>>2)
+			offset = int(binary.LittleEndian.Uint16(src[1:]))
+			src = src[3:]
+
+		case snappyTagCopy4:
+			if len(src) < 5 {
+				return nil, errSnappyCorrupt
+			}
+			length = 1 + int(tag>>2)
+			offset = int(binary.LittleEndian.Uint32(src[1:]))
+			src = src[5:]
+		}
+		if offset <= 0 || offset > len(dst) || len(dst)+length > int(n) {
+			return nil, errSnappyCorrupt
+		}
+		// copy byte by byte, since the source and destination can overlap
+		for i := len(dst) - offset; length > 0; i, length = i+1, length-1 {
+			dst = append(dst, dst[i])
+		}
+	}
+	if len(dst) != int(n) {
+		return nil, errSnappyCorrupt
+	}
+	return dst, nil
+}
+
+// snappyEncode encodes src in a Snappy block.
+func snappyEncode(src []byte) []byte {
+	dst := binary.AppendUvarint(make([]byte, 0, len(src)+len(src)/6+32), uint64(len(src)))
+	var table [1 << snappyTableBits]int32
+	for len(src) > 0 {
+		block := src
+		if len(block) > snappyBlockSize {
+			block = block[:snappyBlockSize]
+		}
+		src = src[len(block):]
+		dst = snappyEncodeBlock(dst, block, &table)
+	}
+	return dst
+}
+
+func snappyHash(u uint32) uint32 {
+	return (u * 0x1e35a7bd) >> (32 - snappyTableBits)
+}
+
+// snappyEncodeBlock finds matches of at least 4 bytes with a hash table
+// of the positions, and emits the literals and copies in between.
+func snappyEncodeBlock(dst, src []byte, table *[1 << snappyTableBits]int32) []byte {
+	if len(src) < 8 {
+		return snappyEmitLiteral(dst, src)
+	}
+	for i := range table {
+		table[i] = -1
+	}
+	lit := 0
+	for s := 0; s+4 <= len(src); {
+		cur := binary.LittleEndian.Uint32(src[s:])
+		h := snappyHash(cur)
+		cand := int(table[h])
+		table[h] = int32(s)
+		if cand < 0 || binary.LittleEndian.Uint32(src[cand:]) != cur {
+			s++
+			continue
+		}
+
+		end := s + 4
+		for end < len(src) && src[end] == src[end-s+cand] {
+			end++
+		}
+		dst = snappyEmitLiteral(dst, src[lit:s])
+		dst = snappyEmitCopy(dst, s-cand, end-s)
+		for i := s + 1; i < end && i+4 <= len(src); i++ {
+			table[snappyHash(binary.LittleEndian.Uint32(src[i:]))] = int32(i)
+		}
+		s, lit = end, end
+	}
+	return snappyEmitLiteral(dst, src[lit:])
+}
+
+func snappyEmitLiteral(dst, lit []byte) []byte {
+	if len(lit) == 0 {
+		return dst
+	}
+	n := len(lit) - 1
+	switch {
+	case n < 60:
+		dst = append(dst, byte(n)<<2|snappyTagLiteral)
+	default:
+		nb := (bits.Len(uint(n)) + 7) / 8
+		dst = append(dst, byte(59+nb)<<2|snappyTagLiteral)
+		for i := 0; i < nb; i++ {
+			dst = append(dst, byte(n>>(8*i)))
+		}
+	}
+	return append(dst, lit...)
+}
+
+func snappyEmitCopy(dst []byte, offset, length int) []byte {
+	for length >= 68 {
+		dst = append(dst, 63<<2|snappyTagCopy2, byte(offset), byte(offset>>8))
+		length -= 64
+	}
+	if length > 64 {
+		// leave at least 4 bytes for the last copy
+		dst = append(dst, 59<<2|snappyTagCopy2, byte(offset), byte(offset>>8))
+		length -= 60
+	}
+	if length >= 12 || offset >= 2048 {
+		return append(dst, byte(length-1)<<2|snappyTagCopy2, byte(offset), byte(offset>>8))
+	}
+	return append(dst, byte(offset>>8)<<5|byte(length-4)<<2|snappyTagCopy1, byte(offset))
+}
@@ -0,0 +1,166 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// NullOrder defines where SortBy places the null Values.
+type NullOrder int
+
+const (
+	NullOrder_Last NullOrder = iota
+	NullOrder_First
+)
+
+// SortKey is a key column of SortBy.
+type SortKey struct {
+	// Header is the header of the key column.
+	Header string
+
+	// Option is the direction of the key.
+	Option SortOption
+
+	// Nulls places the null and NaN Values,
+	// regardless of the direction.
+	Nulls NullOrder
+}
+
+// sortColumn compares the rows of a key column.
+type sortColumn struct {
+	// valid is false for the null and NaN rows.
+	valid []bool
+
+	// cmp compares the valid rows.
+	cmp func(i, j int) int
+
+	desc       bool
+	nullsFirst bool
+}
+
+// keyValues converts the n rows of the Column once,
+// to be compared without converting each time.
+func keyValues[T any](col Column, n int, conv func(v Value) (T, bool), cmp func(a, b T) int) ([]bool, func(i, j int) int) {
+	vs := make([]T, n)
+	valid := make([]bool, n)
+	for row := range vs {
+		if v := valueAt(col, row); !v.IsNull() {
+			vs[row], valid[row] = conv(v)
+		}
+	}
+	return valid, func(i, j int) int { return cmp(vs[i], vs[j]) }
+}
+
+// newSortColumn returns the sortColumn of the n rows,
+// compared in the data type.
+func newSortColumn(col Column, n int, tp DATA_TYPE, layout string) sortColumn {
+	var sc sortColumn
+	switch tp {
+	case INT64:
+		vs, mask := col.Int64sMask()
+		sc.valid = make([]bool, n)
+		copy(sc.valid, mask)
+		sc.cmp = func(i, j int) int { return compareOrdered(vs[i], vs[j]) }
+	case FLOAT64:
+		vs, mask := col.Float64sMask()
+		sc.valid = make([]bool, n)
+		for i, ok := range mask {
+			sc.valid[i] = ok && !math.IsNaN(vs[i])
+		}
+		sc.cmp = func(i, j int) int { return compareOrdered(vs[i], vs[j]) }
+	case UINT64:
+		sc.valid, sc.cmp = keyValues(col, n, Value.Uint64, compareOrdered[uint64])
+	case BOOL:
+		sc.valid, sc.cmp = keyValues(col, n, Value.Bool, func(a, b bool) int {
+			switch {
+			case a == b:
+				return 0
+			case b:
+				return -1
+			default:
+				return 1
+			}
+		})
+	case DURATION:
+		sc.valid, sc.cmp = keyValues(col, n, Value.Duration, compareOrdered[time.Duration])
+	case TIME:
+		sc.valid, sc.cmp = keyValues(col, n, func(v Value) (time.Time, bool) { return v.Time(layout) }, time.Time.Compare)
+	default:
+		sc.valid, sc.cmp = keyValues(col, n, Value.String, compareOrdered[string])
+	}
+	return sc
+}
+
+// SortBy sorts the rows of the Frame by the keys in order, comparing
+// the Values in the data types of the key columns. The sort is stable,
+// so the rows with the same keys stay in order. The Columns are padded
+// with nulls to the same length, and sorted in place.
+func (f *frame) SortBy(keys ...SortKey) error {
+	return f.sortBy(keys, nil)
+}
+
+// sortBy sorts the rows by the keys, compared in the types if not nil.
+func (f *frame) sortBy(keys []SortKey, types []DATA_TYPE) error {
+	if len(keys) == 0 {
+		return fmt.Errorf("no sort keys")
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	n := rowCount(f.columns)
+	scs := make([]sortColumn, len(keys))
+	for i, k := range keys {
+		idx, ok := f.headerTo[k.Header]
+		if !ok {
+			return fmt.Errorf("%q does not exist", k.Header)
+		}
+		if k.Option != SortOption_Ascending && k.Option != SortOption_Descending {
+			return fmt.Errorf("sort option %d of %q is unknown", k.Option, k.Header)
+		}
+		if k.Nulls != NullOrder_Last && k.Nulls != NullOrder_First {
+			return fmt.Errorf("null order %d of %q is unknown", k.Nulls, k.Header)
+		}
+		fd := f.columns[idx].Field()
+		if types != nil {
+			fd.Type = types[i]
+		}
+		scs[i] = newSortColumn(f.columns[idx], n, fd.Type, fd.Layout)
+		scs[i].desc = k.Option == SortOption_Descending
+		scs[i].nullsFirst = k.Nulls == NullOrder_First
+	}
+
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	sort.SliceStable(perm, func(a, b int) bool {
+		i, j := perm[a], perm[b]
+		for _, sc := range scs {
+			vi, vj := sc.valid[i], sc.valid[j]
+			if vi != vj {
+				return vi != sc.nullsFirst
+			}
+			if !vi {
+				continue
+			}
+			c := sc.cmp(i, j)
+			if sc.desc {
+				c = -c
+			}
+			if c != 0 {
+				return c < 0
+			}
+		}
+		return false
+	})
+
+	for _, col := range f.columns {
+		if err := col.Appends(NewNullValue(), n); err != nil {
+			return err
+		}
+		permuteColumn(col, perm)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package dataframe
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSortBy(t *testing.T) {
+	schema := Schema{Fields: []Field{
+		{Name: "id", Type: INT64},
+		{Name: "host", Type: STRING},
+		{Name: "cpu", Type: FLOAT64, Nullable: true},
+		{Name: "ts", Type: TIME, Layout: LayoutUnixSeconds, Nullable: true},
+	}}
+	rows := [][]string{
+		{"0", "b", "1.5", "1458757864"},
+		{"1", "a", "", "1458757866"},
+		{"2", "b", "0.5", ""},
+		{"3", "a", "2.5", "1458757865"},
+		{"4", "b", "1.5", "1458757863"},
+		{"5", "a", "NaN", "1458757867"},
+		{"6", "b", "0.5", "1458757862"},
+	}
+
+	tests := []struct {
+		keys     []SortKey
+		expected []string
+	}{
+		{
+			[]SortKey{{Header: "cpu"}},
+			[]string{"2", "6", "0", "4", "3", "1", "5"},
+		},
+		{
+			[]SortKey{{Header: "cpu", Option: SortOption_Descending}},
+			[]string{"3", "0", "4", "2", "6", "1", "5"},
+		},
+		{
+			[]SortKey{{Header: "cpu", Option: SortOption_Descending, Nulls: NullOrder_First}},
+			[]string{"1", "5", "3", "0", "4", "2", "6"},
+		},
+		{
+			[]SortKey{{Header: "host"}, {Header: "cpu", Option: SortOption_Descending}},
+			[]string{"3", "1", "5", "0", "4", "2", "6"},
+		},
+		{
+			[]SortKey{{Header: "ts", Nulls: NullOrder_First}},
+			[]string{"2", "6", "4", "0", "3", "1", "5"},
+		},
+	}
+	for i, tt := range tests {
+		fr, err := NewFromRowsTyped(schema.Headers(), rows, schema)
+		if err != nil {
+			t.Fatal(err)
+		}
+		col, err := fr.Column("id")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := fr.SortBy(tt.keys...); err != nil {
+			t.Fatal(err)
+		}
+		if got := col.Rows(); !reflect.DeepEqual(got, tt.expected) {
+			t.Fatalf("#%d: expected %v, got %v", i, tt.expected, got)
+		}
+		if !fr.Schema().Equal(schema) {
+			t.Fatalf("#%d: expected %v, got %v", i, schema, fr.Schema())
+		}
+	}
+
+	fr, err := NewFromRowsTyped(schema.Headers(), rows, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fr.SortBy(); err == nil {
+		t.Fatal("expected error")
+	}
+	if err := fr.SortBy(SortKey{Header: "mem"}); err == nil {
+		t.Fatal("expected error")
+	}
+	if err := fr.SortBy(SortKey{Header: "cpu", Option: 2}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestSortTyped(t *testing.T) {
+	fr, _, err := NewFromCSVInfer(nil, "testdata/bench-01-etcd-1-monitor.csv", InferOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	schema := fr.Schema()
+	if err := fr.Sort("CpuUsageFloat64", SortType_Float64, SortOption_Descending); err != nil {
+		t.Fatal(err)
+	}
+	if !fr.Schema().Equal(schema) {
+		t.Fatalf("expected %v, got %v", schema, fr.Schema())
+	}
+	col, err := fr.Column("CpuUsageFloat64")
+	if err != nil {
+		t.Fatal(err)
+	}
+	vs, ok := col.Float64s()
+	if !ok {
+		t.Fatalf("expected float64s, got %v", col.Rows())
+	}
+	for i := 1; i < len(vs); i++ {
+		if vs[i-1] < vs[i] {
+			t.Fatalf("expected descending, got %v before %v", vs[i-1], vs[i])
+		}
+	}
+}
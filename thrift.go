@@ -0,0 +1,313 @@
+package dataframe
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// thrift implements the Thrift compact protocol,
+// which Parquet uses to encode its metadata.
+
+const (
+	thriftStop      = 0
+	thriftTrue      = 1
+	thriftFalse     = 2
+	thriftByte      = 3
+	thriftI16       = 4
+	thriftI32       = 5
+	thriftI64       = 6
+	thriftDouble    = 7
+	thriftBinary    = 8
+	thriftList      = 9
+	thriftSet       = 10
+	thriftMap       = 11
+	thriftStructure = 12
+)
+
+var errThriftCorrupt = errors.New("thrift: corrupt input")
+
+// thriftWriter encodes structs. Nested structs are written
+// between beginStruct and endStruct.
+type thriftWriter struct {
+	buf []byte
+
+	// lastID is the stack of the last field ids of the structs.
+	lastID []int16
+}
+
+func (w *thriftWriter) field(id int16, tp byte) {
+	last := &w.lastID[len(w.lastID)-1]
+	if delta := id - *last; 0 < delta && delta <= 15 {
+		w.buf = append(w.buf, byte(delta)<<4|tp)
+	} else {
+		w.buf = append(w.buf, tp)
+		w.buf = binary.AppendVarint(w.buf, int64(id))
+	}
+	*last = id
+}
+
+func (w *thriftWriter) i32(id int16, v int32) {
+	w.field(id, thriftI32)
+	w.buf = binary.AppendVarint(w.buf, int64(v))
+}
+
+func (w *thriftWriter) i64(id int16, v int64) {
+	w.field(id, thriftI64)
+	w.buf = binary.AppendVarint(w.buf, v)
+}
+
+func (w *thriftWriter) i8(id int16, v int8) {
+	w.field(id, thriftByte)
+	w.buf = append(w.buf, byte(v))
+}
+
+func (w *thriftWriter) bool(id int16, v bool) {
+	if v {
+		w.field(id, thriftTrue)
+	} else {
+		w.field(id, thriftFalse)
+	}
+}
+
+func (w *thriftWriter) binary(id int16, s string) {
+	w.field(id, thriftBinary)
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+// list writes the list header. Elements follow with the
+// element functions, or as structs with beginElement.
+func (w *thriftWriter) list(id int16, elem byte, n int) {
+	w.field(id, thriftList)
+	if n < 15 {
+		w.buf = append(w.buf, byte(n)<<4|elem)
+		return
+	}
+	w.buf = append(w.buf, 0xF0|elem)
+	w.buf = binary.AppendUvarint(w.buf, uint64(n))
+}
+
+func (w *thriftWriter) elemI32(v int32) {
+	w.buf = binary.AppendVarint(w.buf, int64(v))
+}
+
+func (w *thriftWriter) elemBinary(s string) {
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+// beginStruct begins a struct field. id is ignored for
+// the top-level struct and the elements of lists.
+func (w *thriftWriter) beginStruct(id int16) {
+	if len(w.lastID) > 0 && id > 0 {
+		w.field(id, thriftStructure)
+	}
+	w.lastID = append(w.lastID, 0)
+}
+
+func (w *thriftWriter) beginElement() {
+	w.beginStruct(0)
+}
+
+func (w *thriftWriter) endStruct() {
+	w.buf = append(w.buf, thriftStop)
+	w.lastID = w.lastID[:len(w.lastID)-1]
+}
+
+// thriftStruct is a decoded struct by field id. The values are bool,
+// int64 for integers, float64, []byte, []interface{} for lists and sets,
+// and thriftStruct. Maps are skipped.
+type thriftStruct map[int16]interface{}
+
+func (s thriftStruct) int(id int16) (int64, bool) {
+	v, ok := s[id].(int64)
+	return v, ok
+}
+
+func (s thriftStruct) bool(id int16) (bool, bool) {
+	v, ok := s[id].(bool)
+	return v, ok
+}
+
+func (s thriftStruct) string(id int16) string {
+	v, _ := s[id].([]byte)
+	return string(v)
+}
+
+func (s thriftStruct) list(id int16) []interface{} {
+	v, _ := s[id].([]interface{})
+	return v
+}
+
+func (s thriftStruct) structure(id int16) thriftStruct {
+	v, _ := s[id].(thriftStruct)
+	return v
+}
+
+// thriftReader decodes structs from a byte slice.
+type thriftReader struct {
+	data  []byte
+	pos   int
+	depth int
+}
+
+// readThriftStruct decodes a struct, and returns the number of bytes read.
+func readThriftStruct(data []byte) (thriftStruct, int, error) {
+	r := &thriftReader{data: data}
+	s, err := r.readStruct()
+	if err != nil {
+		return nil, 0, err
+	}
+	return s, r.pos, nil
+}
+
+func (r *thriftReader) readByte() (byte, error) {
+	if r.pos >= len(r.data) {
+		return 0, errThriftCorrupt
+	}
+	b := r.data[r.pos]
+	r.pos++
+	return b, nil
+}
+
+func (r *thriftReader) readVarint() (int64, error) {
+	v, n := binary.Varint(r.data[r.pos:])
+	if n <= 0 {
+		return 0, errThriftCorrupt
+	}
+	r.pos += n
+	return v, nil
+}
+
+func (r *thriftReader) readUvarint() (uint64, error) {
+	v, n := binary.Uvarint(r.data[r.pos:])
+	if n <= 0 {
+		return 0, errThriftCorrupt
+	}
+	r.pos += n
+	return v, nil
+}
+
+func (r *thriftReader) readStruct() (thriftStruct, error) {
+	r.depth++
+	defer func() { r.depth-- }()
+	if r.depth > 64 {
+		return nil, fmt.Errorf("thrift: nested too deep")
+	}
+
+	s := make(thriftStruct)
+	var last int16
+	for {
+		b, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		if b == thriftStop {
+			return s, nil
+		}
+		tp := b & 0x0F
+		id := last + int16(b>>4)
+		if b>>4 == 0 {
+			v, err := r.readVarint()
+			if err != nil {
+				return nil, err
+			}
+			id = int16(v)
+		}
+		last = id
+
+		var v interface{}
+		switch tp {
+		case thriftTrue:
+			v = true
+		case thriftFalse:
+			v = false
+		default:
+			v, err = r.readValue(tp)
+			if err != nil {
+				return nil, err
+			}
+		}
+		s[id] = v
+	}
+}
+
+func (r *thriftReader) readValue(tp byte) (interface{}, error) {
+	switch tp {
+	case thriftTrue, thriftFalse:
+		// booleans in lists are one byte
+		b, err := r.readByte()
+		return b == thriftTrue, err
+	case thriftByte:
+		b, err := r.readByte()
+		return int64(int8(b)), err
+	case thriftI16, thriftI32, thriftI64:
+		return r.readVarint()
+	case thriftDouble:
+		if r.pos+8 > len(r.data) {
+			return nil, errThriftCorrupt
+		}
+		v := math.Float64frombits(binary.LittleEndian.Uint64(r.data[r.pos:]))
+		r.pos += 8
+		return v, nil
+	case thriftBinary:
+		n, err := r.readUvarint()
+		if err != nil {
+			return nil, err
+		}
+		if n > uint64(len(r.data)-r.pos) {
+			return nil, errThriftCorrupt
+		}
+		v := r.data[r.pos : r.pos+int(n)]
+		r.pos += int(n)
+		return v, nil
+	case thriftList, thriftSet:
+		b, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		n := uint64(b >> 4)
+		if n == 15 {
+			if n, err = r.readUvarint(); err != nil {
+				return nil, err
+			}
+		}
+		if n > uint64(len(r.data)-r.pos) { // at least a byte per element
+			return nil, errThriftCorrupt
+		}
+		elems := make([]interface{}, n)
+		for i := range elems {
+			if elems[i], err = r.readValue(b & 0x0F); err != nil {
+				return nil, err
+			}
+		}
+		return elems, nil
+	case thriftMap:
+		n, err := r.readUvarint()
+		if err != nil || n == 0 {
+			return nil, err
+		}
+		b, err := r.readByte()
+		if err != nil {
+			return nil, err
+		}
+		if n > uint64(len(r.data)-r.pos) {
+			return nil, errThriftCorrupt
+		}
+		for i := uint64(0); i < n; i++ {
+			if _, err := r.readValue(b >> 4); err != nil {
+				return nil, err
+			}
+			if _, err := r.readValue(b & 0x0F); err != nil {
+				return nil, err
+			}
+		}
+		return nil, nil
+	case thriftStructure:
+		return r.readStruct()
+	default:
+		return nil, fmt.Errorf("thrift: unknown type %d", tp)
+	}
+}
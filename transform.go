@@ -0,0 +1,305 @@
+package dataframe
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+type number interface {
+	int64 | uint64 | float64
+}
+
+// numbers returns the values of the Column in T,
+// which are false in valid for the null and NaN rows.
+func numbers[T number](c Column, conv func(v Value) (T, bool)) ([]T, []bool) {
+	n := c.Count()
+	vs, valid := make([]T, n), make([]bool, n)
+	for row := range vs {
+		if v := valueAt(c, row); !v.IsNull() {
+			vs[row], valid[row] = conv(v)
+			valid[row] = valid[row] && vs[row] == vs[row] // NaN
+		}
+	}
+	return vs, valid
+}
+
+// numberColumn returns the Column of the values,
+// with nulls for the rows that are false in valid.
+func numberColumn[T number](fd Field, vs []T, valid []bool, to func(x T) Value) Column {
+	for _, ok := range valid {
+		if !ok {
+			fd.Nullable = true
+			break
+		}
+	}
+	col := NewColumnField(fd)
+	for i, x := range vs {
+		if valid[i] {
+			col.PushBack(to(x))
+		} else {
+			col.PushBack(NewNullValue())
+		}
+	}
+	return col
+}
+
+func durationValue(v Value) (int64, bool) {
+	d, ok := v.Duration()
+	return int64(d), ok
+}
+
+func int64Of(x int64) Value     { return Int64(x) }
+func uint64Of(x uint64) Value   { return Uint64(x) }
+func float64Of(x float64) Value { return Float64(x) }
+func durationOf(x int64) Value  { return GoDuration(time.Duration(x)) }
+
+// cumulate replaces each non-null value with the accumulation
+// of op over the non-null values up to the row.
+func cumulate[T number](vs []T, valid []bool, name string) {
+	var op func(acc, x T) T
+	switch name {
+	case "cumsum":
+		op = func(acc, x T) T { return acc + x }
+	case "cumprod":
+		op = func(acc, x T) T { return acc * x }
+	case "cummax":
+		op = func(acc, x T) T { return max(acc, x) }
+	default:
+		op = func(acc, x T) T { return min(acc, x) }
+	}
+	started := false
+	var acc T
+	for i, x := range vs {
+		if !valid[i] {
+			continue
+		}
+		if started {
+			acc = op(acc, x)
+		} else {
+			acc, started = x, true
+		}
+		vs[i] = acc
+	}
+}
+
+// cumulative returns the Column of the cumulative function
+// of the numeric Column, in the data type of the Column.
+func (c *column) cumulative(name string) (Column, error) {
+	fd := c.Field()
+	out := Field{Name: fd.Name + "_" + name, Type: fd.Type}
+	switch fd.Type {
+	case INT64:
+		vs, valid := numbers(c, Value.Int64)
+		cumulate(vs, valid, name)
+		return numberColumn(out, vs, valid, int64Of), nil
+	case UINT64:
+		vs, valid := numbers(c, Value.Uint64)
+		cumulate(vs, valid, name)
+		return numberColumn(out, vs, valid, uint64Of), nil
+	case FLOAT64:
+		vs, valid := numbers(c, Value.Float64)
+		cumulate(vs, valid, name)
+		return numberColumn(out, vs, valid, float64Of), nil
+	case DURATION:
+		if name != "cumprod" {
+			vs, valid := numbers(c, durationValue)
+			cumulate(vs, valid, name)
+			return numberColumn(out, vs, valid, durationOf), nil
+		}
+	}
+	return nil, fmt.Errorf("%s is not supported for %q of %s", name, fd.Name, fd.Type)
+}
+
+func (c *column) CumSum() (Column, error) {
+	return c.cumulative("cumsum")
+}
+
+func (c *column) CumProd() (Column, error) {
+	return c.cumulative("cumprod")
+}
+
+func (c *column) CumMax() (Column, error) {
+	return c.cumulative("cummax")
+}
+
+func (c *column) CumMin() (Column, error) {
+	return c.cumulative("cummin")
+}
+
+// lagged returns the row periods before each row, which is -1
+// if either of the rows is null or out of the Column.
+func lagged(valid []bool, periods int) []int {
+	prev := make([]int, len(valid))
+	for i := range prev {
+		prev[i] = -1
+		if j := i - periods; j >= 0 && j < len(valid) && valid[i] && valid[j] {
+			prev[i] = j
+		}
+	}
+	return prev
+}
+
+func (c *column) Diff(periods int) (Column, error) {
+	fd := c.Field()
+	out := Field{Name: fd.Name + "_diff", Type: fd.Type}
+	var vs []int64
+	var valid []bool
+	to := int64Of
+	switch fd.Type {
+	case INT64:
+		vs, valid = numbers(c, Value.Int64)
+	case UINT64:
+		// the difference wraps around into int64
+		us, uvalid := numbers(c, Value.Uint64)
+		vs, valid = make([]int64, len(us)), uvalid
+		for i, u := range us {
+			vs[i] = int64(u)
+		}
+		out.Type = INT64
+	case DURATION:
+		vs, valid = numbers(c, durationValue)
+		to = durationOf
+	case TIME:
+		vs, valid = numbers(c, func(v Value) (int64, bool) {
+			t, ok := v.Time(fd.Layout)
+			return t.UnixNano(), ok && !t.Before(minUnixNano) && !t.After(maxUnixNano)
+		})
+		out.Type, to = DURATION, durationOf
+	case FLOAT64:
+		fs, fvalid := numbers(c, Value.Float64)
+		prev := lagged(fvalid, periods)
+		diffs := make([]float64, len(fs))
+		for i, j := range prev {
+			if j >= 0 {
+				diffs[i] = fs[i] - fs[j]
+			}
+		}
+		return numberColumn(out, diffs, nonNegative(prev), float64Of), nil
+	default:
+		return nil, fmt.Errorf("diff is not supported for %q of %s", fd.Name, fd.Type)
+	}
+	prev := lagged(valid, periods)
+	diffs := make([]int64, len(vs))
+	for i, j := range prev {
+		if j >= 0 {
+			diffs[i] = vs[i] - vs[j]
+		}
+	}
+	return numberColumn(out, diffs, nonNegative(prev), to), nil
+}
+
+func nonNegative(rows []int) []bool {
+	ok := make([]bool, len(rows))
+	for i, row := range rows {
+		ok[i] = row >= 0
+	}
+	return ok
+}
+
+func (c *column) PctChange(periods int) (Column, error) {
+	fd := c.Field()
+	switch fd.Type {
+	case INT64, UINT64, FLOAT64, DURATION:
+	default:
+		return nil, fmt.Errorf("pct_change is not supported for %q of %s", fd.Name, fd.Type)
+	}
+	fs, valid := numbers(c, func(v Value) (float64, bool) { return valueFloat64(fd.Type, v), true })
+	prev := lagged(valid, periods)
+	pcts := make([]float64, len(fs))
+	for i, j := range prev {
+		if j >= 0 {
+			pcts[i] = fs[i]/fs[j] - 1
+		}
+	}
+	return numberColumn(Field{Name: fd.Name + "_pct_change", Type: FLOAT64}, pcts, nonNegative(prev), float64Of), nil
+}
+
+func (c *column) Shift(periods int, fill Value) (Column, error) {
+	fd := c.Field()
+	fd.Name += "_shift"
+	if fill == nil || fill.IsNull() {
+		fd.Nullable = true
+		fill = NewNullValue()
+	} else {
+		var err error
+		if fill, err = typedValue(fd, -1, fill); err != nil {
+			return nil, err
+		}
+	}
+	n := c.Count()
+	col := NewColumnField(fd)
+	for i := 0; i < n; i++ {
+		if j := i - periods; j >= 0 && j < n {
+			col.PushBack(valueAt(c, j))
+		} else {
+			col.PushBack(fill)
+		}
+	}
+	return col, nil
+}
+
+// RankMethod defines the rank of the tied values.
+type RankMethod int
+
+const (
+	// RankMethod_Average ranks the ties with the average of their ranks.
+	RankMethod_Average RankMethod = iota
+
+	// RankMethod_Min ranks the ties with the lowest of their ranks.
+	RankMethod_Min
+
+	// RankMethod_Max ranks the ties with the highest of their ranks.
+	RankMethod_Max
+
+	// RankMethod_First ranks the ties in the order of the rows.
+	RankMethod_First
+
+	// RankMethod_Dense ranks the ties as RankMethod_Min, but
+	// the next values are ranked one higher than the ties.
+	RankMethod_Dense
+)
+
+func (c *column) Rank(method RankMethod) (Column, error) {
+	if method < RankMethod_Average || method > RankMethod_Dense {
+		return nil, fmt.Errorf("rank method %d is unknown", method)
+	}
+	fd := c.Field()
+	n := c.Count()
+	sc := newSortColumn(c, n, fd.Type, fd.Layout)
+	var rows []int
+	for row, ok := range sc.valid {
+		if ok {
+			rows = append(rows, row)
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool { return sc.cmp(rows[i], rows[j]) < 0 })
+
+	ranks := make([]float64, n)
+	dense := 0
+	for s := 0; s < len(rows); {
+		e := s + 1
+		for e < len(rows) && sc.cmp(rows[s], rows[e]) == 0 {
+			e++
+		}
+		dense++
+		for k := s; k < e; k++ {
+			var rank float64
+			switch method {
+			case RankMethod_Average:
+				rank = float64(s+1+e) / 2
+			case RankMethod_Min:
+				rank = float64(s + 1)
+			case RankMethod_Max:
+				rank = float64(e)
+			case RankMethod_First:
+				rank = float64(k + 1)
+			default:
+				rank = float64(dense)
+			}
+			ranks[rows[k]] = rank
+		}
+		s = e
+	}
+	return numberColumn(Field{Name: fd.Name + "_rank", Type: FLOAT64}, ranks, sc.valid, float64Of), nil
+}
@@ -0,0 +1,117 @@
+package dataframe
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCumulative(t *testing.T) {
+	col := NewColumnTyped("throughput", INT64)
+	for _, v := range []Value{Int64(3), Int64(1), NewNullValue(), Int64(4), Int64(-2)} {
+		col.PushBack(v)
+	}
+	tests := []struct {
+		fn       func(c Column) (Column, error)
+		header   string
+		expected []string
+	}{
+		{Column.CumSum, "throughput_cumsum", []string{"3", "4", "", "8", "6"}},
+		{Column.CumProd, "throughput_cumprod", []string{"3", "3", "", "12", "-24"}},
+		{Column.CumMax, "throughput_cummax", []string{"3", "3", "", "4", "4"}},
+		{Column.CumMin, "throughput_cummin", []string{"3", "1", "", "1", "-2"}},
+		{func(c Column) (Column, error) { return c.Diff(1) }, "throughput_diff", []string{"", "-2", "", "", "-6"}},
+		{func(c Column) (Column, error) { return c.Diff(-1) }, "throughput_diff", []string{"2", "", "", "6", ""}},
+		{func(c Column) (Column, error) { return c.PctChange(1) }, "throughput_pct_change", []string{"", "-0.6666666666666667", "", "", "-1.5"}},
+		{func(c Column) (Column, error) { return c.Shift(2, Int64(0)) }, "throughput_shift", []string{"0", "0", "3", "1", ""}},
+		{func(c Column) (Column, error) { return c.Shift(-1, nil) }, "throughput_shift", []string{"1", "", "4", "-2", ""}},
+	}
+	for i, tt := range tests {
+		got, err := tt.fn(col)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Header() != tt.header {
+			t.Fatalf("#%d: expected %q, got %q", i, tt.header, got.Header())
+		}
+		if rows := got.Rows(); !reflect.DeepEqual(rows, tt.expected) {
+			t.Fatalf("#%d: expected %v, got %v", i, tt.expected, rows)
+		}
+	}
+
+	if _, err := col.Shift(1, NewStringValue("x")); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := NewColumn("host").CumSum(); err == nil {
+		t.Fatal("expected error")
+	}
+	if _, err := NewColumnTyped("took", DURATION).CumProd(); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestCumSumAggregated(t *testing.T) {
+	fr, _, err := NewFromCSVInfer(nil, "testdata/bench-01-etcd-aggregated.csv", InferOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	throughput, err := fr.Column("throughput")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := throughput.CumSum()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := fr.AddColumn(got); err != nil {
+		t.Fatal(err)
+	}
+	expected, err := fr.Column("cumulative_throughput")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got.Rows(), expected.Rows()) {
+		t.Fatalf("expected %v, got %v", expected.Rows(), got.Rows())
+	}
+
+	ts, err := fr.Column("unix_ts")
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff, err := ts.Diff(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, err := diff.Value(1); err != nil || !v.EqualTo(GoDuration(time.Second)) {
+		t.Fatalf("expected 1s, got %v (%v)", v, err)
+	}
+}
+
+func TestRank(t *testing.T) {
+	col := NewColumnTyped("latency", FLOAT64)
+	for _, v := range []Value{Float64(2), Float64(1), NewNullValue(), Float64(2), Float64(3), Float64(2)} {
+		col.PushBack(v)
+	}
+	tests := []struct {
+		method   RankMethod
+		expected []string
+	}{
+		{RankMethod_Average, []string{"3", "1", "", "3", "5", "3"}},
+		{RankMethod_Min, []string{"2", "1", "", "2", "5", "2"}},
+		{RankMethod_Max, []string{"4", "1", "", "4", "5", "4"}},
+		{RankMethod_First, []string{"2", "1", "", "3", "5", "4"}},
+		{RankMethod_Dense, []string{"2", "1", "", "2", "3", "2"}},
+	}
+	for _, tt := range tests {
+		got, err := col.Rank(tt.method)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rows := got.Rows(); !reflect.DeepEqual(rows, tt.expected) {
+			t.Fatalf("rank %d: expected %v, got %v", tt.method, tt.expected, rows)
+		}
+	}
+	if _, err := col.Rank(RankMethod_Dense + 1); err == nil {
+		t.Fatal("expected error")
+	}
+}
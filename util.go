@@ -11,9 +11,33 @@ func openToRead(fpath string) (*os.File, error) {
 }
 
 func openToOverwrite(fpath string) (*os.File, error) {
-	f, err := os.OpenFile(fpath, os.O_RDWR|os.O_TRUNC|os.O_CREATE, 0777)
+	f, err := os.OpenFile(fpath, os.O_RDWR|os.O_TRUNC|os.O_CREATE, 0644)
 	if err != nil {
 		return nil, err
 	}
 	return f, nil
 }
+
+// rowCount returns the number of rows of the longest Column.
+func rowCount(cols []Column) int {
+	n := 0
+	for _, col := range cols {
+		if c := col.Count(); n < c {
+			n = c
+		}
+	}
+	return n
+}
+
+// valueAt returns the Value in the row, or null if the row
+// is out of the Column.
+func valueAt(col Column, row int) Value {
+	if row < 0 {
+		return NewNullValue()
+	}
+	v, err := col.Value(row)
+	if err != nil {
+		return NewNullValue()
+	}
+	return v
+}
@@ -16,15 +16,22 @@ type Value interface {
 	// Float64 parses Value to float64. It returns false if not possible.
 	Float64() (float64, bool)
 
+	// Bool parses Value to bool. It returns false if not possible.
+	Bool() (bool, bool)
+
 	// Time parses Value to time.Time based on the layout. It returns false if not possible.
 	Time(layout string) (time.Time, bool)
 
 	// Duration parses Value to time.Duration. It returns false if not possible.
 	Duration() (time.Duration, bool)
 
-	// IsNil returns true if the Value is nil.
+	// IsNil returns true if the Value is nil. Null Values are nil,
+	// and so are empty strings and zero times.
 	IsNil() bool
 
+	// IsNull returns true if the Value is missing.
+	IsNull() bool
+
 	// EqualTo returns true if the Value is equal to v.
 	EqualTo(v Value) bool
 
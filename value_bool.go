@@ -0,0 +1,65 @@
+package dataframe
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Bool defines bool data types.
+type Bool bool
+
+// NewBoolValue takes bool and returns Value.
+func NewBoolValue(v interface{}) Value {
+	switch t := v.(type) {
+	case bool:
+		return Bool(t)
+	default:
+		panic(fmt.Errorf("%v(%T) is not supported yet", v, v))
+	}
+}
+
+func (b Bool) String() (string, bool) {
+	return strconv.FormatBool(bool(b)), true
+}
+
+func (b Bool) Int64() (int64, bool) {
+	return 0, false
+}
+
+func (b Bool) Uint64() (uint64, bool) {
+	return 0, false
+}
+
+func (b Bool) Float64() (float64, bool) {
+	return 0, false
+}
+
+func (b Bool) Bool() (bool, bool) {
+	return bool(b), true
+}
+
+func (b Bool) Time(layout string) (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (b Bool) Duration() (time.Duration, bool) {
+	return 0, false
+}
+
+func (b Bool) IsNil() bool {
+	return false
+}
+
+func (b Bool) IsNull() bool {
+	return false
+}
+
+func (b Bool) EqualTo(v Value) bool {
+	tv, ok := v.(Bool)
+	return ok && b == tv
+}
+
+func (b Bool) Copy() Value {
+	return b
+}
@@ -0,0 +1,16 @@
+package dataframe
+
+import "testing"
+
+func TestBoolValue(t *testing.T) {
+	v := NewBoolValue(true)
+	if bv, ok := v.Bool(); !ok || !bv {
+		t.Fatalf("expected true, got %v(%v)", bv, ok)
+	}
+	if sv, ok := v.String(); !ok || sv != "true" {
+		t.Fatalf("expected 'true', got %q(%v)", sv, ok)
+	}
+	if bv, ok := NewStringValue("false").Bool(); !ok || bv {
+		t.Fatalf("expected false, got %v(%v)", bv, ok)
+	}
+}
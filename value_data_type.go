@@ -17,6 +17,21 @@ const (
 
 	// TIME represents Go time.Time type.
 	TIME
+
+	// INT64 represents Go signed integer types.
+	INT64
+
+	// UINT64 represents Go unsigned integer types.
+	UINT64
+
+	// FLOAT64 represents Go float32 and float64 types.
+	FLOAT64
+
+	// BOOL represents Go bool type.
+	BOOL
+
+	// DURATION represents Go time.Duration type.
+	DURATION
 )
 
 func (dt DATA_TYPE) String() string {
@@ -25,16 +40,55 @@ func (dt DATA_TYPE) String() string {
 		return "STRING"
 	case TIME:
 		return "TIME"
+	case INT64:
+		return "INT64"
+	case UINT64:
+		return "UINT64"
+	case FLOAT64:
+		return "FLOAT64"
+	case BOOL:
+		return "BOOL"
+	case DURATION:
+		return "DURATION"
 	default:
 		panic(fmt.Errorf("DATA_TYPE %d is unknown", dt))
 	}
 }
 
+// MarshalText encodes DATA_TYPE as its name.
+func (dt DATA_TYPE) MarshalText() ([]byte, error) {
+	if dt > DURATION {
+		return nil, fmt.Errorf("DATA_TYPE %d is unknown", dt)
+	}
+	return []byte(dt.String()), nil
+}
+
+// UnmarshalText decodes DATA_TYPE from its name.
+func (dt *DATA_TYPE) UnmarshalText(text []byte) error {
+	for tp := STRING; tp <= DURATION; tp++ {
+		if tp.String() == string(text) {
+			*dt = tp
+			return nil
+		}
+	}
+	return fmt.Errorf("DATA_TYPE %q is unknown", text)
+}
+
 // ReflectTypeOf returns the DATA_TYPE.
 func ReflectTypeOf(v interface{}) DATA_TYPE {
 	switch v.(type) {
 	case time.Time:
 		return TIME
+	case time.Duration:
+		return DURATION
+	case int, int8, int16, int32, int64:
+		return INT64
+	case uint, uint8, uint16, uint32, uint64:
+		return UINT64
+	case float32, float64:
+		return FLOAT64
+	case bool:
+		return BOOL
 	default:
 		return STRING
 	}
@@ -45,6 +99,16 @@ func ToValue(v interface{}) Value {
 	switch ReflectTypeOf(v) {
 	case TIME:
 		return NewTimeValue(v)
+	case DURATION:
+		return NewDurationValue(v)
+	case INT64:
+		return NewInt64Value(v)
+	case UINT64:
+		return NewUint64Value(v)
+	case FLOAT64:
+		return NewFloat64Value(v)
+	case BOOL:
+		return NewBoolValue(v)
 	default:
 		return NewStringValue(v)
 	}
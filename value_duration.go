@@ -0,0 +1,67 @@
+package dataframe
+
+import (
+	"fmt"
+	"time"
+)
+
+// GoDuration defines time.Duration data types.
+type GoDuration time.Duration
+
+// NewDurationValue takes time.Duration and returns Value.
+func NewDurationValue(v interface{}) Value {
+	switch t := v.(type) {
+	case time.Duration:
+		return GoDuration(t)
+	default:
+		panic(fmt.Errorf("%v(%T) is not supported yet", v, v))
+	}
+}
+
+func (gd GoDuration) String() (string, bool) {
+	return time.Duration(gd).String(), true
+}
+
+func (gd GoDuration) Int64() (int64, bool) {
+	return int64(gd), true
+}
+
+func (gd GoDuration) Uint64() (uint64, bool) {
+	if gd < 0 {
+		return 0, false
+	}
+	return uint64(gd), true
+}
+
+func (gd GoDuration) Float64() (float64, bool) {
+	return float64(gd), true
+}
+
+func (gd GoDuration) Bool() (bool, bool) {
+	return false, false
+}
+
+func (gd GoDuration) Time(layout string) (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (gd GoDuration) Duration() (time.Duration, bool) {
+	return time.Duration(gd), true
+}
+
+func (gd GoDuration) IsNil() bool {
+	return false
+}
+
+func (gd GoDuration) IsNull() bool {
+	return false
+}
+
+func (gd GoDuration) EqualTo(v Value) bool {
+	tv, ok := v.(GoDuration)
+	return ok && gd == tv
+}
+
+func (gd GoDuration) Copy() Value {
+	return gd
+}
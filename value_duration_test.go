@@ -0,0 +1,19 @@
+package dataframe
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDurationValue(t *testing.T) {
+	v := NewDurationValue(3 * time.Second)
+	if dv, ok := v.Duration(); !ok || dv != 3*time.Second {
+		t.Fatalf("expected 3s, got %v(%v)", dv, ok)
+	}
+	if sv, ok := v.String(); !ok || sv != "3s" {
+		t.Fatalf("expected '3s', got %q(%v)", sv, ok)
+	}
+	if !v.EqualTo(NewDurationValue(3 * time.Second)) {
+		t.Fatalf("EqualTo expected 'true' for %v", v)
+	}
+}
@@ -0,0 +1,80 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Float64 defines float64 data types.
+type Float64 float64
+
+// NewFloat64Value takes any float and returns Value.
+func NewFloat64Value(v interface{}) Value {
+	switch t := v.(type) {
+	case float32:
+		return Float64(t)
+	case float64:
+		return Float64(t)
+	default:
+		panic(fmt.Errorf("%v(%T) is not supported yet", v, v))
+	}
+}
+
+func (f Float64) String() (string, bool) {
+	return strconv.FormatFloat(float64(f), 'f', -1, 64), true
+}
+
+// Int64 returns false if the float has a fractional part
+// or does not fit in int64.
+func (f Float64) Int64() (int64, bool) {
+	fv := float64(f)
+	if fv != math.Trunc(fv) || fv < math.MinInt64 || fv >= math.MaxInt64 {
+		return 0, false
+	}
+	return int64(fv), true
+}
+
+// Uint64 returns false if the float has a fractional part
+// or does not fit in uint64.
+func (f Float64) Uint64() (uint64, bool) {
+	fv := float64(f)
+	if fv != math.Trunc(fv) || fv < 0 || fv >= math.MaxUint64 {
+		return 0, false
+	}
+	return uint64(fv), true
+}
+
+func (f Float64) Float64() (float64, bool) {
+	return float64(f), true
+}
+
+func (f Float64) Bool() (bool, bool) {
+	return false, false
+}
+
+func (f Float64) Time(layout string) (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (f Float64) Duration() (time.Duration, bool) {
+	return 0, false
+}
+
+func (f Float64) IsNil() bool {
+	return false
+}
+
+func (f Float64) IsNull() bool {
+	return false
+}
+
+func (f Float64) EqualTo(v Value) bool {
+	tv, ok := v.(Float64)
+	return ok && f == tv
+}
+
+func (f Float64) Copy() Value {
+	return f
+}
@@ -0,0 +1,19 @@
+package dataframe
+
+import "testing"
+
+func TestFloat64Value(t *testing.T) {
+	v := NewFloat64Value(2.5)
+	if fv, ok := v.Float64(); !ok || fv != 2.5 {
+		t.Fatalf("expected 2.5, got %f(%v)", fv, ok)
+	}
+	if iv, ok := v.Int64(); ok {
+		t.Fatalf("expected false, got %d(%v)", iv, ok)
+	}
+	if sv, ok := v.String(); !ok || sv != "2.5" {
+		t.Fatalf("expected '2.5', got %q(%v)", sv, ok)
+	}
+	if iv, ok := NewFloat64Value(float32(3)).Int64(); !ok || iv != 3 {
+		t.Fatalf("expected 3, got %d(%v)", iv, ok)
+	}
+}
@@ -0,0 +1,76 @@
+package dataframe
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Int64 defines int64 data types.
+type Int64 int64
+
+// NewInt64Value takes any signed integer and returns Value.
+func NewInt64Value(v interface{}) Value {
+	switch t := v.(type) {
+	case int:
+		return Int64(t)
+	case int8:
+		return Int64(t)
+	case int16:
+		return Int64(t)
+	case int32:
+		return Int64(t)
+	case int64:
+		return Int64(t)
+	default:
+		panic(fmt.Errorf("%v(%T) is not supported yet", v, v))
+	}
+}
+
+func (i Int64) String() (string, bool) {
+	return strconv.FormatInt(int64(i), 10), true
+}
+
+func (i Int64) Int64() (int64, bool) {
+	return int64(i), true
+}
+
+func (i Int64) Uint64() (uint64, bool) {
+	if i < 0 {
+		return 0, false
+	}
+	return uint64(i), true
+}
+
+func (i Int64) Float64() (float64, bool) {
+	return float64(i), true
+}
+
+func (i Int64) Bool() (bool, bool) {
+	return false, false
+}
+
+func (i Int64) Time(layout string) (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (i Int64) Duration() (time.Duration, bool) {
+	return time.Duration(i), true
+}
+
+func (i Int64) IsNil() bool {
+	return false
+}
+
+func (i Int64) IsNull() bool {
+	return false
+}
+
+func (i Int64) EqualTo(v Value) bool {
+	tv, ok := v.(Int64)
+	return ok && i == tv
+}
+
+func (i Int64) Copy() Value {
+	return i
+}
@@ -0,0 +1,25 @@
+package dataframe
+
+import "testing"
+
+func TestInt64Value(t *testing.T) {
+	v := NewInt64Value(-10)
+	if iv, ok := v.Int64(); !ok || iv != -10 {
+		t.Fatalf("expected -10, got %d(%v)", iv, ok)
+	}
+	if fv, ok := v.Float64(); !ok || fv != -10 {
+		t.Fatalf("expected -10, got %f(%v)", fv, ok)
+	}
+	if uv, ok := v.Uint64(); ok {
+		t.Fatalf("expected false, got %d(%v)", uv, ok)
+	}
+	if sv, ok := v.String(); !ok || sv != "-10" {
+		t.Fatalf("expected '-10', got %q(%v)", sv, ok)
+	}
+	if !v.EqualTo(NewInt64Value(int64(-10))) {
+		t.Fatalf("EqualTo expected 'true' for %v", v)
+	}
+	if v.EqualTo(NewStringValue(-10)) {
+		t.Fatalf("EqualTo expected 'false' for %v", v)
+	}
+}
@@ -0,0 +1,57 @@
+package dataframe
+
+import "time"
+
+// Null defines a missing value. It is different from
+// an empty String or a zero GoTime, which are nil but not null.
+type Null struct{}
+
+// NewNullValue returns a null Value.
+func NewNullValue() Value {
+	return Null{}
+}
+
+func (n Null) String() (string, bool) {
+	return "", false
+}
+
+func (n Null) Int64() (int64, bool) {
+	return 0, false
+}
+
+func (n Null) Uint64() (uint64, bool) {
+	return 0, false
+}
+
+func (n Null) Float64() (float64, bool) {
+	return 0, false
+}
+
+func (n Null) Bool() (bool, bool) {
+	return false, false
+}
+
+func (n Null) Time(layout string) (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (n Null) Duration() (time.Duration, bool) {
+	return 0, false
+}
+
+func (n Null) IsNil() bool {
+	return true
+}
+
+func (n Null) IsNull() bool {
+	return true
+}
+
+// EqualTo returns true if v is also null.
+func (n Null) EqualTo(v Value) bool {
+	return v != nil && v.IsNull()
+}
+
+func (n Null) Copy() Value {
+	return n
+}
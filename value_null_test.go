@@ -0,0 +1,25 @@
+package dataframe
+
+import "testing"
+
+func TestNullValue(t *testing.T) {
+	v := NewNullValue()
+	if !v.IsNull() || !v.IsNil() {
+		t.Fatalf("expected null, got %v", v)
+	}
+	if fv, ok := v.Float64(); ok {
+		t.Fatalf("expected false, got %f(%v)", fv, ok)
+	}
+	if !v.EqualTo(NewNullValue()) {
+		t.Fatalf("EqualTo expected 'true' for %v", v)
+	}
+	if v.EqualTo(NewStringValue("")) {
+		t.Fatalf("EqualTo expected 'false' for %v", v)
+	}
+	if NewStringValue("").IsNull() {
+		t.Fatal("empty string expected non-null")
+	}
+	if NewTimeValueNil().IsNull() {
+		t.Fatal("zero time expected non-null")
+	}
+}
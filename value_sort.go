@@ -1,5 +1,7 @@
 package dataframe
 
+import "time"
+
 type ByStringAscending []Value
 
 func (vs ByStringAscending) Len() int {
@@ -95,3 +97,99 @@ func (vs ByDurationDescending) Less(i, j int) bool {
 	vs2, _ := vs[j].Duration()
 	return vs1 > vs2
 }
+
+// compareValues compares the Values in the data type, and returns -1, 0
+// or +1. Null Values and the Values that cannot be converted to the data
+// type sort after the others, and NaN sorts after the other numbers.
+func compareValues(tp DATA_TYPE, layout string, a, b Value) int {
+	if c, ok := compareNulls(a == nil || a.IsNull(), b == nil || b.IsNull()); ok {
+		return c
+	}
+	switch tp {
+	case INT64:
+		av, aok := a.Int64()
+		bv, bok := b.Int64()
+		if c, ok := compareNulls(!aok, !bok); ok {
+			return c
+		}
+		return compareOrdered(av, bv)
+	case UINT64:
+		av, aok := a.Uint64()
+		bv, bok := b.Uint64()
+		if c, ok := compareNulls(!aok, !bok); ok {
+			return c
+		}
+		return compareOrdered(av, bv)
+	case FLOAT64:
+		av, aok := a.Float64()
+		bv, bok := b.Float64()
+		if c, ok := compareNulls(!aok, !bok); ok {
+			return c
+		}
+		if c, ok := compareNulls(av != av, bv != bv); ok { // NaN
+			return c
+		}
+		return compareOrdered(av, bv)
+	case BOOL:
+		av, aok := a.Bool()
+		bv, bok := b.Bool()
+		if c, ok := compareNulls(!aok, !bok); ok {
+			return c
+		}
+		switch {
+		case av == bv:
+			return 0
+		case bv:
+			return -1
+		default:
+			return 1
+		}
+	case DURATION:
+		av, aok := a.Duration()
+		bv, bok := b.Duration()
+		if c, ok := compareNulls(!aok, !bok); ok {
+			return c
+		}
+		return compareOrdered(av, bv)
+	case TIME:
+		av, aok := a.Time(layout)
+		bv, bok := b.Time(layout)
+		if c, ok := compareNulls(!aok, !bok); ok {
+			return c
+		}
+		return av.Compare(bv)
+	default:
+		av, aok := a.String()
+		bv, bok := b.String()
+		if c, ok := compareNulls(!aok, !bok); ok {
+			return c
+		}
+		return compareOrdered(av, bv)
+	}
+}
+
+// compareNulls compares by nulls, which sort last. It returns
+// false if neither is null, and the values need to be compared.
+func compareNulls(an, bn bool) (int, bool) {
+	switch {
+	case an && bn:
+		return 0, true
+	case an:
+		return 1, true
+	case bn:
+		return -1, true
+	default:
+		return 0, false
+	}
+}
+
+func compareOrdered[T int64 | uint64 | float64 | string | time.Duration](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
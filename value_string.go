@@ -73,6 +73,11 @@ func (s String) Float64() (float64, bool) {
 	return f, err == nil
 }
 
+func (s String) Bool() (bool, bool) {
+	b, err := strconv.ParseBool(string(s))
+	return b, err == nil
+}
+
 func (s String) Time(layout string) (time.Time, bool) {
 	t, err := time.Parse(layout, string(s))
 	return t, err == nil
@@ -87,6 +92,10 @@ func (s String) IsNil() bool {
 	return len(s) == 0
 }
 
+func (s String) IsNull() bool {
+	return false
+}
+
 func (s String) EqualTo(v Value) bool {
 	tv, ok := v.(String)
 	return ok && s == tv
@@ -39,6 +39,10 @@ func (gt GoTime) Float64() (float64, bool) {
 	return 0, false
 }
 
+func (gt GoTime) Bool() (bool, bool) {
+	return false, false
+}
+
 func (gt GoTime) Time(layout string) (time.Time, bool) {
 	return time.Time(gt), true
 }
@@ -51,6 +55,10 @@ func (gt GoTime) IsNil() bool {
 	return time.Time(gt).IsZero()
 }
 
+func (gt GoTime) IsNull() bool {
+	return false
+}
+
 func (gt GoTime) EqualTo(v Value) bool {
 	tv, ok := v.(GoTime)
 	return ok && time.Time(gt).Equal(time.Time(tv))
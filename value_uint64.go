@@ -0,0 +1,80 @@
+package dataframe
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// Uint64 defines uint64 data types.
+type Uint64 uint64
+
+// NewUint64Value takes any unsigned integer and returns Value.
+func NewUint64Value(v interface{}) Value {
+	switch t := v.(type) {
+	case uint:
+		return Uint64(t)
+	case uint8:
+		return Uint64(t)
+	case uint16:
+		return Uint64(t)
+	case uint32:
+		return Uint64(t)
+	case uint64:
+		return Uint64(t)
+	default:
+		panic(fmt.Errorf("%v(%T) is not supported yet", v, v))
+	}
+}
+
+func (u Uint64) String() (string, bool) {
+	return strconv.FormatUint(uint64(u), 10), true
+}
+
+func (u Uint64) Int64() (int64, bool) {
+	if u > math.MaxInt64 {
+		return 0, false
+	}
+	return int64(u), true
+}
+
+func (u Uint64) Uint64() (uint64, bool) {
+	return uint64(u), true
+}
+
+func (u Uint64) Float64() (float64, bool) {
+	return float64(u), true
+}
+
+func (u Uint64) Bool() (bool, bool) {
+	return false, false
+}
+
+func (u Uint64) Time(layout string) (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (u Uint64) Duration() (time.Duration, bool) {
+	if u > math.MaxInt64 {
+		return 0, false
+	}
+	return time.Duration(u), true
+}
+
+func (u Uint64) IsNil() bool {
+	return false
+}
+
+func (u Uint64) IsNull() bool {
+	return false
+}
+
+func (u Uint64) EqualTo(v Value) bool {
+	tv, ok := v.(Uint64)
+	return ok && u == tv
+}
+
+func (u Uint64) Copy() Value {
+	return u
+}
@@ -0,0 +1,19 @@
+package dataframe
+
+import (
+	"math"
+	"testing"
+)
+
+func TestUint64Value(t *testing.T) {
+	v := NewUint64Value(uint64(math.MaxUint64))
+	if uv, ok := v.Uint64(); !ok || uv != math.MaxUint64 {
+		t.Fatalf("expected %d, got %d(%v)", uint64(math.MaxUint64), uv, ok)
+	}
+	if iv, ok := v.Int64(); ok {
+		t.Fatalf("expected false, got %d(%v)", iv, ok)
+	}
+	if sv, ok := v.String(); !ok || sv != "18446744073709551615" {
+		t.Fatalf("expected '18446744073709551615', got %q(%v)", sv, ok)
+	}
+}
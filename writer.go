@@ -0,0 +1,214 @@
+package dataframe
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// QuoteOption defines when to quote fields in CSV.
+type QuoteOption int
+
+const (
+	// QuoteOption_Minimal quotes fields only if needed,
+	// such as fields with delimiters, quotes or newlines.
+	QuoteOption_Minimal QuoteOption = iota
+
+	// QuoteOption_All quotes all fields.
+	QuoteOption_All
+
+	// QuoteOption_NonNumeric quotes all fields except
+	// INT64, UINT64, FLOAT64 columns and null values.
+	QuoteOption_NonNumeric
+
+	// QuoteOption_None never quotes fields. Writing fails
+	// if a field needs quotes.
+	QuoteOption_None
+)
+
+// WriteOptions configures writing CSV.
+type WriteOptions struct {
+	// Delimiter is the field delimiter. Zero uses comma.
+	// Use '\t' for TSV.
+	Delimiter rune
+
+	// Quote is the quoting policy.
+	Quote QuoteOption
+
+	// NoHeader is true to skip writing the header strings.
+	NoHeader bool
+
+	// NullString is written for null values.
+	NullString string
+
+	// FloatPrecision is the number of digits after the decimal point
+	// for FLOAT64 columns, where 0 writes no decimals as in "%.0f".
+	// Nil, or a negative precision, writes the smallest number of digits
+	// necessary to represent the value exactly.
+	FloatPrecision *int
+
+	// TimeLayout formats TIME columns. If empty, the layout of each
+	// column's Field is used, or time.Time.String if not specified.
+	// LayoutUnixSeconds writes integer seconds.
+	TimeLayout string
+
+	// UseCRLF is true to use \r\n as the line terminator.
+	UseCRLF bool
+}
+
+func (opts WriteOptions) delimiter() (rune, error) {
+	if opts.Delimiter == 0 {
+		return ',', nil
+	}
+	switch opts.Delimiter {
+	case '"', '\r', '\n', 0xFFFD:
+		return 0, fmt.Errorf("invalid delimiter %q", opts.Delimiter)
+	}
+	return opts.Delimiter, nil
+}
+
+// formatFunc returns the function that formats Values of the Column.
+func (opts WriteOptions) formatFunc(col Column) func(v Value) string {
+	fd := col.Field()
+	switch fd.Type {
+	case FLOAT64:
+		prec := -1
+		if opts.FloatPrecision != nil && *opts.FloatPrecision >= 0 {
+			prec = *opts.FloatPrecision
+		}
+		return func(v Value) string {
+			fv, _ := v.Float64()
+			return strconv.FormatFloat(fv, 'f', prec, 64)
+		}
+	case TIME:
+		layout := opts.TimeLayout
+		if layout == "" {
+			layout = fd.Layout
+		}
+		return func(v Value) string {
+			tv, ok := v.Time(layout)
+			if !ok {
+				s, _ := v.String()
+				return s
+			}
+			return formatTime(layout, tv)
+		}
+	default:
+		return func(v Value) string {
+			s, _ := v.String()
+			return s
+		}
+	}
+}
+
+func formatTime(layout string, t time.Time) string {
+	switch layout {
+	case "":
+		return t.Round(0).String() // strip monotonic clock reading
+	case LayoutUnixSeconds:
+		return strconv.FormatInt(t.Unix(), 10)
+	default:
+		return t.Format(layout)
+	}
+}
+
+func isNumeric(tp DATA_TYPE) bool {
+	return tp == INT64 || tp == UINT64 || tp == FLOAT64
+}
+
+// fieldNeedsQuotes follows the rules of encoding/csv.
+func fieldNeedsQuotes(field string, delim rune) bool {
+	if field == "" {
+		return false
+	}
+	if field == `\.` || strings.ContainsRune(field, delim) || strings.ContainsAny(field, "\"\r\n") {
+		return true
+	}
+	return field[0] == ' ' || field[0] == '\t'
+}
+
+func (f *frame) WriteCSV(w io.Writer, opts WriteOptions) error {
+	delim, err := opts.delimiter()
+	if err != nil {
+		return err
+	}
+	lineEnd := "\n"
+	if opts.UseCRLF {
+		lineEnd = "\r\n"
+	}
+
+	f.mu.Lock()
+	cols := make([]Column, len(f.columns))
+	copy(cols, f.columns)
+	f.mu.Unlock()
+
+	bw := bufio.NewWriter(w)
+	writeRow := func(fields []string, numeric []bool) error {
+		for i, field := range fields {
+			if i > 0 {
+				bw.WriteRune(delim)
+			}
+			quote := false
+			switch opts.Quote {
+			case QuoteOption_Minimal:
+				quote = fieldNeedsQuotes(field, delim)
+			case QuoteOption_All:
+				quote = true
+			case QuoteOption_NonNumeric:
+				quote = !numeric[i] || fieldNeedsQuotes(field, delim)
+			case QuoteOption_None:
+				if strings.ContainsRune(field, delim) || strings.ContainsAny(field, "\"\r\n") {
+					return fmt.Errorf("field %q needs quotes", field)
+				}
+			}
+			if !quote {
+				bw.WriteString(field)
+				continue
+			}
+			bw.WriteByte('"')
+			bw.WriteString(strings.Replace(field, `"`, `""`, -1))
+			bw.WriteByte('"')
+		}
+		_, err := bw.WriteString(lineEnd)
+		return err
+	}
+
+	fields := make([]string, len(cols))
+	numeric := make([]bool, len(cols))
+	if !opts.NoHeader {
+		for i, col := range cols {
+			fields[i] = col.Header()
+		}
+		if err := writeRow(fields, numeric); err != nil {
+			return err
+		}
+	}
+
+	var rowN int
+	formats := make([]func(Value) string, len(cols))
+	numericCols := make([]bool, len(cols))
+	for i, col := range cols {
+		if n := col.Count(); rowN < n {
+			rowN = n
+		}
+		formats[i] = opts.formatFunc(col)
+		numericCols[i] = isNumeric(col.DataType())
+	}
+	for rowIdx := 0; rowIdx < rowN; rowIdx++ {
+		for colIdx, col := range cols {
+			v, err := col.Value(rowIdx)
+			if err != nil || v.IsNull() {
+				fields[colIdx], numeric[colIdx] = opts.NullString, true
+				continue
+			}
+			fields[colIdx], numeric[colIdx] = formats[colIdx](v), numericCols[colIdx]
+		}
+		if err := writeRow(fields, numeric); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
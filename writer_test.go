@@ -0,0 +1,99 @@
+package dataframe
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestWriteCSV(t *testing.T) {
+	schema := Schema{Fields: []Field{
+		{Name: "unix_ts", Type: TIME, Layout: LayoutUnixSeconds},
+		{Name: "STATE", Type: STRING},
+		{Name: "avg_latency_ms", Type: FLOAT64, Nullable: true},
+	}}
+	rows := [][]string{
+		{"1458757864", "R (running)", "4.484004"},
+		{"1458757865", "S (sleeping)", ""},
+		{"1458757866", "say \"hi\", bye", "1.5"},
+	}
+	fr, err := NewFromRowsTyped(schema.Headers(), rows, schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	prec2, prec0 := 2, 0
+	tests := []struct {
+		opts     WriteOptions
+		expected string
+	}{
+		{
+			WriteOptions{},
+			"unix_ts,STATE,avg_latency_ms\n1458757864,R (running),4.484004\n1458757865,S (sleeping),\n1458757866,\"say \"\"hi\"\", bye\",1.5\n",
+		},
+		{
+			WriteOptions{Delimiter: '\t', NoHeader: true, NullString: "NA", FloatPrecision: &prec2},
+			"1458757864\tR (running)\t4.48\n1458757865\tS (sleeping)\tNA\n1458757866\t\"say \"\"hi\"\", bye\"\t1.50\n",
+		},
+		{
+			WriteOptions{NoHeader: true, FloatPrecision: &prec0},
+			"1458757864,R (running),4\n1458757865,S (sleeping),\n1458757866,\"say \"\"hi\"\", bye\",2\n",
+		},
+		{
+			WriteOptions{Quote: QuoteOption_NonNumeric, TimeLayout: "2006-01-02", UseCRLF: true},
+			"\"unix_ts\",\"STATE\",\"avg_latency_ms\"\r\n\"2016-03-23\",\"R (running)\",4.484004\r\n\"2016-03-23\",\"S (sleeping)\",\r\n\"2016-03-23\",\"say \"\"hi\"\", bye\",1.5\r\n",
+		},
+	}
+	for i, tt := range tests {
+		var buf bytes.Buffer
+		if err := fr.WriteCSV(&buf, tt.opts); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != tt.expected {
+			t.Fatalf("#%d: expected %q, got %q", i, tt.expected, buf.String())
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := fr.WriteCSV(&buf, WriteOptions{Quote: QuoteOption_None}); err == nil {
+		t.Fatal("expected error")
+	}
+	if err := fr.WriteCSV(&buf, WriteOptions{Delimiter: '"'}); err == nil {
+		t.Fatal("expected error")
+	}
+}
+
+func TestWriteCSVReadBack(t *testing.T) {
+	fr, schema, err := NewFromCSVInfer(nil, "testdata/bench-01-etcd-timeseries.csv", InferOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	buf.WriteString("generated by bench\n# comment\n")
+	if err := fr.WriteCSV(&buf, WriteOptions{Delimiter: '\t'}); err != nil {
+		t.Fatal(err)
+	}
+
+	fr2, err := NewFromReader(&buf, ReadOptions{Schema: &schema, Delimiter: '\t', Comment: '#', SkipLines: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	h1, rows1 := fr.Rows()
+	h2, rows2 := fr2.Rows()
+	if strings.Join(h1, ",") != strings.Join(h2, ",") || len(rows1) != len(rows2) {
+		t.Fatalf("expected %q with %d rows, got %q with %d rows", h1, len(rows1), h2, len(rows2))
+	}
+	for i := range rows1 {
+		if strings.Join(rows1[i], ",") != strings.Join(rows2[i], ",") {
+			t.Fatalf("row %d expected %q, got %q", i, rows1[i], rows2[i])
+		}
+	}
+
+	fr3, err := NewFromReader(strings.NewReader("a, b\n1, \"x\"y\n"), ReadOptions{TrimLeadingSpace: true, LazyQuotes: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hs := fr3.Headers(); strings.Join(hs, ",") != "a,b" {
+		t.Fatalf("unexpected headers %q", hs)
+	}
+}
@@ -0,0 +1,838 @@
+package dataframe
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/bits"
+)
+
+// zstd implements the Zstandard format (RFC 8878), which Parquet
+// uses for ZSTD compressed pages. Dictionaries are not supported.
+
+var errZstdCorrupt = errors.New("zstd: corrupt input")
+
+const (
+	zstdMagic          = 0xFD2FB528
+	zstdSkippableMagic = 0x184D2A50 // 0x184D2A50 to 0x184D2A5F
+
+	zstdBlockRaw        = 0
+	zstdBlockRLE        = 1
+	zstdBlockCompressed = 2
+
+	zstdMaxBlockSize = 1 << 17
+
+	zstdLiteralsRaw        = 0
+	zstdLiteralsRLE        = 1
+	zstdLiteralsCompressed = 2
+	zstdLiteralsTreeless   = 3
+
+	zstdModePredefined = 0
+	zstdModeRLE        = 1
+	zstdModeFSE        = 2
+	zstdModeRepeat     = 3
+)
+
+// zstdCode is the baseline and the number of extra bits of a literals
+// length, match length or offset code.
+type zstdCode struct {
+	baseline uint32
+	bits     uint8
+}
+
+var zstdLiteralsLengthCodes = [36]zstdCode{
+	{0, 0}, {1, 0}, {2, 0}, {3, 0}, {4, 0}, {5, 0}, {6, 0}, {7, 0},
+	{8, 0}, {9, 0}, {10, 0}, {11, 0}, {12, 0}, {13, 0}, {14, 0}, {15, 0},
+	{16, 1}, {18, 1}, {20, 1}, {22, 1}, {24, 2}, {28, 2}, {32, 3}, {40, 3},
+	{48, 4}, {64, 6}, {128, 7}, {256, 8}, {512, 9}, {1024, 10}, {2048, 11}, {4096, 12},
+	{8192, 13}, {16384, 14}, {32768, 15}, {65536, 16},
+}
+
+var zstdMatchLengthCodes = [53]zstdCode{
+	{3, 0}, {4, 0}, {5, 0}, {6, 0}, {7, 0}, {8, 0}, {9, 0}, {10, 0},
+	{11, 0}, {12, 0}, {13, 0}, {14, 0}, {15, 0}, {16, 0}, {17, 0}, {18, 0},
+	{19, 0}, {20, 0}, {21, 0}, {22, 0}, {23, 0}, {24, 0}, {25, 0}, {26, 0},
+	{27, 0}, {28, 0}, {29, 0}, {30, 0}, {31, 0}, {32, 0}, {33, 0}, {34, 0},
+	{35, 1}, {37, 1}, {39, 1}, {41, 1}, {43, 2}, {47, 2}, {51, 3}, {59, 3},
+	{67, 4}, {83, 4}, {99, 5}, {131, 7}, {259, 8}, {515, 9}, {1027, 10}, {2051, 11},
+	{4099, 12}, {8195, 13}, {16387, 14}, {32771, 15}, {65539, 16},
+}
+
+// predefined distributions of the symbols
+var (
+	zstdLiteralsLengthDefault = []int16{
+		4, 3, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 1, 1, 1,
+		2, 2, 2, 2, 2, 2, 2, 2, 2, 3, 2, 1, 1, 1, 1, 1,
+		-1, -1, -1, -1,
+	}
+	zstdMatchLengthDefault = []int16{
+		1, 4, 3, 2, 2, 2, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, -1, -1,
+		-1, -1, -1, -1, -1,
+	}
+	zstdOffsetDefault = []int16{
+		1, 1, 1, 1, 1, 1, 2, 2, 2, 1, 1, 1, 1, 1, 1, 1,
+		1, 1, 1, 1, 1, 1, 1, 1, -1, -1, -1, -1, -1,
+	}
+)
+
+const (
+	zstdLiteralsLengthDefaultLog = 6
+	zstdMatchLengthDefaultLog    = 6
+	zstdOffsetDefaultLog         = 5
+)
+
+// revBitReader reads a bitstream backward, from the highest bit
+// below the end marker of the last byte down to the first bit.
+type revBitReader struct {
+	data []byte
+
+	// pos is the number of bits left. It is negative after
+	// reading past the beginning of the stream.
+	pos int
+}
+
+func newRevBitReader(data []byte) (*revBitReader, error) {
+	if len(data) == 0 || data[len(data)-1] == 0 {
+		return nil, errZstdCorrupt
+	}
+	last := data[len(data)-1]
+	return &revBitReader{data: data, pos: (len(data)-1)*8 + bits.Len8(last) - 1}, nil
+}
+
+// extract returns n bits (n <= 56) from the bit position start.
+func (br *revBitReader) extract(start, n int) uint64 {
+	idx := start >> 3
+	var buf [8]byte
+	copy(buf[:], br.data[idx:])
+	v := binary.LittleEndian.Uint64(buf[:]) >> uint(start&7)
+	return v & (1<<uint(n) - 1)
+}
+
+// peek returns the next n bits without consuming them.
+// Missing bits past the beginning of the stream are zeros.
+func (br *revBitReader) peek(n int) uint64 {
+	switch {
+	case n == 0:
+		return 0
+	case br.pos >= n:
+		return br.extract(br.pos-n, n)
+	case br.pos <= 0:
+		return 0
+	default:
+		return br.extract(0, br.pos) << uint(n-br.pos)
+	}
+}
+
+func (br *revBitReader) read(n int) uint64 {
+	v := br.peek(n)
+	br.pos -= n
+	return v
+}
+
+// fseEntry is an entry of the FSE decoding table.
+type fseEntry struct {
+	symbol   uint8
+	nbBits   uint8
+	baseline uint16
+}
+
+type fseTable struct {
+	log     int
+	entries []fseEntry
+}
+
+// readFSECounts reads the normalized counts of an FSE table description,
+// and returns the number of bytes read.
+func readFSECounts(src []byte, maxSymbol, maxLog int) (counts []int16, log, n int, err error) {
+	bitPos := 0
+	peek := func() uint32 {
+		idx := bitPos >> 3
+		var buf [4]byte
+		if idx < len(src) {
+			copy(buf[:], src[idx:])
+		}
+		return binary.LittleEndian.Uint32(buf[:]) >> uint(bitPos&7)
+	}
+	if len(src) == 0 {
+		return nil, 0, 0, errZstdCorrupt
+	}
+
+	log = int(src[0]&0x0F) + 5
+	if log > maxLog {
+		return nil, 0, 0, fmt.Errorf("zstd: FSE table log %d is too large", log)
+	}
+	bitPos = 4
+	remaining := 1<<uint(log) + 1
+	threshold := 1 << uint(log)
+	nbBits := log + 1
+	previous0 := false
+	for remaining > 1 && len(counts) <= maxSymbol {
+		if previous0 {
+			n0 := len(counts)
+			for peek()&0xFFFF == 0xFFFF {
+				n0 += 24
+				bitPos += 16
+			}
+			for peek()&3 == 3 {
+				n0 += 3
+				bitPos += 2
+			}
+			n0 += int(peek() & 3)
+			bitPos += 2
+			if n0 > maxSymbol+1 {
+				return nil, 0, 0, errZstdCorrupt
+			}
+			for len(counts) < n0 {
+				counts = append(counts, 0)
+			}
+			if len(counts) > maxSymbol {
+				break
+			}
+		}
+
+		max := (2*threshold - 1) - remaining
+		var count int
+		if bs := int(peek()); bs&(threshold-1) < max {
+			count = bs & (threshold - 1)
+			bitPos += nbBits - 1
+		} else {
+			count = bs & (2*threshold - 1)
+			if count >= threshold {
+				count -= max
+			}
+			bitPos += nbBits
+		}
+		count-- // -1 is a "less than 1" probability
+		if count < 0 {
+			remaining += count
+		} else {
+			remaining -= count
+		}
+		counts = append(counts, int16(count))
+		previous0 = count == 0
+		for remaining < threshold {
+			nbBits--
+			threshold >>= 1
+		}
+		if bitPos > len(src)*8 {
+			return nil, 0, 0, errZstdCorrupt
+		}
+	}
+	if remaining != 1 {
+		return nil, 0, 0, errZstdCorrupt
+	}
+	return counts, log, (bitPos + 7) >> 3, nil
+}
+
+// fseSpread returns the symbol of each state.
+func fseSpread(counts []int16, log int) []uint8 {
+	size := 1 << uint(log)
+	symbols := make([]uint8, size)
+	high := size - 1
+	for s, c := range counts {
+		if c == -1 {
+			symbols[high] = uint8(s)
+			high--
+		}
+	}
+	step := size>>1 + size>>3 + 3
+	mask := size - 1
+	pos := 0
+	for s, c := range counts {
+		for i := 0; i < int(c); i++ {
+			symbols[pos] = uint8(s)
+			pos = (pos + step) & mask
+			for pos > high {
+				pos = (pos + step) & mask
+			}
+		}
+	}
+	return symbols
+}
+
+func newFSETable(counts []int16, log int) *fseTable {
+	size := 1 << uint(log)
+	symbols := fseSpread(counts, log)
+	next := make([]int, len(counts))
+	for s, c := range counts {
+		if c == -1 {
+			next[s] = 1
+		} else {
+			next[s] = int(c)
+		}
+	}
+	t := &fseTable{log: log, entries: make([]fseEntry, size)}
+	for u, s := range symbols {
+		n := next[s]
+		next[s]++
+		nb := log - (bits.Len(uint(n)) - 1)
+		t.entries[u] = fseEntry{symbol: s, nbBits: uint8(nb), baseline: uint16(n<<uint(nb) - size)}
+	}
+	return t
+}
+
+func newFSETableRLE(symbol uint8) *fseTable {
+	return &fseTable{entries: []fseEntry{{symbol: symbol}}}
+}
+
+// huffEntry is an entry of the Huffman decoding table.
+type huffEntry struct {
+	symbol uint8
+	nbBits uint8
+}
+
+type huffTable struct {
+	log     int
+	entries []huffEntry
+}
+
+const zstdHuffMaxLog = 11
+
+// readHuffTable reads the Huffman tree description,
+// and returns the number of bytes read.
+func readHuffTable(src []byte) (*huffTable, int, error) {
+	if len(src) == 0 {
+		return nil, 0, errZstdCorrupt
+	}
+	var weights []uint8
+	hd := int(src[0])
+	n := 1
+	if hd < 128 {
+		// FSE compressed weights
+		if len(src) < 1+hd {
+			return nil, 0, errZstdCorrupt
+		}
+		data := src[1 : 1+hd]
+		counts, log, k, err := readFSECounts(data, 255, 6)
+		if err != nil {
+			return nil, 0, err
+		}
+		table := newFSETable(counts, log)
+		br, err := newRevBitReader(data[k:])
+		if err != nil {
+			return nil, 0, err
+		}
+		state1 := int(br.read(log))
+		state2 := int(br.read(log))
+		decode := func(state *int) {
+			e := table.entries[*state]
+			weights = append(weights, e.symbol)
+			*state = int(e.baseline) + int(br.read(int(e.nbBits)))
+		}
+		for {
+			if len(weights) > 254 {
+				return nil, 0, errZstdCorrupt
+			}
+			decode(&state1)
+			if br.pos < 0 {
+				weights = append(weights, table.entries[state2].symbol)
+				break
+			}
+			decode(&state2)
+			if br.pos < 0 {
+				weights = append(weights, table.entries[state1].symbol)
+				break
+			}
+		}
+		n += hd
+	} else {
+		// 4 bits per weight
+		nw := hd - 127
+		nb := (nw + 1) / 2
+		if len(src) < 1+nb {
+			return nil, 0, errZstdCorrupt
+		}
+		for i := 0; i < nw; i++ {
+			b := src[1+i/2]
+			if i%2 == 0 {
+				weights = append(weights, b>>4)
+			} else {
+				weights = append(weights, b&0x0F)
+			}
+		}
+		n += nb
+	}
+
+	// the weight of the last symbol is implied
+	total := 0
+	for _, w := range weights {
+		if w > zstdHuffMaxLog {
+			return nil, 0, errZstdCorrupt
+		}
+		if w > 0 {
+			total += 1 << (w - 1)
+		}
+	}
+	if total == 0 {
+		return nil, 0, errZstdCorrupt
+	}
+	log := bits.Len(uint(total))
+	if log > zstdHuffMaxLog {
+		return nil, 0, errZstdCorrupt
+	}
+	left := 1<<uint(log) - total
+	if left&(left-1) != 0 {
+		return nil, 0, errZstdCorrupt
+	}
+	weights = append(weights, uint8(bits.Len(uint(left))))
+
+	// symbols of the lowest weight take the first entries
+	var rankStart [zstdHuffMaxLog + 2]int
+	for _, w := range weights {
+		if w > 0 {
+			rankStart[w] += 1 << (w - 1)
+		}
+	}
+	next := 0
+	for w := 1; w <= log; w++ {
+		cur := next
+		next += rankStart[w]
+		rankStart[w] = cur
+	}
+	t := &huffTable{log: log, entries: make([]huffEntry, 1<<uint(log))}
+	for s, w := range weights {
+		if w == 0 {
+			continue
+		}
+		length := 1 << (w - 1)
+		e := huffEntry{symbol: uint8(s), nbBits: uint8(log + 1 - int(w))}
+		for i := rankStart[w]; i < rankStart[w]+length; i++ {
+			t.entries[i] = e
+		}
+		rankStart[w] += length
+	}
+	return t, n, nil
+}
+
+func (t *huffTable) decodeStream(dst, src []byte, n int) ([]byte, error) {
+	br, err := newRevBitReader(src)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < n; i++ {
+		e := t.entries[br.peek(t.log)]
+		dst = append(dst, e.symbol)
+		br.pos -= int(e.nbBits)
+	}
+	if br.pos != 0 {
+		return nil, errZstdCorrupt
+	}
+	return dst, nil
+}
+
+// zstdDecoder keeps the state that is shared by the blocks of a frame.
+type zstdDecoder struct {
+	huff                     *huffTable
+	litLength, offset, match *fseTable
+	reps                     [3]int
+	literals                 []byte
+}
+
+// zstdDecode decodes all the frames in src.
+func zstdDecode(src []byte) ([]byte, error) {
+	var dst []byte
+	for len(src) > 0 {
+		if len(src) < 4 {
+			return nil, errZstdCorrupt
+		}
+		magic := binary.LittleEndian.Uint32(src)
+		if magic&0xFFFFFFF0 == zstdSkippableMagic {
+			if len(src) < 8 {
+				return nil, errZstdCorrupt
+			}
+			size := int(binary.LittleEndian.Uint32(src[4:]))
+			if len(src) < 8+size {
+				return nil, errZstdCorrupt
+			}
+			src = src[8+size:]
+			continue
+		}
+		if magic != zstdMagic {
+			return nil, errors.New("zstd: invalid magic number")
+		}
+		var err error
+		dst, src, err = zstdDecodeFrame(dst, src[4:])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return dst, nil
+}
+
+func zstdDecodeFrame(dst, src []byte) ([]byte, []byte, error) {
+	if len(src) < 1 {
+		return nil, nil, errZstdCorrupt
+	}
+	fhd := src[0]
+	src = src[1:]
+	fcsFlag := fhd >> 6
+	single := fhd&0x20 != 0
+	checksum := fhd&0x04 != 0
+	if fhd&0x08 != 0 {
+		return nil, nil, errZstdCorrupt
+	}
+	dictIDSize := [4]int{0, 1, 2, 4}[fhd&0x03]
+	fcsSize := [4]int{0, 2, 4, 8}[fcsFlag]
+	if fcsFlag == 0 && single {
+		fcsSize = 1
+	}
+	hdrSize := dictIDSize + fcsSize
+	if !single {
+		hdrSize++ // window descriptor
+	}
+	if len(src) < hdrSize {
+		return nil, nil, errZstdCorrupt
+	}
+	if !single {
+		src = src[1:]
+	}
+	for _, b := range src[:dictIDSize] {
+		if b != 0 {
+			return nil, nil, errors.New("zstd: dictionaries are not supported")
+		}
+	}
+	src = src[dictIDSize:]
+	var contentSize uint64
+	switch fcsSize {
+	case 1:
+		contentSize = uint64(src[0])
+	case 2:
+		contentSize = uint64(binary.LittleEndian.Uint16(src)) + 256
+	case 4:
+		contentSize = uint64(binary.LittleEndian.Uint32(src))
+	case 8:
+		contentSize = binary.LittleEndian.Uint64(src)
+	}
+	src = src[fcsSize:]
+	// each block of at most zstdMaxBlockSize bytes has a 3 byte header
+	if fcsSize > 0 && contentSize < 1<<30 && contentSize <= uint64(len(src)/3)*zstdMaxBlockSize {
+		dst = append(make([]byte, 0, len(dst)+int(contentSize)), dst...)
+	}
+
+	start := len(dst)
+	d := &zstdDecoder{reps: [3]int{1, 4, 8}}
+	for {
+		if len(src) < 3 {
+			return nil, nil, errZstdCorrupt
+		}
+		hd := uint32(src[0]) | uint32(src[1])<<8 | uint32(src[2])<<16
+		src = src[3:]
+		last := hd&1 != 0
+		size := int(hd >> 3)
+		switch (hd >> 1) & 3 {
+		case zstdBlockRaw:
+			if len(src) < size {
+				return nil, nil, errZstdCorrupt
+			}
+			dst = append(dst, src[:size]...)
+			src = src[size:]
+		case zstdBlockRLE:
+			if len(src) < 1 {
+				return nil, nil, errZstdCorrupt
+			}
+			for i := 0; i < size; i++ {
+				dst = append(dst, src[0])
+			}
+			src = src[1:]
+		case zstdBlockCompressed:
+			if len(src) < size || size > zstdMaxBlockSize {
+				return nil, nil, errZstdCorrupt
+			}
+			var err error
+			dst, err = d.decodeBlock(dst, start, src[:size])
+			if err != nil {
+				return nil, nil, err
+			}
+			src = src[size:]
+		default:
+			return nil, nil, errZstdCorrupt
+		}
+		if last {
+			break
+		}
+	}
+	if fcsSize > 0 && uint64(len(dst)-start) != contentSize {
+		return nil, nil, errZstdCorrupt
+	}
+	if checksum {
+		if len(src) < 4 {
+			return nil, nil, errZstdCorrupt
+		}
+		src = src[4:]
+	}
+	return dst, src, nil
+}
+
+func (d *zstdDecoder) decodeBlock(dst []byte, start int, src []byte) ([]byte, error) {
+	n, err := d.decodeLiterals(src)
+	if err != nil {
+		return nil, err
+	}
+	src = src[n:]
+
+	if len(src) < 1 {
+		return nil, errZstdCorrupt
+	}
+	nseq := int(src[0])
+	switch {
+	case nseq < 128:
+		src = src[1:]
+	case nseq < 255:
+		if len(src) < 2 {
+			return nil, errZstdCorrupt
+		}
+		nseq = (nseq-128)<<8 + int(src[1])
+		src = src[2:]
+	default:
+		if len(src) < 3 {
+			return nil, errZstdCorrupt
+		}
+		nseq = int(src[1]) + int(src[2])<<8 + 0x7F00
+		src = src[3:]
+	}
+	if nseq == 0 {
+		return append(dst, d.literals...), nil
+	}
+
+	if len(src) < 1 {
+		return nil, errZstdCorrupt
+	}
+	modes := src[0]
+	src = src[1:]
+	for _, tb := range []struct {
+		table      **fseTable
+		mode       byte
+		defaults   []int16
+		defaultLog int
+		maxSymbol  int
+		maxLog     int
+	}{
+		{&d.litLength, modes >> 6, zstdLiteralsLengthDefault, zstdLiteralsLengthDefaultLog, 35, 9},
+		{&d.offset, (modes >> 4) & 3, zstdOffsetDefault, zstdOffsetDefaultLog, 31, 8},
+		{&d.match, (modes >> 2) & 3, zstdMatchLengthDefault, zstdMatchLengthDefaultLog, 52, 9},
+	} {
+		switch tb.mode {
+		case zstdModePredefined:
+			*tb.table = newFSETable(tb.defaults, tb.defaultLog)
+		case zstdModeRLE:
+			if len(src) < 1 || int(src[0]) > tb.maxSymbol {
+				return nil, errZstdCorrupt
+			}
+			*tb.table = newFSETableRLE(src[0])
+			src = src[1:]
+		case zstdModeFSE:
+			counts, log, k, err := readFSECounts(src, tb.maxSymbol, tb.maxLog)
+			if err != nil {
+				return nil, err
+			}
+			*tb.table = newFSETable(counts, log)
+			src = src[k:]
+		case zstdModeRepeat:
+			if *tb.table == nil {
+				return nil, errZstdCorrupt
+			}
+		}
+	}
+	return d.execSequences(dst, start, src, nseq)
+}
+
+func (d *zstdDecoder) decodeLiterals(src []byte) (int, error) {
+	if len(src) < 1 {
+		return 0, errZstdCorrupt
+	}
+	tp := src[0] & 3
+	sf := (src[0] >> 2) & 3
+	var hdr, regen, comp int
+	switch tp {
+	case zstdLiteralsRaw, zstdLiteralsRLE:
+		switch sf {
+		case 0, 2:
+			hdr, regen = 1, int(src[0]>>3)
+		case 1:
+			if len(src) < 2 {
+				return 0, errZstdCorrupt
+			}
+			hdr, regen = 2, int(src[0]>>4)+int(src[1])<<4
+		case 3:
+			if len(src) < 3 {
+				return 0, errZstdCorrupt
+			}
+			hdr, regen = 3, int(src[0]>>4)+int(src[1])<<4+int(src[2])<<12
+		}
+		if tp == zstdLiteralsRaw {
+			if len(src) < hdr+regen {
+				return 0, errZstdCorrupt
+			}
+			d.literals = append(d.literals[:0], src[hdr:hdr+regen]...)
+			return hdr + regen, nil
+		}
+		if len(src) < hdr+1 {
+			return 0, errZstdCorrupt
+		}
+		d.literals = d.literals[:0]
+		for i := 0; i < regen; i++ {
+			d.literals = append(d.literals, src[hdr])
+		}
+		return hdr + 1, nil
+	}
+
+	streams := 4
+	switch sf {
+	case 0, 1:
+		if len(src) < 3 {
+			return 0, errZstdCorrupt
+		}
+		if sf == 0 {
+			streams = 1
+		}
+		hdr = 3
+		v := int(src[0]>>4) | int(src[1])<<4 | int(src[2])<<12
+		regen, comp = v&0x3FF, v>>10
+	case 2:
+		if len(src) < 4 {
+			return 0, errZstdCorrupt
+		}
+		hdr = 4
+		v := int(src[0]>>4) | int(src[1])<<4 | int(src[2])<<12 | int(src[3])<<20
+		regen, comp = v&0x3FFF, v>>14
+	case 3:
+		if len(src) < 5 {
+			return 0, errZstdCorrupt
+		}
+		hdr = 5
+		v := int(src[0]>>4) | int(src[1])<<4 | int(src[2])<<12 | int(src[3])<<20 | int(src[4])<<28
+		regen, comp = v&0x3FFFF, v>>18
+	}
+	if len(src) < hdr+comp || regen > zstdMaxBlockSize {
+		return 0, errZstdCorrupt
+	}
+	data := src[hdr : hdr+comp]
+	if tp == zstdLiteralsCompressed {
+		t, n, err := readHuffTable(data)
+		if err != nil {
+			return 0, err
+		}
+		d.huff = t
+		data = data[n:]
+	} else if d.huff == nil {
+		return 0, errZstdCorrupt
+	}
+
+	lits := d.literals[:0]
+	var err error
+	if streams == 1 {
+		lits, err = d.huff.decodeStream(lits, data, regen)
+	} else {
+		if len(data) < 6 {
+			return 0, errZstdCorrupt
+		}
+		sizes := [4]int{
+			int(binary.LittleEndian.Uint16(data)),
+			int(binary.LittleEndian.Uint16(data[2:])),
+			int(binary.LittleEndian.Uint16(data[4:])),
+		}
+		data = data[6:]
+		sizes[3] = len(data) - sizes[0] - sizes[1] - sizes[2]
+		if sizes[3] < 0 {
+			return 0, errZstdCorrupt
+		}
+		each := (regen + 3) / 4
+		for i, size := range sizes {
+			n := each
+			if i == 3 {
+				n = regen - 3*each
+			}
+			if n < 0 {
+				return 0, errZstdCorrupt
+			}
+			lits, err = d.huff.decodeStream(lits, data[:size], n)
+			if err != nil {
+				return 0, err
+			}
+			data = data[size:]
+		}
+	}
+	if err != nil {
+		return 0, err
+	}
+	d.literals = lits
+	return hdr + comp, nil
+}
+
+func (d *zstdDecoder) execSequences(dst []byte, start int, src []byte, nseq int) ([]byte, error) {
+	br, err := newRevBitReader(src)
+	if err != nil {
+		return nil, err
+	}
+	llState := int(br.read(d.litLength.log))
+	ofState := int(br.read(d.offset.log))
+	mlState := int(br.read(d.match.log))
+
+	lits := d.literals
+	for i := 0; i < nseq; i++ {
+		ofCode := d.offset.entries[ofState].symbol
+		mlCode := d.match.entries[mlState].symbol
+		llCode := d.litLength.entries[llState].symbol
+		if ofCode > 31 || int(mlCode) >= len(zstdMatchLengthCodes) || int(llCode) >= len(zstdLiteralsLengthCodes) {
+			return nil, errZstdCorrupt
+		}
+
+		ofValue := int(1)<<ofCode + int(br.read(int(ofCode)))
+		mc := zstdMatchLengthCodes[mlCode]
+		ml := int(mc.baseline) + int(br.read(int(mc.bits)))
+		lc := zstdLiteralsLengthCodes[llCode]
+		ll := int(lc.baseline) + int(br.read(int(lc.bits)))
+
+		var offset int
+		if ofValue > 3 {
+			offset = ofValue - 3
+			d.reps = [3]int{offset, d.reps[0], d.reps[1]}
+		} else {
+			idx := ofValue - 1
+			if ll == 0 {
+				idx++
+			}
+			switch idx {
+			case 0:
+				offset = d.reps[0]
+			case 1:
+				offset = d.reps[1]
+				d.reps[0], d.reps[1] = offset, d.reps[0]
+			case 2:
+				offset = d.reps[2]
+				d.reps = [3]int{offset, d.reps[0], d.reps[1]}
+			case 3:
+				offset = d.reps[0] - 1
+				d.reps = [3]int{offset, d.reps[0], d.reps[1]}
+			}
+		}
+
+		if ll > len(lits) {
+			return nil, errZstdCorrupt
+		}
+		dst = append(dst, lits[:ll]...)
+		lits = lits[ll:]
+		if offset <= 0 || offset > len(dst)-start {
+			return nil, errZstdCorrupt
+		}
+		for j := len(dst) - offset; ml > 0; j, ml = j+1, ml-1 {
+			dst = append(dst, dst[j])
+		}
+
+		if i == nseq-1 {
+			break
+		}
+		e := d.litLength.entries[llState]
+		llState = int(e.baseline) + int(br.read(int(e.nbBits)))
+		e = d.match.entries[mlState]
+		mlState = int(e.baseline) + int(br.read(int(e.nbBits)))
+		e = d.offset.entries[ofState]
+		ofState = int(e.baseline) + int(br.read(int(e.nbBits)))
+	}
+	if br.pos != 0 {
+		return nil, errZstdCorrupt
+	}
+	return append(dst, lits...), nil
+}
@@ -0,0 +1,319 @@
+package dataframe
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// zstdEncode compresses src in a single Zstandard frame. It finds matches
+// with a hash table, and encodes the sequences with the predefined FSE
+// tables and the literals uncompressed, which is fast and simple while
+// still compressing repetitive column data well.
+func zstdEncode(src []byte) []byte {
+	dst := make([]byte, 0, len(src)/2+32)
+	dst = binary.LittleEndian.AppendUint32(dst, zstdMagic)
+
+	// single segment, with the frame content size
+	switch n := uint64(len(src)); {
+	case n < 256:
+		dst = append(dst, 0x20, byte(n))
+	case n < 65536+256:
+		dst = append(dst, 1<<6|0x20)
+		dst = binary.LittleEndian.AppendUint16(dst, uint16(n-256))
+	case n < 1<<32:
+		dst = append(dst, 2<<6|0x20)
+		dst = binary.LittleEndian.AppendUint32(dst, uint32(n))
+	default:
+		dst = append(dst, 3<<6|0x20)
+		dst = binary.LittleEndian.AppendUint64(dst, n)
+	}
+
+	e := newZstdEncoder()
+	if len(src) == 0 {
+		return append(dst, 1, 0, 0) // last empty raw block
+	}
+	for pos := 0; pos < len(src); {
+		end := pos + zstdMaxBlockSize
+		if end > len(src) {
+			end = len(src)
+		}
+		last := end == len(src)
+		dst = e.encodeBlock(dst, src, pos, end, last)
+		pos = end
+	}
+	return dst
+}
+
+const (
+	zstdHashBits  = 16
+	zstdMinMatch  = 4
+	zstdMaxOffset = 1 << 24
+)
+
+type zstdSequence struct {
+	litLength, matchLength, offset int
+}
+
+// fseEncoder encodes symbols with an FSE table.
+type fseEncoder struct {
+	log    int
+	states []uint16
+
+	// deltaNbBits and deltaFindState by symbol
+	deltaNbBits    []uint32
+	deltaFindState []int32
+}
+
+func newFSEEncoder(counts []int16, log int) *fseEncoder {
+	size := 1 << uint(log)
+	symbols := fseSpread(counts, log)
+
+	cumul := make([]int, len(counts)+1)
+	for s, c := range counts {
+		if c == -1 {
+			c = 1
+		}
+		cumul[s+1] = cumul[s] + int(c)
+	}
+	enc := &fseEncoder{
+		log:            log,
+		states:         make([]uint16, size),
+		deltaNbBits:    make([]uint32, len(counts)),
+		deltaFindState: make([]int32, len(counts)),
+	}
+	for u, s := range symbols {
+		enc.states[cumul[s]] = uint16(size + u)
+		cumul[s]++
+	}
+
+	total := 0
+	for s, c := range counts {
+		switch c {
+		case 0:
+			enc.deltaNbBits[s] = uint32((log+1)<<16 - size)
+		case -1, 1:
+			enc.deltaNbBits[s] = uint32(log<<16 - size)
+			enc.deltaFindState[s] = int32(total - 1)
+			total++
+		default:
+			maxBitsOut := log - (bits.Len(uint(c-1)) - 1)
+			minStatePlus := int(c) << uint(maxBitsOut)
+			enc.deltaNbBits[s] = uint32(maxBitsOut<<16 - minStatePlus)
+			enc.deltaFindState[s] = int32(total - int(c))
+			total += int(c)
+		}
+	}
+	return enc
+}
+
+func (enc *fseEncoder) init(symbol uint8) uint32 {
+	nbBitsOut := (enc.deltaNbBits[symbol] + 1<<15) >> 16
+	value := nbBitsOut<<16 - enc.deltaNbBits[symbol]
+	return uint32(enc.states[int32(value>>nbBitsOut)+enc.deltaFindState[symbol]])
+}
+
+func (enc *fseEncoder) encode(bw *bitWriter, state uint32, symbol uint8) uint32 {
+	nbBitsOut := (state + enc.deltaNbBits[symbol]) >> 16
+	bw.write(uint64(state), int(nbBitsOut))
+	return uint32(enc.states[int32(state>>nbBitsOut)+enc.deltaFindState[symbol]])
+}
+
+// bitWriter writes bits from the lowest bit of each byte.
+type bitWriter struct {
+	buf   []byte
+	acc   uint64
+	nbits int
+}
+
+func (bw *bitWriter) write(v uint64, n int) {
+	if n == 0 {
+		return
+	}
+	bw.acc |= (v & (1<<uint(n) - 1)) << uint(bw.nbits)
+	bw.nbits += n
+	for bw.nbits >= 8 {
+		bw.buf = append(bw.buf, byte(bw.acc))
+		bw.acc >>= 8
+		bw.nbits -= 8
+	}
+}
+
+// close writes the end marker bit, and pads the last byte.
+func (bw *bitWriter) close() []byte {
+	bw.write(1, 1)
+	if bw.nbits > 0 {
+		bw.buf = append(bw.buf, byte(bw.acc))
+	}
+	return bw.buf
+}
+
+type zstdEncoder struct {
+	table                    []int32
+	litLength, offset, match *fseEncoder
+	seqs                     []zstdSequence
+	literals                 []byte
+}
+
+func newZstdEncoder() *zstdEncoder {
+	e := &zstdEncoder{
+		table:     make([]int32, 1<<zstdHashBits),
+		litLength: newFSEEncoder(zstdLiteralsLengthDefault, zstdLiteralsLengthDefaultLog),
+		offset:    newFSEEncoder(zstdOffsetDefault, zstdOffsetDefaultLog),
+		match:     newFSEEncoder(zstdMatchLengthDefault, zstdMatchLengthDefaultLog),
+	}
+	for i := range e.table {
+		e.table[i] = -1
+	}
+	return e
+}
+
+func zstdHash(u uint32) uint32 {
+	return (u * 2654435761) >> (32 - zstdHashBits)
+}
+
+// encodeBlock encodes src[pos:end], where src[:pos] is the history
+// that matches can refer to.
+func (e *zstdEncoder) encodeBlock(dst, src []byte, pos, end int, last bool) []byte {
+	e.seqs = e.seqs[:0]
+	e.literals = e.literals[:0]
+	lit := pos
+	for s := pos; s+zstdMinMatch <= end; {
+		cur := binary.LittleEndian.Uint32(src[s:])
+		h := zstdHash(cur)
+		cand := int(e.table[h])
+		e.table[h] = int32(s)
+		if cand < 0 || s-cand > zstdMaxOffset || binary.LittleEndian.Uint32(src[cand:]) != cur {
+			s++
+			continue
+		}
+		mend := s + zstdMinMatch
+		for mend < end && src[mend] == src[mend-s+cand] {
+			mend++
+		}
+		for s > lit && cand > 0 && src[s-1] == src[cand-1] {
+			s--
+			cand--
+		}
+		e.seqs = append(e.seqs, zstdSequence{litLength: s - lit, matchLength: mend - s, offset: s - cand})
+		e.literals = append(e.literals, src[lit:s]...)
+		for i := s + 1; i < mend && i+4 <= end; i += 2 {
+			e.table[zstdHash(binary.LittleEndian.Uint32(src[i:]))] = int32(i)
+		}
+		s, lit = mend, mend
+	}
+	e.literals = append(e.literals, src[lit:end]...)
+
+	hd := len(dst)
+	dst = append(dst, 0, 0, 0)
+	dst = appendZstdLiteralsHeader(dst, len(e.literals))
+	dst = append(dst, e.literals...)
+	dst = e.appendSequences(dst)
+
+	size := len(dst) - hd - 3
+	tp := zstdBlockCompressed
+	if size >= end-pos {
+		// not compressible
+		dst = append(dst[:hd+3], src[pos:end]...)
+		size, tp = end-pos, zstdBlockRaw
+	}
+	v := uint32(size)<<3 | uint32(tp)<<1
+	if last {
+		v |= 1
+	}
+	dst[hd], dst[hd+1], dst[hd+2] = byte(v), byte(v>>8), byte(v>>16)
+	return dst
+}
+
+func appendZstdLiteralsHeader(dst []byte, n int) []byte {
+	switch {
+	case n < 32:
+		return append(dst, byte(n)<<3|zstdLiteralsRaw)
+	case n < 4096:
+		return append(dst, byte(n)<<4|1<<2|zstdLiteralsRaw, byte(n>>4))
+	default:
+		return append(dst, byte(n)<<4|3<<2|zstdLiteralsRaw, byte(n>>4), byte(n>>12))
+	}
+}
+
+func zstdLiteralsLengthCode(ll int) uint8 {
+	if ll < 16 {
+		return uint8(ll)
+	}
+	code := uint8(16)
+	for code+1 < uint8(len(zstdLiteralsLengthCodes)) && int(zstdLiteralsLengthCodes[code+1].baseline) <= ll {
+		code++
+	}
+	return code
+}
+
+func zstdMatchLengthCode(ml int) uint8 {
+	if ml-3 < 32 {
+		return uint8(ml - 3)
+	}
+	code := uint8(32)
+	for code+1 < uint8(len(zstdMatchLengthCodes)) && int(zstdMatchLengthCodes[code+1].baseline) <= ml {
+		code++
+	}
+	return code
+}
+
+func (e *zstdEncoder) appendSequences(dst []byte) []byte {
+	n := len(e.seqs)
+	switch {
+	case n < 128:
+		dst = append(dst, byte(n))
+	case n < 0x7F00:
+		dst = append(dst, byte(n>>8)+128, byte(n))
+	default:
+		dst = append(dst, 255, byte(n-0x7F00), byte((n-0x7F00)>>8))
+	}
+	if n == 0 {
+		return dst
+	}
+	dst = append(dst, zstdModePredefined) // all predefined tables
+
+	type code struct {
+		ll, ml, of          uint8
+		llExtra, mlExtra    uint64
+		ofExtra             uint64
+		llBits, mlBits, ofB int
+	}
+	toCode := func(sq zstdSequence) code {
+		ll, ml := zstdLiteralsLengthCode(sq.litLength), zstdMatchLengthCode(sq.matchLength)
+		ofValue := sq.offset + 3
+		of := uint8(bits.Len(uint(ofValue)) - 1)
+		return code{
+			ll: ll, ml: ml, of: of,
+			llExtra: uint64(sq.litLength) - uint64(zstdLiteralsLengthCodes[ll].baseline),
+			mlExtra: uint64(sq.matchLength) - uint64(zstdMatchLengthCodes[ml].baseline),
+			ofExtra: uint64(ofValue) - 1<<of,
+			llBits:  int(zstdLiteralsLengthCodes[ll].bits),
+			mlBits:  int(zstdMatchLengthCodes[ml].bits),
+			ofB:     int(of),
+		}
+	}
+
+	// sequences are written from the last, so that
+	// the decoder reads them from the first
+	bw := &bitWriter{buf: dst}
+	c := toCode(e.seqs[n-1])
+	mlState := e.match.init(c.ml)
+	ofState := e.offset.init(c.of)
+	llState := e.litLength.init(c.ll)
+	bw.write(c.llExtra, c.llBits)
+	bw.write(c.mlExtra, c.mlBits)
+	bw.write(c.ofExtra, c.ofB)
+	for i := n - 2; i >= 0; i-- {
+		c = toCode(e.seqs[i])
+		ofState = e.offset.encode(bw, ofState, c.of)
+		mlState = e.match.encode(bw, mlState, c.ml)
+		llState = e.litLength.encode(bw, llState, c.ll)
+		bw.write(c.llExtra, c.llBits)
+		bw.write(c.mlExtra, c.mlBits)
+		bw.write(c.ofExtra, c.ofB)
+	}
+	bw.write(uint64(mlState), e.match.log)
+	bw.write(uint64(ofState), e.offset.log)
+	bw.write(uint64(llState), e.litLength.log)
+	return bw.close()
+}